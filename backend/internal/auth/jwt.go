@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"os"
 	"time"
@@ -16,8 +18,24 @@ type Claims struct {
 
 var jwtSecret []byte
 
-// InitJWT initializes the JWT secret from environment variable
-// Should be called at application startup
+// jwtPreviousSecret is the optional secret being rotated out (JWT_SECRET_PREVIOUS)
+// Tokens signed with it are still accepted so a JWT_SECRET rotation doesn't log everyone out;
+// remove it once the access token TTL has passed since the rotation
+var jwtPreviousSecret []byte
+
+// jwtIssuer and jwtAudience are the optional iss/aud claims (JWT_ISSUER, JWT_AUDIENCE)
+// When unset, tokens are issued without the claim and it is not validated
+var (
+	jwtIssuer   string
+	jwtAudience string
+)
+
+// denylistEnabled turns on the revoked access token check (ACCESS_TOKEN_DENYLIST=true)
+// Off by default since it adds a database lookup to every authenticated request
+var denylistEnabled bool
+
+// InitJWT initializes the JWT secrets, issuer and audience from environment variables
+// Should be called at application startup; on error nothing is changed
 func InitJWT() error {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
@@ -26,10 +44,41 @@ func InitJWT() error {
 	if len(secret) < 32 {
 		return errors.New("JWT_SECRET must be at least 32 characters long")
 	}
+	previous := os.Getenv("JWT_SECRET_PREVIOUS")
+	if previous != "" && len(previous) < 32 {
+		return errors.New("JWT_SECRET_PREVIOUS must be at least 32 characters long")
+	}
 	jwtSecret = []byte(secret)
+	jwtPreviousSecret = nil
+	if previous != "" {
+		jwtPreviousSecret = []byte(previous)
+	}
+	jwtIssuer = os.Getenv("JWT_ISSUER")
+	jwtAudience = os.Getenv("JWT_AUDIENCE")
+	denylistEnabled = os.Getenv("ACCESS_TOKEN_DENYLIST") == "true"
 	return nil
 }
 
+// Ready reports whether InitJWT has loaded a signing secret, i.e. whether tokens can be issued and validated
+// Only a boolean is exposed so health checks can report it without revealing the secret
+func Ready() bool {
+	return len(jwtSecret) > 0
+}
+
+// DenylistEnabled reports whether access tokens must be checked against the revoked_access_tokens denylist
+func DenylistEnabled() bool {
+	return denylistEnabled
+}
+
+// newTokenID returns a random 32-character hex token ID, used as the jti claim
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // GetJWTSecret returns the JWT secret (for testing purposes)
 func GetJWTSecret() []byte {
 	return jwtSecret
@@ -41,15 +90,26 @@ func GenerateAccessToken(userID int32, expiration time.Duration) (string, error)
 		return "", errors.New("JWT secret not initialized. Call InitJWT() first")
 	}
 
+	// jti identifies this token so it can be revoked before it expires
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now()
 	claims := &Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    jwtIssuer,
 		},
 	}
+	if jwtAudience != "" {
+		claims.Audience = jwt.ClaimStrings{jwtAudience}
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(jwtSecret)
@@ -61,17 +121,30 @@ func GenerateAccessToken(userID int32, expiration time.Duration) (string, error)
 }
 
 // ValidateAccessToken validates and parses a JWT access token (used only by legacy test middleware).
+// Tokens signed with the current secret or, during a rotation, JWT_SECRET_PREVIOUS are accepted
 func ValidateAccessToken(tokenString string) (*Claims, error) {
 	if len(jwtSecret) == 0 {
 		return nil, errors.New("JWT secret not initialized. Call InitJWT() first")
 	}
 
+	// Only check iss/aud when configured, so tokens issued before they were set keep working
+	var opts []jwt.ParserOption
+	if jwtIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(jwtIssuer))
+	}
+	if jwtAudience != "" {
+		opts = append(opts, jwt.WithAudience(jwtAudience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
+		if len(jwtPreviousSecret) > 0 {
+			return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{jwtSecret, jwtPreviousSecret}}, nil
+		}
 		return jwtSecret, nil
-	})
+	}, opts...)
 	if err != nil {
 		return nil, err
 	}
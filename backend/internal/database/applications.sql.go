@@ -9,11 +9,115 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+const archiveApplicationByIDAndUserID = `-- name: ArchiveApplicationByIDAndUserID :one
+UPDATE applications
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
+`
+
+type ArchiveApplicationByIDAndUserIDParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+// Archive an application and return the updated record (verifies ownership via user_id)
+// Archiving an archived application keeps its original archived_at
+func (q *Queries) ArchiveApplicationByIDAndUserID(ctx context.Context, arg ArchiveApplicationByIDAndUserIDParams) (Application, error) {
+	row := q.db.QueryRowContext(ctx, archiveApplicationByIDAndUserID, arg.ID, arg.UserID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.AppliedDate,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContactID,
+		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
+	)
+	return i, err
+}
+
+const archiveApplicationsByIDsAndUserID = `-- name: ArchiveApplicationsByIDsAndUserID :many
+UPDATE applications
+SET archived_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY($1::int[]) AND user_id = $2 AND archived_at IS NULL
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
+`
+
+type ArchiveApplicationsByIDsAndUserIDParams struct {
+	Ids    []int32 `json:"ids"`
+	UserID int32   `json:"user_id"`
+}
+
+// Archive the user's applications among the given IDs and return the archived records
+// IDs of other users' applications and already archived ones are silently skipped
+func (q *Queries) ArchiveApplicationsByIDsAndUserID(ctx context.Context, arg ArchiveApplicationsByIDsAndUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, archiveApplicationsByIDsAndUserID, pq.Array(arg.Ids), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countApplicationsByStatusAndUserID = `-- name: CountApplicationsByStatusAndUserID :one
 SELECT COUNT(*) FROM applications
-WHERE status = $1 AND user_id = $2
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL
 `
 
 type CountApplicationsByStatusAndUserIDParams struct {
@@ -31,7 +135,7 @@ func (q *Queries) CountApplicationsByStatusAndUserID(ctx context.Context, arg Co
 
 const countApplicationsByUserID = `-- name: CountApplicationsByUserID :one
 SELECT COUNT(*) FROM applications
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 `
 
 // Get total count of applications for a specific user
@@ -42,23 +146,110 @@ func (q *Queries) CountApplicationsByUserID(ctx context.Context, userID int32) (
 	return count, err
 }
 
+const countApplicationsFilteredByUserID = `-- name: CountApplicationsFilteredByUserID :one
+SELECT COUNT(*) FROM applications
+WHERE user_id = $1
+  AND ($2::text IS NULL OR status = $2)
+  AND ($3::text IS NULL OR source = $3)
+  AND (NOT $4::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id))
+  AND (NOT $5::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = applications.id AND d.type = 'resume'))
+  AND (NOT $6::boolean OR COALESCE(cover_letter, '') = '')
+  AND ($7::boolean OR archived_at IS NULL)
+`
+
+type CountApplicationsFilteredByUserIDParams struct {
+	UserID             int32          `json:"user_id"`
+	Status             sql.NullString `json:"status"`
+	Source             sql.NullString `json:"source"`
+	MissingJob         bool           `json:"missing_job"`
+	MissingResume      bool           `json:"missing_resume"`
+	MissingCoverLetter bool           `json:"missing_cover_letter"`
+	IncludeArchived    bool           `json:"include_archived"`
+}
+
+// Get total count of applications for a specific user with the same optional filters
+func (q *Queries) CountApplicationsFilteredByUserID(ctx context.Context, arg CountApplicationsFilteredByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countApplicationsFilteredByUserID,
+		arg.UserID,
+		arg.Status,
+		arg.Source,
+		arg.MissingJob,
+		arg.MissingResume,
+		arg.MissingCoverLetter,
+		arg.IncludeArchived,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchApplicationsByUserID = `-- name: CountSearchApplicationsByUserID :one
+SELECT COUNT(*) FROM applications
+WHERE user_id = $1
+  AND ($2::text[] IS NULL OR status = ANY($2::text[]))
+  AND ($3::text IS NULL OR source = $3)
+  AND ($4::int IS NULL OR EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id AND j.company_id = $4))
+  AND ($5::int IS NULL OR EXISTS (SELECT 1 FROM application_tags apt WHERE apt.application_id = applications.id AND apt.tag_id = $5))
+  AND ($6::date IS NULL OR applied_date >= $6)
+  AND ($7::date IS NULL OR applied_date <= $7)
+  AND ($8::boolean OR archived_at IS NULL)
+`
+
+type CountSearchApplicationsByUserIDParams struct {
+	UserID          int32          `json:"user_id"`
+	Statuses        []string       `json:"statuses"`
+	Source          sql.NullString `json:"source"`
+	CompanyID       sql.NullInt32  `json:"company_id"`
+	TagID           sql.NullInt32  `json:"tag_id"`
+	AppliedFrom     sql.NullTime   `json:"applied_from"`
+	AppliedTo       sql.NullTime   `json:"applied_to"`
+	IncludeArchived bool           `json:"include_archived"`
+}
+
+// Get total count of applications matching SearchApplicationsByUserID's filters
+func (q *Queries) CountSearchApplicationsByUserID(ctx context.Context, arg CountSearchApplicationsByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchApplicationsByUserID,
+		arg.UserID,
+		pq.Array(arg.Statuses),
+		arg.Source,
+		arg.CompanyID,
+		arg.TagID,
+		arg.AppliedFrom,
+		arg.AppliedTo,
+		arg.IncludeArchived,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createApplication = `-- name: CreateApplication :one
-INSERT INTO applications (status, applied_date, notes, contact_id, user_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id
+INSERT INTO applications (status, applied_date, notes, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, cover_letter)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
 `
 
 type CreateApplicationParams struct {
-	Status      string         `json:"status"`
-	AppliedDate time.Time      `json:"applied_date"`
-	Notes       sql.NullString `json:"notes"`
-	ContactID   sql.NullInt32  `json:"contact_id"`
-	UserID      int32          `json:"user_id"`
+	Status              string         `json:"status"`
+	AppliedDate         time.Time      `json:"applied_date"`
+	Notes               sql.NullString `json:"notes"`
+	ContactID           sql.NullInt32  `json:"contact_id"`
+	UserID              int32          `json:"user_id"`
+	Source              sql.NullString `json:"source"`
+	NextAction          sql.NullString `json:"next_action"`
+	NextActionDue       sql.NullTime   `json:"next_action_due"`
+	OfferSalary         sql.NullInt64  `json:"offer_salary"`
+	OfferCurrency       sql.NullString `json:"offer_currency"`
+	OfferReceivedDate   sql.NullTime   `json:"offer_received_date"`
+	Decision            sql.NullString `json:"decision"`
+	ReferredByContactID sql.NullInt32  `json:"referred_by_contact_id"`
+	ClosedReason        sql.NullString `json:"closed_reason"`
+	CoverLetter         sql.NullString `json:"cover_letter"`
 }
 
 // Create a new application and return the created record
 // Note: job_id is no longer needed, jobs will reference applications
-// contact_id is optional
+// contact_id, source, next_action/next_action_due, the offer fields, referred_by_contact_id, closed_reason and cover_letter are optional
 func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationParams) (Application, error) {
 	row := q.db.QueryRowContext(ctx, createApplication,
 		arg.Status,
@@ -66,6 +257,16 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		arg.Notes,
 		arg.ContactID,
 		arg.UserID,
+		arg.Source,
+		arg.NextAction,
+		arg.NextActionDue,
+		arg.OfferSalary,
+		arg.OfferCurrency,
+		arg.OfferReceivedDate,
+		arg.Decision,
+		arg.ReferredByContactID,
+		arg.ClosedReason,
+		arg.CoverLetter,
 	)
 	var i Application
 	err := row.Scan(
@@ -77,6 +278,18 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		&i.UpdatedAt,
 		&i.ContactID,
 		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
 	)
 	return i, err
 }
@@ -98,7 +311,7 @@ func (q *Queries) DeleteApplication(ctx context.Context, arg DeleteApplicationPa
 }
 
 const getApplicationByIDAndUserID = `-- name: GetApplicationByIDAndUserID :one
-SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id FROM applications
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
 WHERE id = $1 AND user_id = $2
 `
 
@@ -120,13 +333,80 @@ func (q *Queries) GetApplicationByIDAndUserID(ctx context.Context, arg GetApplic
 		&i.UpdatedAt,
 		&i.ContactID,
 		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
 	)
 	return i, err
 }
 
+const getApplicationsByIDsAndUserID = `-- name: GetApplicationsByIDsAndUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE id = ANY($1::int[]) AND user_id = $2
+`
+
+type GetApplicationsByIDsAndUserIDParams struct {
+	Ids    []int32 `json:"ids"`
+	UserID int32   `json:"user_id"`
+}
+
+// Get the user's applications among the given IDs (IDs of other users are silently skipped)
+func (q *Queries) GetApplicationsByIDsAndUserID(ctx context.Context, arg GetApplicationsByIDsAndUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationsByIDsAndUserID, pq.Array(arg.Ids), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getApplicationsByStatusAndUserID = `-- name: GetApplicationsByStatusAndUserID :many
-SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id FROM applications
-WHERE status = $1 AND user_id = $2
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 `
 
@@ -154,6 +434,18 @@ func (q *Queries) GetApplicationsByStatusAndUserID(ctx context.Context, arg GetA
 			&i.UpdatedAt,
 			&i.ContactID,
 			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
 		); err != nil {
 			return nil, err
 		}
@@ -169,8 +461,8 @@ func (q *Queries) GetApplicationsByStatusAndUserID(ctx context.Context, arg GetA
 }
 
 const getApplicationsByStatusAndUserIDPaginated = `-- name: GetApplicationsByStatusAndUserIDPaginated :many
-SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id FROM applications
-WHERE status = $1 AND user_id = $2
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -206,6 +498,18 @@ func (q *Queries) GetApplicationsByStatusAndUserIDPaginated(ctx context.Context,
 			&i.UpdatedAt,
 			&i.ContactID,
 			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
 		); err != nil {
 			return nil, err
 		}
@@ -221,8 +525,8 @@ func (q *Queries) GetApplicationsByStatusAndUserIDPaginated(ctx context.Context,
 }
 
 const getApplicationsByUserID = `-- name: GetApplicationsByUserID :many
-SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id FROM applications
-WHERE user_id = $1
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 `
 
@@ -245,6 +549,18 @@ func (q *Queries) GetApplicationsByUserID(ctx context.Context, userID int32) ([]
 			&i.UpdatedAt,
 			&i.ContactID,
 			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
 		); err != nil {
 			return nil, err
 		}
@@ -260,8 +576,8 @@ func (q *Queries) GetApplicationsByUserID(ctx context.Context, userID int32) ([]
 }
 
 const getApplicationsByUserIDPaginated = `-- name: GetApplicationsByUserIDPaginated :many
-SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id FROM applications
-WHERE user_id = $1
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -291,6 +607,351 @@ func (q *Queries) GetApplicationsByUserIDPaginated(ctx context.Context, arg GetA
 			&i.UpdatedAt,
 			&i.ContactID,
 			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicationsFilteredByUserID = `-- name: GetApplicationsFilteredByUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1
+  AND ($2::text IS NULL OR status = $2)
+  AND ($3::text IS NULL OR source = $3)
+  AND (NOT $4::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id))
+  AND (NOT $5::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = applications.id AND d.type = 'resume'))
+  AND (NOT $6::boolean OR COALESCE(cover_letter, '') = '')
+  AND ($7::boolean OR archived_at IS NULL)
+ORDER BY
+  CASE WHEN $8::text = 'applied_date_asc' THEN applied_date END ASC,
+  CASE WHEN $8::text = 'applied_date_desc' THEN applied_date END DESC,
+  CASE WHEN $8::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN $8::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN $8::text = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN $8::text = 'updated_at_desc' THEN updated_at END DESC NULLS LAST,
+  CASE WHEN $8::text = 'last_activity_asc' THEN last_activity_at END ASC,
+  CASE WHEN $8::text = 'last_activity_desc' THEN last_activity_at END DESC NULLS LAST,
+  updated_at DESC NULLS LAST, created_at DESC
+LIMIT $9 OFFSET $10
+`
+
+type GetApplicationsFilteredByUserIDParams struct {
+	UserID             int32          `json:"user_id"`
+	Status             sql.NullString `json:"status"`
+	Source             sql.NullString `json:"source"`
+	MissingJob         bool           `json:"missing_job"`
+	MissingResume      bool           `json:"missing_resume"`
+	MissingCoverLetter bool           `json:"missing_cover_letter"`
+	IncludeArchived    bool           `json:"include_archived"`
+	SortKey            string         `json:"sort_key"`
+	RowLimit           sql.NullInt32  `json:"row_limit"`
+	RowOffset          int32          `json:"row_offset"`
+}
+
+// Get applications for a specific user with optional filters (a NULL filter is not applied)
+// missing_job/missing_resume/missing_cover_letter keep only applications without a job/resume document/cover letter; archived applications are skipped unless include_archived is true
+// row_limit NULL returns all rows (LIMIT NULL), otherwise paginates with row_offset
+// sort_key picks the order (e.g. created_at_desc); other values keep the default order
+func (q *Queries) GetApplicationsFilteredByUserID(ctx context.Context, arg GetApplicationsFilteredByUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationsFilteredByUserID,
+		arg.UserID,
+		arg.Status,
+		arg.Source,
+		arg.MissingJob,
+		arg.MissingResume,
+		arg.MissingCoverLetter,
+		arg.IncludeArchived,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicationsReferredByContactIDAndUserID = `-- name: GetApplicationsReferredByContactIDAndUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE referred_by_contact_id = $1 AND user_id = $2
+ORDER BY applied_date DESC, id DESC
+`
+
+type GetApplicationsReferredByContactIDAndUserIDParams struct {
+	ReferredByContactID sql.NullInt32 `json:"referred_by_contact_id"`
+	UserID              int32         `json:"user_id"`
+}
+
+// Get the applications a contact referred the user for, most recently applied first
+func (q *Queries) GetApplicationsReferredByContactIDAndUserID(ctx context.Context, arg GetApplicationsReferredByContactIDAndUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationsReferredByContactIDAndUserID, arg.ReferredByContactID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicationsWithDueNextActionByUserID = `-- name: GetApplicationsWithDueNextActionByUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
+  AND next_action IS NOT NULL
+  AND next_action_due <= $2
+ORDER BY next_action_due ASC, id ASC
+`
+
+type GetApplicationsWithDueNextActionByUserIDParams struct {
+	UserID        int32        `json:"user_id"`
+	NextActionDue sql.NullTime `json:"next_action_due"`
+}
+
+// Get applications whose next action is due on or before the given date (overdue first)
+func (q *Queries) GetApplicationsWithDueNextActionByUserID(ctx context.Context, arg GetApplicationsWithDueNextActionByUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationsWithDueNextActionByUserID, arg.UserID, arg.NextActionDue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicationsWithFlagsFilteredByUserID = `-- name: GetApplicationsWithFlagsFilteredByUserID :many
+SELECT a.id, a.status, a.applied_date, a.notes, a.created_at, a.updated_at, a.contact_id, a.user_id, a.source, a.next_action, a.next_action_due, a.offer_salary, a.offer_currency, a.offer_received_date, a.decision, a.referred_by_contact_id, a.closed_reason, a.archived_at, a.cover_letter, a.last_activity_at,
+  EXISTS(SELECT 1 FROM jobs j WHERE j.application_id = a.id) AS has_job,
+  EXISTS(SELECT 1 FROM documents d WHERE d.application_id = a.id AND d.type = 'resume') AS has_resume,
+  EXISTS(SELECT 1 FROM contacts ct WHERE ct.id = a.contact_id) AS has_contact
+FROM applications a
+WHERE a.user_id = $1
+  AND ($2::text IS NULL OR a.status = $2)
+  AND ($3::text IS NULL OR a.source = $3)
+  AND (NOT $4::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = a.id))
+  AND (NOT $5::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = a.id AND d.type = 'resume'))
+  AND (NOT $6::boolean OR COALESCE(a.cover_letter, '') = '')
+  AND ($7::boolean OR a.archived_at IS NULL)
+ORDER BY
+  CASE WHEN $8::text = 'applied_date_asc' THEN a.applied_date END ASC,
+  CASE WHEN $8::text = 'applied_date_desc' THEN a.applied_date END DESC,
+  CASE WHEN $8::text = 'created_at_asc' THEN a.created_at END ASC,
+  CASE WHEN $8::text = 'created_at_desc' THEN a.created_at END DESC,
+  CASE WHEN $8::text = 'updated_at_asc' THEN a.updated_at END ASC,
+  CASE WHEN $8::text = 'updated_at_desc' THEN a.updated_at END DESC NULLS LAST,
+  CASE WHEN $8::text = 'last_activity_asc' THEN a.last_activity_at END ASC,
+  CASE WHEN $8::text = 'last_activity_desc' THEN a.last_activity_at END DESC NULLS LAST,
+  a.updated_at DESC NULLS LAST, a.created_at DESC
+LIMIT $9 OFFSET $10
+`
+
+type GetApplicationsWithFlagsFilteredByUserIDParams struct {
+	UserID             int32          `json:"user_id"`
+	Status             sql.NullString `json:"status"`
+	Source             sql.NullString `json:"source"`
+	MissingJob         bool           `json:"missing_job"`
+	MissingResume      bool           `json:"missing_resume"`
+	MissingCoverLetter bool           `json:"missing_cover_letter"`
+	IncludeArchived    bool           `json:"include_archived"`
+	SortKey            string         `json:"sort_key"`
+	RowLimit           sql.NullInt32  `json:"row_limit"`
+	RowOffset          int32          `json:"row_offset"`
+}
+
+type GetApplicationsWithFlagsFilteredByUserIDRow struct {
+	ID                  int32          `json:"id"`
+	Status              string         `json:"status"`
+	AppliedDate         time.Time      `json:"applied_date"`
+	Notes               sql.NullString `json:"notes"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	UpdatedAt           sql.NullTime   `json:"updated_at"`
+	ContactID           sql.NullInt32  `json:"contact_id"`
+	UserID              int32          `json:"user_id"`
+	Source              sql.NullString `json:"source"`
+	NextAction          sql.NullString `json:"next_action"`
+	NextActionDue       sql.NullTime   `json:"next_action_due"`
+	OfferSalary         sql.NullInt64  `json:"offer_salary"`
+	OfferCurrency       sql.NullString `json:"offer_currency"`
+	OfferReceivedDate   sql.NullTime   `json:"offer_received_date"`
+	Decision            sql.NullString `json:"decision"`
+	ReferredByContactID sql.NullInt32  `json:"referred_by_contact_id"`
+	ClosedReason        sql.NullString `json:"closed_reason"`
+	ArchivedAt          sql.NullTime   `json:"archived_at"`
+	CoverLetter         sql.NullString `json:"cover_letter"`
+	LastActivityAt      sql.NullTime   `json:"last_activity_at"`
+	HasJob              bool           `json:"has_job"`
+	HasResume           bool           `json:"has_resume"`
+	HasContact          bool           `json:"has_contact"`
+}
+
+// Same as GetApplicationsFilteredByUserID plus flags telling whether a job, resume document or contact is attached
+// sort_key picks the order (e.g. created_at_desc); other values keep the default order
+func (q *Queries) GetApplicationsWithFlagsFilteredByUserID(ctx context.Context, arg GetApplicationsWithFlagsFilteredByUserIDParams) ([]GetApplicationsWithFlagsFilteredByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationsWithFlagsFilteredByUserID,
+		arg.UserID,
+		arg.Status,
+		arg.Source,
+		arg.MissingJob,
+		arg.MissingResume,
+		arg.MissingCoverLetter,
+		arg.IncludeArchived,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApplicationsWithFlagsFilteredByUserIDRow
+	for rows.Next() {
+		var i GetApplicationsWithFlagsFilteredByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+			&i.HasJob,
+			&i.HasResume,
+			&i.HasContact,
 		); err != nil {
 			return nil, err
 		}
@@ -306,7 +967,7 @@ func (q *Queries) GetApplicationsByUserIDPaginated(ctx context.Context, arg GetA
 }
 
 const getJobByApplicationIDAndUserID = `-- name: GetJobByApplicationIDAndUserID :one
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE j.application_id = $1 AND a.user_id = $2
 `
@@ -330,39 +991,386 @@ func (q *Queries) GetJobByApplicationIDAndUserID(ctx context.Context, arg GetJob
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApplicationID,
+		&i.EmploymentType,
+	)
+	return i, err
+}
+
+const getStaleApplicationsByUserID = `-- name: GetStaleApplicationsByUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
+  AND status = 'applied'
+  AND applied_date < $2
+ORDER BY applied_date ASC, id ASC
+`
+
+type GetStaleApplicationsByUserIDParams struct {
+	UserID      int32     `json:"user_id"`
+	AppliedDate time.Time `json:"applied_date"`
+}
+
+// Get applications still in "applied" whose applied_date is before the given date (oldest first)
+func (q *Queries) GetStaleApplicationsByUserID(ctx context.Context, arg GetStaleApplicationsByUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getStaleApplicationsByUserID, arg.UserID, arg.AppliedDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsedApplicationStatusesByUserID = `-- name: GetUsedApplicationStatusesByUserID :many
+SELECT DISTINCT status FROM applications
+WHERE user_id = $1
+ORDER BY status ASC
+`
+
+// Get the distinct statuses present in a user's applications, in alphabetical order
+func (q *Queries) GetUsedApplicationStatusesByUserID(ctx context.Context, userID int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getUsedApplicationStatusesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			return nil, err
+		}
+		items = append(items, status)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignApplicationContactByUserID = `-- name: ReassignApplicationContactByUserID :execrows
+UPDATE applications
+SET contact_id = CASE WHEN contact_id = $1::int THEN $2::int ELSE contact_id END,
+    referred_by_contact_id = CASE WHEN referred_by_contact_id = $1::int THEN $2::int ELSE referred_by_contact_id END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $3
+  AND (contact_id = $1::int OR referred_by_contact_id = $1::int)
+`
+
+type ReassignApplicationContactByUserIDParams struct {
+	FromContactID int32 `json:"from_contact_id"`
+	ToContactID   int32 `json:"to_contact_id"`
+	UserID        int32 `json:"user_id"`
+}
+
+// Point the user's applications linked to one contact (as contact or referrer) at another and return how many changed
+// Ownership of both contacts must be verified before calling this
+func (q *Queries) ReassignApplicationContactByUserID(ctx context.Context, arg ReassignApplicationContactByUserIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignApplicationContactByUserID, arg.FromContactID, arg.ToContactID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchApplicationsByUserID = `-- name: SearchApplicationsByUserID :many
+SELECT id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at FROM applications
+WHERE user_id = $1
+  AND ($2::text[] IS NULL OR status = ANY($2::text[]))
+  AND ($3::text IS NULL OR source = $3)
+  AND ($4::int IS NULL OR EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id AND j.company_id = $4))
+  AND ($5::int IS NULL OR EXISTS (SELECT 1 FROM application_tags apt WHERE apt.application_id = applications.id AND apt.tag_id = $5))
+  AND ($6::date IS NULL OR applied_date >= $6)
+  AND ($7::date IS NULL OR applied_date <= $7)
+  AND ($8::boolean OR archived_at IS NULL)
+ORDER BY
+  CASE WHEN $9::text = 'applied_date_asc' THEN applied_date END ASC,
+  CASE WHEN $9::text = 'applied_date_desc' THEN applied_date END DESC,
+  CASE WHEN $9::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN $9::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN $9::text = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN $9::text = 'updated_at_desc' THEN updated_at END DESC NULLS LAST,
+  CASE WHEN $9::text = 'last_activity_asc' THEN last_activity_at END ASC,
+  CASE WHEN $9::text = 'last_activity_desc' THEN last_activity_at END DESC NULLS LAST,
+  updated_at DESC NULLS LAST, created_at DESC
+LIMIT $10 OFFSET $11
+`
+
+type SearchApplicationsByUserIDParams struct {
+	UserID          int32          `json:"user_id"`
+	Statuses        []string       `json:"statuses"`
+	Source          sql.NullString `json:"source"`
+	CompanyID       sql.NullInt32  `json:"company_id"`
+	TagID           sql.NullInt32  `json:"tag_id"`
+	AppliedFrom     sql.NullTime   `json:"applied_from"`
+	AppliedTo       sql.NullTime   `json:"applied_to"`
+	IncludeArchived bool           `json:"include_archived"`
+	SortKey         string         `json:"sort_key"`
+	RowLimit        int32          `json:"row_limit"`
+	RowOffset       int32          `json:"row_offset"`
+}
+
+// Search a user's applications with optional filters combined with AND (a NULL filter is not applied)
+// statuses matches any of the given statuses; company_id and tag_id match through the application's job and tags
+// applied_from/applied_to bound applied_date (inclusive); sort_key orders like GetApplicationsFilteredByUserID
+// archived applications are skipped unless include_archived is true
+func (q *Queries) SearchApplicationsByUserID(ctx context.Context, arg SearchApplicationsByUserIDParams) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, searchApplicationsByUserID,
+		arg.UserID,
+		pq.Array(arg.Statuses),
+		arg.Source,
+		arg.CompanyID,
+		arg.TagID,
+		arg.AppliedFrom,
+		arg.AppliedTo,
+		arg.IncludeArchived,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.AppliedDate,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContactID,
+			&i.UserID,
+			&i.Source,
+			&i.NextAction,
+			&i.NextActionDue,
+			&i.OfferSalary,
+			&i.OfferCurrency,
+			&i.OfferReceivedDate,
+			&i.Decision,
+			&i.ReferredByContactID,
+			&i.ClosedReason,
+			&i.ArchivedAt,
+			&i.CoverLetter,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const snoozeNextActionByIDAndUserID = `-- name: SnoozeNextActionByIDAndUserID :one
+UPDATE applications
+SET next_action_due = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND user_id = $3 AND next_action IS NOT NULL
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
+`
+
+type SnoozeNextActionByIDAndUserIDParams struct {
+	NextActionDue sql.NullTime `json:"next_action_due"`
+	ID            int32        `json:"id"`
+	UserID        int32        `json:"user_id"`
+}
+
+// Move an application's next action due date and return the updated record (verifies ownership via user_id)
+// Only applications that have a next action are updated
+func (q *Queries) SnoozeNextActionByIDAndUserID(ctx context.Context, arg SnoozeNextActionByIDAndUserIDParams) (Application, error) {
+	row := q.db.QueryRowContext(ctx, snoozeNextActionByIDAndUserID, arg.NextActionDue, arg.ID, arg.UserID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.AppliedDate,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContactID,
+		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
+	)
+	return i, err
+}
+
+const unarchiveApplicationByIDAndUserID = `-- name: UnarchiveApplicationByIDAndUserID :one
+UPDATE applications
+SET archived_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
+`
+
+type UnarchiveApplicationByIDAndUserIDParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+// Unarchive an application and return the updated record (verifies ownership via user_id)
+func (q *Queries) UnarchiveApplicationByIDAndUserID(ctx context.Context, arg UnarchiveApplicationByIDAndUserIDParams) (Application, error) {
+	row := q.db.QueryRowContext(ctx, unarchiveApplicationByIDAndUserID, arg.ID, arg.UserID)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.AppliedDate,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContactID,
+		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
 	)
 	return i, err
 }
 
 const updateApplication = `-- name: UpdateApplication :one
 UPDATE applications
-SET status = $2,
-    applied_date = $3,
-    notes = $4,
-    contact_id = $5,
+SET status = $1,
+    applied_date = $2,
+    notes = $3,
+    contact_id = CASE WHEN $4::boolean THEN contact_id ELSE $5 END,
+    source = CASE WHEN $6::boolean THEN source ELSE $7 END,
+    next_action = CASE WHEN $8::boolean THEN next_action ELSE $9 END,
+    next_action_due = CASE WHEN $8::boolean THEN next_action_due ELSE $10 END,
+    offer_salary = CASE WHEN $11::boolean THEN offer_salary ELSE $12 END,
+    offer_currency = CASE WHEN $11::boolean THEN offer_currency ELSE $13 END,
+    offer_received_date = CASE WHEN $11::boolean THEN offer_received_date ELSE $14 END,
+    decision = CASE WHEN $11::boolean THEN decision ELSE $15 END,
+    referred_by_contact_id = CASE WHEN $16::boolean THEN referred_by_contact_id ELSE $17 END,
+    closed_reason = CASE WHEN $18::boolean THEN closed_reason ELSE $19 END,
+    cover_letter = CASE WHEN $20::boolean THEN cover_letter ELSE $21 END,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $6
-RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id
+WHERE id = $22 AND user_id = $23
+  AND ($24::timestamp IS NULL OR updated_at = $24)
+RETURNING id, status, applied_date, notes, created_at, updated_at, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, archived_at, cover_letter, last_activity_at
 `
 
 type UpdateApplicationParams struct {
-	ID          int32          `json:"id"`
-	Status      string         `json:"status"`
-	AppliedDate time.Time      `json:"applied_date"`
-	Notes       sql.NullString `json:"notes"`
-	ContactID   sql.NullInt32  `json:"contact_id"`
-	UserID      int32          `json:"user_id"`
+	Status              string         `json:"status"`
+	AppliedDate         time.Time      `json:"applied_date"`
+	Notes               sql.NullString `json:"notes"`
+	KeepContact         bool           `json:"keep_contact"`
+	ContactID           sql.NullInt32  `json:"contact_id"`
+	KeepSource          bool           `json:"keep_source"`
+	Source              sql.NullString `json:"source"`
+	KeepNextAction      bool           `json:"keep_next_action"`
+	NextAction          sql.NullString `json:"next_action"`
+	NextActionDue       sql.NullTime   `json:"next_action_due"`
+	KeepOffer           bool           `json:"keep_offer"`
+	OfferSalary         sql.NullInt64  `json:"offer_salary"`
+	OfferCurrency       sql.NullString `json:"offer_currency"`
+	OfferReceivedDate   sql.NullTime   `json:"offer_received_date"`
+	Decision            sql.NullString `json:"decision"`
+	KeepReferredBy      bool           `json:"keep_referred_by"`
+	ReferredByContactID sql.NullInt32  `json:"referred_by_contact_id"`
+	KeepClosedReason    bool           `json:"keep_closed_reason"`
+	ClosedReason        sql.NullString `json:"closed_reason"`
+	KeepCoverLetter     bool           `json:"keep_cover_letter"`
+	CoverLetter         sql.NullString `json:"cover_letter"`
+	ID                  int32          `json:"id"`
+	UserID              int32          `json:"user_id"`
+	ExpectedUpdatedAt   sql.NullTime   `json:"expected_updated_at"`
 }
 
 // Update an application and return the updated record (verifies ownership via user_id)
+// expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+// keep_contact leaves contact_id unchanged (the client omitted it); otherwise contact_id is set, NULL detaching the contact
+// The other keep_* flags do the same for their columns (keep_next_action covers next_action_due, keep_offer every offer column)
 func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationParams) (Application, error) {
 	row := q.db.QueryRowContext(ctx, updateApplication,
-		arg.ID,
 		arg.Status,
 		arg.AppliedDate,
 		arg.Notes,
+		arg.KeepContact,
 		arg.ContactID,
+		arg.KeepSource,
+		arg.Source,
+		arg.KeepNextAction,
+		arg.NextAction,
+		arg.NextActionDue,
+		arg.KeepOffer,
+		arg.OfferSalary,
+		arg.OfferCurrency,
+		arg.OfferReceivedDate,
+		arg.Decision,
+		arg.KeepReferredBy,
+		arg.ReferredByContactID,
+		arg.KeepClosedReason,
+		arg.ClosedReason,
+		arg.KeepCoverLetter,
+		arg.CoverLetter,
+		arg.ID,
 		arg.UserID,
+		arg.ExpectedUpdatedAt,
 	)
 	var i Application
 	err := row.Scan(
@@ -374,6 +1382,18 @@ func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationPa
 		&i.UpdatedAt,
 		&i.ContactID,
 		&i.UserID,
+		&i.Source,
+		&i.NextAction,
+		&i.NextActionDue,
+		&i.OfferSalary,
+		&i.OfferCurrency,
+		&i.OfferReceivedDate,
+		&i.Decision,
+		&i.ReferredByContactID,
+		&i.ClosedReason,
+		&i.ArchivedAt,
+		&i.CoverLetter,
+		&i.LastActivityAt,
 	)
 	return i, err
 }
@@ -8,14 +8,71 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+const archiveCompany = `-- name: ArchiveCompany :one
+UPDATE companies
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite
+`
+
+type ArchiveCompanyParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+// Archive a company and return the updated record (verifies ownership via user_id)
+// Archiving an archived company keeps its original archived_at
+func (q *Queries) ArchiveCompany(ctx context.Context, arg ArchiveCompanyParams) (Company, error) {
+	row := q.db.QueryRowContext(ctx, archiveCompany, arg.ID, arg.UserID)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
+	)
+	return i, err
+}
+
+const countActiveApplicationsByCompanyID = `-- name: CountActiveApplicationsByCompanyID :one
+SELECT COUNT(*) FROM applications a
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+  AND a.archived_at IS NULL
+`
+
+type CountActiveApplicationsByCompanyIDParams struct {
+	CompanyID int32 `json:"company_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+// Get how many of the user's applications for a company (through their jobs) are still active
+// Active means the application has not been archived
+func (q *Queries) CountActiveApplicationsByCompanyID(ctx context.Context, arg CountActiveApplicationsByCompanyIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveApplicationsByCompanyID, arg.CompanyID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countCompaniesByUserID = `-- name: CountCompaniesByUserID :one
 SELECT COUNT(*) FROM companies
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 `
 
-// Get total count of companies for a specific user
+// Get total count of non-archived companies for a specific user
 func (q *Queries) CountCompaniesByUserID(ctx context.Context, userID int32) (int64, error) {
 	row := q.db.QueryRowContext(ctx, countCompaniesByUserID, userID)
 	var count int64
@@ -23,21 +80,93 @@ func (q *Queries) CountCompaniesByUserID(ctx context.Context, userID int32) (int
 	return count, err
 }
 
+const countCompaniesFilteredByUserID = `-- name: CountCompaniesFilteredByUserID :one
+SELECT COUNT(*) FROM companies
+WHERE user_id = $1
+  AND ($2::text IS NULL OR LOWER(industry) = LOWER($2))
+  AND ($3::boolean OR archived_at IS NULL)
+  AND ($4::boolean IS NULL OR (COALESCE(website, '') <> '') = $4)
+  AND ($5::boolean IS NULL OR is_favorite = $5)
+`
+
+type CountCompaniesFilteredByUserIDParams struct {
+	UserID          int32          `json:"user_id"`
+	Industry        sql.NullString `json:"industry"`
+	IncludeArchived bool           `json:"include_archived"`
+	HasWebsite      sql.NullBool   `json:"has_website"`
+	Favorite        sql.NullBool   `json:"favorite"`
+}
+
+// Get total count of companies for a specific user with the same optional filters
+func (q *Queries) CountCompaniesFilteredByUserID(ctx context.Context, arg CountCompaniesFilteredByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCompaniesFilteredByUserID,
+		arg.UserID,
+		arg.Industry,
+		arg.IncludeArchived,
+		arg.HasWebsite,
+		arg.Favorite,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCompanyActivityByUserID = `-- name: CountCompanyActivityByUserID :one
+SELECT
+  (SELECT COUNT(*) FROM applications a
+   JOIN jobs j ON j.application_id = a.id
+   WHERE j.company_id = $1 AND a.user_id = $2)
+  + (SELECT COUNT(*) FROM application_status_history h
+     JOIN applications a ON a.id = h.application_id
+     JOIN jobs j ON j.application_id = a.id
+     WHERE j.company_id = $1 AND a.user_id = $2
+       AND EXISTS (
+         SELECT 1 FROM application_status_history earlier
+         WHERE earlier.application_id = h.application_id AND earlier.id < h.id
+       ))
+  + (SELECT COUNT(*) FROM interviews i
+     JOIN applications a ON a.id = i.application_id
+     JOIN jobs j ON j.application_id = a.id
+     WHERE j.company_id = $1 AND a.user_id = $2)
+  AS count
+`
+
+type CountCompanyActivityByUserIDParams struct {
+	CompanyID int32 `json:"company_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+// Get total count of events in GetCompanyActivityByUserID's timeline
+func (q *Queries) CountCompanyActivityByUserID(ctx context.Context, arg CountCompanyActivityByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCompanyActivityByUserID, arg.CompanyID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompany = `-- name: CreateCompany :one
-INSERT INTO companies (name, website, user_id)
-VALUES ($1, $2, $3)
-RETURNING id, name, website, created_at, updated_at, user_id
+INSERT INTO companies (name, website, user_id, industry, size)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite
 `
 
 type CreateCompanyParams struct {
-	Name    string         `json:"name"`
-	Website sql.NullString `json:"website"`
-	UserID  int32          `json:"user_id"`
+	Name     string         `json:"name"`
+	Website  sql.NullString `json:"website"`
+	UserID   int32          `json:"user_id"`
+	Industry sql.NullString `json:"industry"`
+	Size     sql.NullString `json:"size"`
 }
 
 // Create a new company and return the created record
 func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (Company, error) {
-	row := q.db.QueryRowContext(ctx, createCompany, arg.Name, arg.Website, arg.UserID)
+	row := q.db.QueryRowContext(ctx, createCompany,
+		arg.Name,
+		arg.Website,
+		arg.UserID,
+		arg.Industry,
+		arg.Size,
+	)
 	var i Company
 	err := row.Scan(
 		&i.ID,
@@ -46,6 +175,10 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
 	)
 	return i, err
 }
@@ -66,9 +199,54 @@ func (q *Queries) DeleteCompany(ctx context.Context, arg DeleteCompanyParams) er
 	return err
 }
 
+const getCompaniesByIDsAndUserID = `-- name: GetCompaniesByIDsAndUserID :many
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
+WHERE id = ANY($1::int[]) AND user_id = $2
+`
+
+type GetCompaniesByIDsAndUserIDParams struct {
+	Ids    []int32 `json:"ids"`
+	UserID int32   `json:"user_id"`
+}
+
+// Get the user's companies among the given IDs (IDs of other users are silently skipped)
+func (q *Queries) GetCompaniesByIDsAndUserID(ctx context.Context, arg GetCompaniesByIDsAndUserIDParams) ([]Company, error) {
+	rows, err := q.db.QueryContext(ctx, getCompaniesByIDsAndUserID, pq.Array(arg.Ids), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Company
+	for rows.Next() {
+		var i Company
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Industry,
+			&i.Size,
+			&i.ArchivedAt,
+			&i.IsFavorite,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCompaniesByUserID = `-- name: GetCompaniesByUserID :many
-SELECT id, name, website, created_at, updated_at, user_id FROM companies
-WHERE user_id = $1
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY name ASC
 `
 
@@ -89,6 +267,10 @@ func (q *Queries) GetCompaniesByUserID(ctx context.Context, userID int32) ([]Com
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
+			&i.Industry,
+			&i.Size,
+			&i.ArchivedAt,
+			&i.IsFavorite,
 		); err != nil {
 			return nil, err
 		}
@@ -104,8 +286,8 @@ func (q *Queries) GetCompaniesByUserID(ctx context.Context, userID int32) ([]Com
 }
 
 const getCompaniesByUserIDPaginated = `-- name: GetCompaniesByUserIDPaginated :many
-SELECT id, name, website, created_at, updated_at, user_id FROM companies
-WHERE user_id = $1
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY name ASC
 LIMIT $2 OFFSET $3
 `
@@ -133,6 +315,181 @@ func (q *Queries) GetCompaniesByUserIDPaginated(ctx context.Context, arg GetComp
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
+			&i.Industry,
+			&i.Size,
+			&i.ArchivedAt,
+			&i.IsFavorite,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompaniesFilteredByUserID = `-- name: GetCompaniesFilteredByUserID :many
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
+WHERE user_id = $1
+  AND ($2::text IS NULL OR LOWER(industry) = LOWER($2))
+  AND ($3::boolean OR archived_at IS NULL)
+  AND ($4::boolean IS NULL OR (COALESCE(website, '') <> '') = $4)
+  AND ($5::boolean IS NULL OR is_favorite = $5)
+ORDER BY
+  CASE WHEN $6::boolean THEN is_favorite END DESC,
+  CASE WHEN $7::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN $7::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN $7::text = 'name_asc' THEN name END ASC,
+  CASE WHEN $7::text = 'name_desc' THEN name END DESC,
+  name ASC, id ASC
+LIMIT $8 OFFSET $9
+`
+
+type GetCompaniesFilteredByUserIDParams struct {
+	UserID          int32          `json:"user_id"`
+	Industry        sql.NullString `json:"industry"`
+	IncludeArchived bool           `json:"include_archived"`
+	HasWebsite      sql.NullBool   `json:"has_website"`
+	Favorite        sql.NullBool   `json:"favorite"`
+	FavoritesFirst  bool           `json:"favorites_first"`
+	SortKey         string         `json:"sort_key"`
+	RowLimit        sql.NullInt32  `json:"row_limit"`
+	RowOffset       int32          `json:"row_offset"`
+}
+
+// Get companies for a specific user with optional filters (a NULL filter is not applied)
+// industry matches case-insensitively; archived companies are skipped unless include_archived is true
+// has_website true keeps companies with a non-empty website, false those without one
+// favorite true keeps starred companies, false the others
+// row_limit NULL returns all rows, otherwise paginates with row_offset
+// sort_key picks the order (e.g. created_at_desc); other values keep the default order
+// favorites_first lists starred companies before the others, each group in sort_key order
+func (q *Queries) GetCompaniesFilteredByUserID(ctx context.Context, arg GetCompaniesFilteredByUserIDParams) ([]Company, error) {
+	rows, err := q.db.QueryContext(ctx, getCompaniesFilteredByUserID,
+		arg.UserID,
+		arg.Industry,
+		arg.IncludeArchived,
+		arg.HasWebsite,
+		arg.Favorite,
+		arg.FavoritesFirst,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Company
+	for rows.Next() {
+		var i Company
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Industry,
+			&i.Size,
+			&i.ArchivedAt,
+			&i.IsFavorite,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompanyActivityByUserID = `-- name: GetCompanyActivityByUserID :many
+SELECT 'application_created'::text AS event_type, COALESCE(a.created_at, a.applied_date)::timestamp AS occurred_at,
+       a.id AS application_id, j.id AS job_id, j.title AS job_title, a.status AS status,
+       NULL::int AS interview_id, NULL::text AS interview_title, NULL::text AS interview_outcome
+FROM applications a
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+UNION ALL
+SELECT 'status_changed'::text, h.changed_at,
+       a.id, j.id, j.title, h.status,
+       NULL::int, NULL::text, NULL::text
+FROM application_status_history h
+JOIN applications a ON a.id = h.application_id
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+  AND EXISTS (
+    SELECT 1 FROM application_status_history earlier
+    WHERE earlier.application_id = h.application_id AND earlier.id < h.id
+  )
+UNION ALL
+SELECT 'interview'::text, COALESCE(i.scheduled_at, i.created_at, CURRENT_TIMESTAMP)::timestamp,
+       a.id, j.id, j.title, NULL::text,
+       i.id, i.title, i.outcome
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+ORDER BY occurred_at DESC, application_id DESC, event_type ASC
+LIMIT $3 OFFSET $4
+`
+
+type GetCompanyActivityByUserIDParams struct {
+	CompanyID int32 `json:"company_id"`
+	UserID    int32 `json:"user_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+type GetCompanyActivityByUserIDRow struct {
+	EventType        string         `json:"event_type"`
+	OccurredAt       time.Time      `json:"occurred_at"`
+	ApplicationID    int32          `json:"application_id"`
+	JobID            int32          `json:"job_id"`
+	JobTitle         string         `json:"job_title"`
+	Status           sql.NullString `json:"status"`
+	InterviewID      sql.NullInt32  `json:"interview_id"`
+	InterviewTitle   sql.NullString `json:"interview_title"`
+	InterviewOutcome sql.NullString `json:"interview_outcome"`
+}
+
+// Get a merged timeline of a company's activity, newest first, through the company's jobs (verifies ownership via user_id)
+// event_type is application_created, status_changed (every status after the first, see application_status_history)
+// or interview (at its scheduled time, or when it was added if unscheduled)
+func (q *Queries) GetCompanyActivityByUserID(ctx context.Context, arg GetCompanyActivityByUserIDParams) ([]GetCompanyActivityByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCompanyActivityByUserID,
+		arg.CompanyID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompanyActivityByUserIDRow
+	for rows.Next() {
+		var i GetCompanyActivityByUserIDRow
+		if err := rows.Scan(
+			&i.EventType,
+			&i.OccurredAt,
+			&i.ApplicationID,
+			&i.JobID,
+			&i.JobTitle,
+			&i.Status,
+			&i.InterviewID,
+			&i.InterviewTitle,
+			&i.InterviewOutcome,
 		); err != nil {
 			return nil, err
 		}
@@ -148,7 +505,7 @@ func (q *Queries) GetCompaniesByUserIDPaginated(ctx context.Context, arg GetComp
 }
 
 const getCompanyByIDAndUserID = `-- name: GetCompanyByIDAndUserID :one
-SELECT id, name, website, created_at, updated_at, user_id FROM companies
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
 WHERE id = $1 AND user_id = $2
 `
 
@@ -168,12 +525,16 @@ func (q *Queries) GetCompanyByIDAndUserID(ctx context.Context, arg GetCompanyByI
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
 	)
 	return i, err
 }
 
 const getCompanyByNameAndUserID = `-- name: GetCompanyByNameAndUserID :one
-SELECT id, name, website, created_at, updated_at, user_id FROM companies
+SELECT id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite FROM companies
 WHERE LOWER(TRIM(name)) = LOWER(TRIM($1)) AND user_id = $2
 LIMIT 1
 `
@@ -195,33 +556,187 @@ func (q *Queries) GetCompanyByNameAndUserID(ctx context.Context, arg GetCompanyB
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
+	)
+	return i, err
+}
+
+const getCompanyDeleteImpact = `-- name: GetCompanyDeleteImpact :one
+SELECT COUNT(*) AS jobs,
+       COUNT(*) FILTER (WHERE a.status IN ('applied', 'interview', 'offer') AND a.archived_at IS NULL) AS active_applications
+FROM jobs j
+JOIN applications a ON a.id = j.application_id
+WHERE j.company_id = $1 AND a.user_id = $2
+`
+
+type GetCompanyDeleteImpactParams struct {
+	CompanyID int32 `json:"company_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+type GetCompanyDeleteImpactRow struct {
+	Jobs               int64 `json:"jobs"`
+	ActiveApplications int64 `json:"active_applications"`
+}
+
+// Count what deleting a company affects (verify ownership of the company first)
+// Its jobs are cascade-deleted; their applications are kept but lose their job
+func (q *Queries) GetCompanyDeleteImpact(ctx context.Context, arg GetCompanyDeleteImpactParams) (GetCompanyDeleteImpactRow, error) {
+	row := q.db.QueryRowContext(ctx, getCompanyDeleteImpact, arg.CompanyID, arg.UserID)
+	var i GetCompanyDeleteImpactRow
+	err := row.Scan(&i.Jobs, &i.ActiveApplications)
+	return i, err
+}
+
+const getCompanyResponseTime = `-- name: GetCompanyResponseTime :one
+WITH responses AS (
+  SELECT a.applied_date,
+         (SELECT MIN(later.changed_at) FROM application_status_history later
+          WHERE later.application_id = a.id AND later.status <> 'applied' AND later.id > applied.id) AS responded_at
+  FROM applications a
+  JOIN jobs j ON j.application_id = a.id
+  JOIN LATERAL (
+    SELECT h.id FROM application_status_history h
+    WHERE h.application_id = a.id AND h.status = 'applied'
+    ORDER BY h.id ASC
+    LIMIT 1
+  ) applied ON true
+  WHERE j.company_id = $1 AND a.user_id = $2
+)
+SELECT COALESCE(percentile_cont(0.5) WITHIN GROUP (
+         ORDER BY GREATEST(EXTRACT(EPOCH FROM (responded_at - applied_date::timestamp)) / 86400, 0)
+       ), 0)::float8 AS median_days,
+       COUNT(*) AS samples
+FROM responses
+WHERE responded_at IS NOT NULL
+`
+
+type GetCompanyResponseTimeParams struct {
+	CompanyID int32 `json:"company_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+type GetCompanyResponseTimeRow struct {
+	MedianDays float64 `json:"median_days"`
+	Samples    int64   `json:"samples"`
+}
+
+// Get the median number of days from applied_date to the first status change after 'applied',
+// across the company's applications that were in 'applied' and have moved on since (verify ownership of the company first)
+// samples is how many applications the median is based on (median_days is 0 when there are none)
+func (q *Queries) GetCompanyResponseTime(ctx context.Context, arg GetCompanyResponseTimeParams) (GetCompanyResponseTimeRow, error) {
+	row := q.db.QueryRowContext(ctx, getCompanyResponseTime, arg.CompanyID, arg.UserID)
+	var i GetCompanyResponseTimeRow
+	err := row.Scan(&i.MedianDays, &i.Samples)
+	return i, err
+}
+
+const setCompanyFavorite = `-- name: SetCompanyFavorite :one
+UPDATE companies
+SET is_favorite = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND user_id = $3
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite
+`
+
+type SetCompanyFavoriteParams struct {
+	IsFavorite bool  `json:"is_favorite"`
+	ID         int32 `json:"id"`
+	UserID     int32 `json:"user_id"`
+}
+
+// Star or unstar a company and return the updated record (verifies ownership via user_id)
+func (q *Queries) SetCompanyFavorite(ctx context.Context, arg SetCompanyFavoriteParams) (Company, error) {
+	row := q.db.QueryRowContext(ctx, setCompanyFavorite, arg.IsFavorite, arg.ID, arg.UserID)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
+	)
+	return i, err
+}
+
+const unarchiveCompany = `-- name: UnarchiveCompany :one
+UPDATE companies
+SET archived_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite
+`
+
+type UnarchiveCompanyParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+// Unarchive a company and return the updated record (verifies ownership via user_id)
+func (q *Queries) UnarchiveCompany(ctx context.Context, arg UnarchiveCompanyParams) (Company, error) {
+	row := q.db.QueryRowContext(ctx, unarchiveCompany, arg.ID, arg.UserID)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
 	)
 	return i, err
 }
 
 const updateCompany = `-- name: UpdateCompany :one
 UPDATE companies
-SET name = $2,
-    website = $3,
+SET name = $1,
+    website = $2,
+    industry = CASE WHEN $3::boolean THEN industry ELSE $4 END,
+    size = CASE WHEN $5::boolean THEN size ELSE $6 END,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $4
-RETURNING id, name, website, created_at, updated_at, user_id
+WHERE id = $7 AND user_id = $8
+  AND ($9::timestamp IS NULL OR updated_at = $9)
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite
 `
 
 type UpdateCompanyParams struct {
-	ID      int32          `json:"id"`
-	Name    string         `json:"name"`
-	Website sql.NullString `json:"website"`
-	UserID  int32          `json:"user_id"`
+	Name              string         `json:"name"`
+	Website           sql.NullString `json:"website"`
+	KeepIndustry      bool           `json:"keep_industry"`
+	Industry          sql.NullString `json:"industry"`
+	KeepSize          bool           `json:"keep_size"`
+	Size              sql.NullString `json:"size"`
+	ID                int32          `json:"id"`
+	UserID            int32          `json:"user_id"`
+	ExpectedUpdatedAt sql.NullTime   `json:"expected_updated_at"`
 }
 
 // Update a company and return the updated record (verifies ownership via user_id)
+// expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+// keep_industry/keep_size leave the column unchanged (the client omitted it); otherwise it is set, NULL clearing it
 func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (Company, error) {
 	row := q.db.QueryRowContext(ctx, updateCompany,
-		arg.ID,
 		arg.Name,
 		arg.Website,
+		arg.KeepIndustry,
+		arg.Industry,
+		arg.KeepSize,
+		arg.Size,
+		arg.ID,
 		arg.UserID,
+		arg.ExpectedUpdatedAt,
 	)
 	var i Company
 	err := row.Scan(
@@ -231,6 +746,66 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (C
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
+	)
+	return i, err
+}
+
+const upsertCompany = `-- name: UpsertCompany :one
+INSERT INTO companies (name, website, user_id, industry, size)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, LOWER(TRIM(name))) DO UPDATE SET name = companies.name
+RETURNING id, name, website, created_at, updated_at, user_id, industry, size, archived_at, is_favorite, (xmax = 0) AS inserted
+`
+
+type UpsertCompanyParams struct {
+	Name     string         `json:"name"`
+	Website  sql.NullString `json:"website"`
+	UserID   int32          `json:"user_id"`
+	Industry sql.NullString `json:"industry"`
+	Size     sql.NullString `json:"size"`
+}
+
+type UpsertCompanyRow struct {
+	ID         int32          `json:"id"`
+	Name       string         `json:"name"`
+	Website    sql.NullString `json:"website"`
+	CreatedAt  sql.NullTime   `json:"created_at"`
+	UpdatedAt  sql.NullTime   `json:"updated_at"`
+	UserID     int32          `json:"user_id"`
+	Industry   sql.NullString `json:"industry"`
+	Size       sql.NullString `json:"size"`
+	ArchivedAt sql.NullTime   `json:"archived_at"`
+	IsFavorite bool           `json:"is_favorite"`
+	Inserted   bool           `json:"inserted"`
+}
+
+// Get-or-create a company by normalized name in one atomic statement (no check-then-insert race)
+// An existing company is returned unchanged; inserted is true only when a new row was created
+func (q *Queries) UpsertCompany(ctx context.Context, arg UpsertCompanyParams) (UpsertCompanyRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertCompany,
+		arg.Name,
+		arg.Website,
+		arg.UserID,
+		arg.Industry,
+		arg.Size,
+	)
+	var i UpsertCompanyRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.Industry,
+		&i.Size,
+		&i.ArchivedAt,
+		&i.IsFavorite,
+		&i.Inserted,
 	)
 	return i, err
 }
@@ -8,12 +8,55 @@ package database
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const countContactsByUserID = `-- name: CountContactsByUserID :one
+SELECT COUNT(*) FROM contacts
+WHERE user_id = $1
+  AND ($2::text IS NULL OR LOWER(split_part(email, '@', 2)) = LOWER($2))
+  AND ($3::text IS NULL OR role = $3)
+`
+
+type CountContactsByUserIDParams struct {
+	UserID      int32          `json:"user_id"`
+	EmailDomain sql.NullString `json:"email_domain"`
+	Role        sql.NullString `json:"role"`
+}
+
+// Get total count of contacts for a specific user with the same optional email_domain and role filters
+func (q *Queries) CountContactsByUserID(ctx context.Context, arg CountContactsByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countContactsByUserID, arg.UserID, arg.EmailDomain, arg.Role)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countContactsMatchingByUserID = `-- name: CountContactsMatchingByUserID :one
+SELECT COUNT(*) FROM contacts
+WHERE user_id = $1
+  AND (name ILIKE $2 OR email ILIKE $2 OR phone ILIKE $2
+    OR linkedin ILIKE $2 OR role ILIKE $2)
+`
+
+type CountContactsMatchingByUserIDParams struct {
+	UserID  int32  `json:"user_id"`
+	Pattern string `json:"pattern"`
+}
+
+// Count the user's contacts matched by SearchContactsByUserID
+func (q *Queries) CountContactsMatchingByUserID(ctx context.Context, arg CountContactsMatchingByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countContactsMatchingByUserID, arg.UserID, arg.Pattern)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createContact = `-- name: CreateContact :one
-INSERT INTO contacts (name, email, phone, linkedin, user_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, email, phone, linkedin, created_at, updated_at, user_id
+INSERT INTO contacts (name, email, phone, linkedin, user_id, role)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, email, phone, linkedin, created_at, updated_at, user_id, role
 `
 
 type CreateContactParams struct {
@@ -22,6 +65,7 @@ type CreateContactParams struct {
 	Phone    sql.NullString `json:"phone"`
 	Linkedin sql.NullString `json:"linkedin"`
 	UserID   int32          `json:"user_id"`
+	Role     sql.NullString `json:"role"`
 }
 
 // Create a new contact and return the created record
@@ -32,6 +76,7 @@ func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (C
 		arg.Phone,
 		arg.Linkedin,
 		arg.UserID,
+		arg.Role,
 	)
 	var i Contact
 	err := row.Scan(
@@ -43,6 +88,7 @@ func (q *Queries) CreateContact(ctx context.Context, arg CreateContactParams) (C
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Role,
 	)
 	return i, err
 }
@@ -63,8 +109,37 @@ func (q *Queries) DeleteContact(ctx context.Context, arg DeleteContactParams) er
 	return err
 }
 
+const getContactByEmailAndUserID = `-- name: GetContactByEmailAndUserID :one
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
+WHERE LOWER(email) = LOWER($1) AND user_id = $2
+LIMIT 1
+`
+
+type GetContactByEmailAndUserIDParams struct {
+	Lower  string `json:"lower"`
+	UserID int32  `json:"user_id"`
+}
+
+// Get a contact by email and user_id (case-insensitive, for get-or-create dedupe)
+func (q *Queries) GetContactByEmailAndUserID(ctx context.Context, arg GetContactByEmailAndUserIDParams) (Contact, error) {
+	row := q.db.QueryRowContext(ctx, getContactByEmailAndUserID, arg.Lower, arg.UserID)
+	var i Contact
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.Linkedin,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.Role,
+	)
+	return i, err
+}
+
 const getContactByIDAndUserID = `-- name: GetContactByIDAndUserID :one
-SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id FROM contacts
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
 WHERE id = $1 AND user_id = $2
 `
 
@@ -86,19 +161,227 @@ func (q *Queries) GetContactByIDAndUserID(ctx context.Context, arg GetContactByI
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Role,
 	)
 	return i, err
 }
 
+const getContactsByIDsAndUserID = `-- name: GetContactsByIDsAndUserID :many
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
+WHERE id = ANY($1::int[]) AND user_id = $2
+`
+
+type GetContactsByIDsAndUserIDParams struct {
+	Ids    []int32 `json:"ids"`
+	UserID int32   `json:"user_id"`
+}
+
+// Get the user's contacts among the given IDs (IDs of other users are silently skipped)
+func (q *Queries) GetContactsByIDsAndUserID(ctx context.Context, arg GetContactsByIDsAndUserIDParams) ([]Contact, error) {
+	rows, err := q.db.QueryContext(ctx, getContactsByIDsAndUserID, pq.Array(arg.Ids), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Contact
+	for rows.Next() {
+		var i Contact
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.Linkedin,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getContactsByUserID = `-- name: GetContactsByUserID :many
-SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id FROM contacts
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
+WHERE user_id = $1
+  AND ($2::text IS NULL OR LOWER(split_part(email, '@', 2)) = LOWER($2))
+  AND ($3::text IS NULL OR role = $3)
+ORDER BY
+  CASE WHEN $4::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN $4::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN $4::text = 'name_asc' THEN name END ASC,
+  CASE WHEN $4::text = 'name_desc' THEN name END DESC,
+  name ASC, id ASC
+LIMIT $5 OFFSET $6
+`
+
+type GetContactsByUserIDParams struct {
+	UserID      int32          `json:"user_id"`
+	EmailDomain sql.NullString `json:"email_domain"`
+	Role        sql.NullString `json:"role"`
+	SortKey     string         `json:"sort_key"`
+	RowLimit    sql.NullInt32  `json:"row_limit"`
+	RowOffset   int32          `json:"row_offset"`
+}
+
+// Get all contacts for a specific user, ordered by sort_key (e.g. created_at_desc), then by name
+// email_domain, when set, keeps contacts whose email is at exactly that domain (case-insensitive)
+// role, when set, keeps contacts with that role
+// row_limit NULL returns all rows, otherwise paginates with row_offset
+func (q *Queries) GetContactsByUserID(ctx context.Context, arg GetContactsByUserIDParams) ([]Contact, error) {
+	rows, err := q.db.QueryContext(ctx, getContactsByUserID,
+		arg.UserID,
+		arg.EmailDomain,
+		arg.Role,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Contact
+	for rows.Next() {
+		var i Contact
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.Linkedin,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContactsByUserIDAfterID = `-- name: GetContactsByUserIDAfterID :many
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
+WHERE user_id = $1 AND id > $2
+ORDER BY id ASC
+LIMIT $3
+`
+
+type GetContactsByUserIDAfterIDParams struct {
+	UserID    int32 `json:"user_id"`
+	AfterID   int32 `json:"after_id"`
+	BatchSize int32 `json:"batch_size"`
+}
+
+// Get the next batch of a user's contacts in ID order, starting after after_id (keyset pagination for exports)
+func (q *Queries) GetContactsByUserIDAfterID(ctx context.Context, arg GetContactsByUserIDAfterIDParams) ([]Contact, error) {
+	rows, err := q.db.QueryContext(ctx, getContactsByUserIDAfterID, arg.UserID, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Contact
+	for rows.Next() {
+		var i Contact
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.Linkedin,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOwnedContactIDsByUserID = `-- name: GetOwnedContactIDsByUserID :many
+SELECT id FROM contacts
+WHERE id = ANY($1::int[]) AND user_id = $2
+`
+
+type GetOwnedContactIDsByUserIDParams struct {
+	Ids    []int32 `json:"ids"`
+	UserID int32   `json:"user_id"`
+}
+
+// Get which of the given contact IDs belong to the user (ownership check for bulk operations)
+func (q *Queries) GetOwnedContactIDsByUserID(ctx context.Context, arg GetOwnedContactIDsByUserIDParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getOwnedContactIDsByUserID, pq.Array(arg.Ids), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchContactsByUserID = `-- name: SearchContactsByUserID :many
+SELECT id, name, email, phone, linkedin, created_at, updated_at, user_id, role FROM contacts
 WHERE user_id = $1
-ORDER BY name ASC
+  AND (name ILIKE $2 OR email ILIKE $2 OR phone ILIKE $2
+    OR linkedin ILIKE $2 OR role ILIKE $2)
+ORDER BY name ASC, id ASC
+LIMIT $3 OFFSET $4
 `
 
-// Get all contacts for a specific user, ordered by name
-func (q *Queries) GetContactsByUserID(ctx context.Context, userID int32) ([]Contact, error) {
-	rows, err := q.db.QueryContext(ctx, getContactsByUserID, userID)
+type SearchContactsByUserIDParams struct {
+	UserID    int32  `json:"user_id"`
+	Pattern   string `json:"pattern"`
+	RowLimit  int32  `json:"row_limit"`
+	RowOffset int32  `json:"row_offset"`
+}
+
+// Get the user's contacts with any of name, email, phone, linkedin or role matching the ILIKE pattern, ordered by name
+func (q *Queries) SearchContactsByUserID(ctx context.Context, arg SearchContactsByUserIDParams) ([]Contact, error) {
+	rows, err := q.db.QueryContext(ctx, searchContactsByUserID,
+		arg.UserID,
+		arg.Pattern,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +398,7 @@ func (q *Queries) GetContactsByUserID(ctx context.Context, userID int32) ([]Cont
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -131,33 +415,43 @@ func (q *Queries) GetContactsByUserID(ctx context.Context, userID int32) ([]Cont
 
 const updateContact = `-- name: UpdateContact :one
 UPDATE contacts
-SET name = $2,
-    email = $3,
-    phone = $4,
-    linkedin = $5,
+SET name = $1,
+    email = $2,
+    phone = $3,
+    linkedin = $4,
+    role = CASE WHEN $5::boolean THEN role ELSE $6 END,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $6
-RETURNING id, name, email, phone, linkedin, created_at, updated_at, user_id
+WHERE id = $7 AND user_id = $8
+  AND ($9::timestamp IS NULL OR updated_at = $9)
+RETURNING id, name, email, phone, linkedin, created_at, updated_at, user_id, role
 `
 
 type UpdateContactParams struct {
-	ID       int32          `json:"id"`
-	Name     string         `json:"name"`
-	Email    sql.NullString `json:"email"`
-	Phone    sql.NullString `json:"phone"`
-	Linkedin sql.NullString `json:"linkedin"`
-	UserID   int32          `json:"user_id"`
+	Name              string         `json:"name"`
+	Email             sql.NullString `json:"email"`
+	Phone             sql.NullString `json:"phone"`
+	Linkedin          sql.NullString `json:"linkedin"`
+	KeepRole          bool           `json:"keep_role"`
+	Role              sql.NullString `json:"role"`
+	ID                int32          `json:"id"`
+	UserID            int32          `json:"user_id"`
+	ExpectedUpdatedAt sql.NullTime   `json:"expected_updated_at"`
 }
 
 // Update a contact and return the updated record (verifies ownership via user_id)
+// expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+// keep_role leaves role unchanged (the client omitted it); otherwise it is set, NULL clearing it
 func (q *Queries) UpdateContact(ctx context.Context, arg UpdateContactParams) (Contact, error) {
 	row := q.db.QueryRowContext(ctx, updateContact,
-		arg.ID,
 		arg.Name,
 		arg.Email,
 		arg.Phone,
 		arg.Linkedin,
+		arg.KeepRole,
+		arg.Role,
+		arg.ID,
 		arg.UserID,
+		arg.ExpectedUpdatedAt,
 	)
 	var i Contact
 	err := row.Scan(
@@ -169,6 +463,7 @@ func (q *Queries) UpdateContact(ctx context.Context, arg UpdateContactParams) (C
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.Role,
 	)
 	return i, err
 }
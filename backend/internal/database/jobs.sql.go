@@ -8,6 +8,8 @@ package database
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const countJobsByUserID = `-- name: CountJobsByUserID :one
@@ -24,19 +26,40 @@ func (q *Queries) CountJobsByUserID(ctx context.Context, userID int32) (int64, e
 	return count, err
 }
 
+const countJobsFilteredByUserID = `-- name: CountJobsFilteredByUserID :one
+SELECT COUNT(*) FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE a.user_id = $1
+  AND ($2::text IS NULL OR j.employment_type = $2)
+`
+
+type CountJobsFilteredByUserIDParams struct {
+	UserID         int32          `json:"user_id"`
+	EmploymentType sql.NullString `json:"employment_type"`
+}
+
+// Get total count of jobs for a specific user with the same optional filters
+func (q *Queries) CountJobsFilteredByUserID(ctx context.Context, arg CountJobsFilteredByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countJobsFilteredByUserID, arg.UserID, arg.EmploymentType)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createJob = `-- name: CreateJob :one
-INSERT INTO jobs (application_id, company_id, title, description, requirements, location)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, company_id, title, description, requirements, location, created_at, updated_at, application_id
+INSERT INTO jobs (application_id, company_id, title, description, requirements, location, employment_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, company_id, title, description, requirements, location, created_at, updated_at, application_id, employment_type
 `
 
 type CreateJobParams struct {
-	ApplicationID int32          `json:"application_id"`
-	CompanyID     int32          `json:"company_id"`
-	Title         string         `json:"title"`
-	Description   sql.NullString `json:"description"`
-	Requirements  sql.NullString `json:"requirements"`
-	Location      sql.NullString `json:"location"`
+	ApplicationID  int32          `json:"application_id"`
+	CompanyID      int32          `json:"company_id"`
+	Title          string         `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Requirements   sql.NullString `json:"requirements"`
+	Location       sql.NullString `json:"location"`
+	EmploymentType sql.NullString `json:"employment_type"`
 }
 
 // Create a new job and return the created record
@@ -50,6 +73,7 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		arg.Description,
 		arg.Requirements,
 		arg.Location,
+		arg.EmploymentType,
 	)
 	var i Job
 	err := row.Scan(
@@ -62,6 +86,7 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApplicationID,
+		&i.EmploymentType,
 	)
 	return i, err
 }
@@ -87,7 +112,7 @@ func (q *Queries) DeleteJob(ctx context.Context, arg DeleteJobParams) error {
 }
 
 const getJobByIDAndUserID = `-- name: GetJobByIDAndUserID :one
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE j.id = $1 AND a.user_id = $2
 `
@@ -111,12 +136,13 @@ func (q *Queries) GetJobByIDAndUserID(ctx context.Context, arg GetJobByIDAndUser
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApplicationID,
+		&i.EmploymentType,
 	)
 	return i, err
 }
 
 const getJobsByApplicationIDAndUserID = `-- name: GetJobsByApplicationIDAndUserID :many
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE j.application_id = $1 AND a.user_id = $2
 ORDER BY j.created_at DESC
@@ -147,6 +173,54 @@ func (q *Queries) GetJobsByApplicationIDAndUserID(ctx context.Context, arg GetJo
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApplicationID,
+			&i.EmploymentType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJobsByApplicationIDsAndUserID = `-- name: GetJobsByApplicationIDsAndUserID :many
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE j.application_id = ANY($1::int[]) AND a.user_id = $2
+`
+
+type GetJobsByApplicationIDsAndUserIDParams struct {
+	ApplicationIds []int32 `json:"application_ids"`
+	UserID         int32   `json:"user_id"`
+}
+
+// Get the jobs of the given applications and verify ownership through application's user_id
+// Used to expand ?expand=job on application lists in one query
+func (q *Queries) GetJobsByApplicationIDsAndUserID(ctx context.Context, arg GetJobsByApplicationIDsAndUserIDParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, getJobsByApplicationIDsAndUserID, pq.Array(arg.ApplicationIds), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.Title,
+			&i.Description,
+			&i.Requirements,
+			&i.Location,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApplicationID,
+			&i.EmploymentType,
 		); err != nil {
 			return nil, err
 		}
@@ -162,7 +236,7 @@ func (q *Queries) GetJobsByApplicationIDAndUserID(ctx context.Context, arg GetJo
 }
 
 const getJobsByCompanyIDAndUserID = `-- name: GetJobsByCompanyIDAndUserID :many
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE j.company_id = $1 AND a.user_id = $2
 ORDER BY j.created_at DESC
@@ -193,6 +267,7 @@ func (q *Queries) GetJobsByCompanyIDAndUserID(ctx context.Context, arg GetJobsBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApplicationID,
+			&i.EmploymentType,
 		); err != nil {
 			return nil, err
 		}
@@ -208,7 +283,7 @@ func (q *Queries) GetJobsByCompanyIDAndUserID(ctx context.Context, arg GetJobsBy
 }
 
 const getJobsByUserID = `-- name: GetJobsByUserID :many
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE a.user_id = $1
 ORDER BY j.created_at DESC
@@ -234,6 +309,7 @@ func (q *Queries) GetJobsByUserID(ctx context.Context, userID int32) ([]Job, err
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApplicationID,
+			&i.EmploymentType,
 		); err != nil {
 			return nil, err
 		}
@@ -249,7 +325,7 @@ func (q *Queries) GetJobsByUserID(ctx context.Context, userID int32) ([]Job, err
 }
 
 const getJobsByUserIDPaginated = `-- name: GetJobsByUserIDPaginated :many
-SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id FROM jobs j
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE a.user_id = $1
 ORDER BY j.created_at DESC
@@ -282,6 +358,7 @@ func (q *Queries) GetJobsByUserIDPaginated(ctx context.Context, arg GetJobsByUse
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApplicationID,
+			&i.EmploymentType,
 		); err != nil {
 			return nil, err
 		}
@@ -296,39 +373,160 @@ func (q *Queries) GetJobsByUserIDPaginated(ctx context.Context, arg GetJobsByUse
 	return items, nil
 }
 
+const getJobsFilteredByUserID = `-- name: GetJobsFilteredByUserID :many
+SELECT j.id, j.company_id, j.title, j.description, j.requirements, j.location, j.created_at, j.updated_at, j.application_id, j.employment_type FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE a.user_id = $1
+  AND ($2::text IS NULL OR j.employment_type = $2)
+ORDER BY
+  CASE WHEN $3::text = 'created_at_asc' THEN j.created_at END ASC,
+  CASE WHEN $3::text = 'created_at_desc' THEN j.created_at END DESC,
+  CASE WHEN $3::text = 'title_asc' THEN j.title END ASC,
+  CASE WHEN $3::text = 'title_desc' THEN j.title END DESC,
+  j.created_at DESC, j.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetJobsFilteredByUserIDParams struct {
+	UserID         int32          `json:"user_id"`
+	EmploymentType sql.NullString `json:"employment_type"`
+	SortKey        string         `json:"sort_key"`
+	RowLimit       sql.NullInt32  `json:"row_limit"`
+	RowOffset      int32          `json:"row_offset"`
+}
+
+// Get jobs for a specific user (through applications) with optional filters (a NULL filter is not applied)
+// row_limit NULL returns all rows (LIMIT NULL), otherwise paginates with row_offset
+// sort_key picks the order (e.g. created_at_desc); other values keep the default order
+func (q *Queries) GetJobsFilteredByUserID(ctx context.Context, arg GetJobsFilteredByUserIDParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, getJobsFilteredByUserID,
+		arg.UserID,
+		arg.EmploymentType,
+		arg.SortKey,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.Title,
+			&i.Description,
+			&i.Requirements,
+			&i.Location,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApplicationID,
+			&i.EmploymentType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveJobToApplication = `-- name: MoveJobToApplication :one
+UPDATE jobs
+SET application_id = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE jobs.id = $2
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = jobs.application_id AND a.user_id = $3
+  )
+  AND ($4::timestamp IS NULL OR jobs.updated_at = $4)
+RETURNING id, company_id, title, description, requirements, location, created_at, updated_at, application_id, employment_type
+`
+
+type MoveJobToApplicationParams struct {
+	ApplicationID     int32        `json:"application_id"`
+	ID                int32        `json:"id"`
+	UserID            int32        `json:"user_id"`
+	ExpectedUpdatedAt sql.NullTime `json:"expected_updated_at"`
+}
+
+// Link a job to another application and return the updated record (verifies ownership through application's user_id)
+// The caller checks the target application is the user's; jobs_application_id_unique keeps one job per application
+// expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+func (q *Queries) MoveJobToApplication(ctx context.Context, arg MoveJobToApplicationParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, moveJobToApplication,
+		arg.ApplicationID,
+		arg.ID,
+		arg.UserID,
+		arg.ExpectedUpdatedAt,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.Title,
+		&i.Description,
+		&i.Requirements,
+		&i.Location,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApplicationID,
+		&i.EmploymentType,
+	)
+	return i, err
+}
+
 const updateJob = `-- name: UpdateJob :one
 UPDATE jobs
-SET title = $2,
-    description = $3,
-    requirements = $4,
-    location = $5,
+SET title = $1,
+    description = $2,
+    requirements = $3,
+    location = $4,
+    employment_type = CASE WHEN $5::boolean THEN employment_type ELSE $6 END,
     updated_at = CURRENT_TIMESTAMP
-WHERE jobs.id = $1
+WHERE jobs.id = $7
   AND EXISTS (
     SELECT 1 FROM applications a
-    WHERE a.id = jobs.application_id AND a.user_id = $6
+    WHERE a.id = jobs.application_id AND a.user_id = $8
   )
-RETURNING id, company_id, title, description, requirements, location, created_at, updated_at, application_id
+  AND ($9::timestamp IS NULL OR jobs.updated_at = $9)
+RETURNING id, company_id, title, description, requirements, location, created_at, updated_at, application_id, employment_type
 `
 
 type UpdateJobParams struct {
-	ID           int32          `json:"id"`
-	Title        string         `json:"title"`
-	Description  sql.NullString `json:"description"`
-	Requirements sql.NullString `json:"requirements"`
-	Location     sql.NullString `json:"location"`
-	UserID       int32          `json:"user_id"`
+	Title              string         `json:"title"`
+	Description        sql.NullString `json:"description"`
+	Requirements       sql.NullString `json:"requirements"`
+	Location           sql.NullString `json:"location"`
+	KeepEmploymentType bool           `json:"keep_employment_type"`
+	EmploymentType     sql.NullString `json:"employment_type"`
+	ID                 int32          `json:"id"`
+	UserID             int32          `json:"user_id"`
+	ExpectedUpdatedAt  sql.NullTime   `json:"expected_updated_at"`
 }
 
 // Update a job and return the updated record (verifies ownership through application's user_id)
+// expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+// keep_employment_type leaves employment_type unchanged (the client omitted it); otherwise it is set, NULL clearing it
 func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, error) {
 	row := q.db.QueryRowContext(ctx, updateJob,
-		arg.ID,
 		arg.Title,
 		arg.Description,
 		arg.Requirements,
 		arg.Location,
+		arg.KeepEmploymentType,
+		arg.EmploymentType,
+		arg.ID,
 		arg.UserID,
+		arg.ExpectedUpdatedAt,
 	)
 	var i Job
 	err := row.Scan(
@@ -341,6 +539,7 @@ func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApplicationID,
+		&i.EmploymentType,
 	)
 	return i, err
 }
@@ -10,23 +10,69 @@ import (
 )
 
 type Application struct {
-	ID          int32          `json:"id"`
-	Status      string         `json:"status"`
-	AppliedDate time.Time      `json:"applied_date"`
-	Notes       sql.NullString `json:"notes"`
-	CreatedAt   sql.NullTime   `json:"created_at"`
-	UpdatedAt   sql.NullTime   `json:"updated_at"`
-	ContactID   sql.NullInt32  `json:"contact_id"`
-	UserID      int32          `json:"user_id"`
+	ID                  int32          `json:"id"`
+	Status              string         `json:"status"`
+	AppliedDate         time.Time      `json:"applied_date"`
+	Notes               sql.NullString `json:"notes"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	UpdatedAt           sql.NullTime   `json:"updated_at"`
+	ContactID           sql.NullInt32  `json:"contact_id"`
+	UserID              int32          `json:"user_id"`
+	Source              sql.NullString `json:"source"`
+	NextAction          sql.NullString `json:"next_action"`
+	NextActionDue       sql.NullTime   `json:"next_action_due"`
+	OfferSalary         sql.NullInt64  `json:"offer_salary"`
+	OfferCurrency       sql.NullString `json:"offer_currency"`
+	OfferReceivedDate   sql.NullTime   `json:"offer_received_date"`
+	Decision            sql.NullString `json:"decision"`
+	ReferredByContactID sql.NullInt32  `json:"referred_by_contact_id"`
+	ClosedReason        sql.NullString `json:"closed_reason"`
+	ArchivedAt          sql.NullTime   `json:"archived_at"`
+	CoverLetter         sql.NullString `json:"cover_letter"`
+	LastActivityAt      sql.NullTime   `json:"last_activity_at"`
+}
+
+type ApplicationNote struct {
+	ID            int32        `json:"id"`
+	ApplicationID int32        `json:"application_id"`
+	Body          string       `json:"body"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	UpdatedAt     sql.NullTime `json:"updated_at"`
+}
+
+type ApplicationShare struct {
+	ID            int32        `json:"id"`
+	ApplicationID int32        `json:"application_id"`
+	TokenHash     string       `json:"token_hash"`
+	IncludeNotes  bool         `json:"include_notes"`
+	ExpiresAt     sql.NullTime `json:"expires_at"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+}
+
+type ApplicationStatusHistory struct {
+	ID            int32     `json:"id"`
+	ApplicationID int32     `json:"application_id"`
+	Status        string    `json:"status"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+type ApplicationTag struct {
+	ApplicationID int32        `json:"application_id"`
+	TagID         int32        `json:"tag_id"`
+	CreatedAt     sql.NullTime `json:"created_at"`
 }
 
 type Company struct {
-	ID        int32          `json:"id"`
-	Name      string         `json:"name"`
-	Website   sql.NullString `json:"website"`
-	CreatedAt sql.NullTime   `json:"created_at"`
-	UpdatedAt sql.NullTime   `json:"updated_at"`
-	UserID    int32          `json:"user_id"`
+	ID         int32          `json:"id"`
+	Name       string         `json:"name"`
+	Website    sql.NullString `json:"website"`
+	CreatedAt  sql.NullTime   `json:"created_at"`
+	UpdatedAt  sql.NullTime   `json:"updated_at"`
+	UserID     int32          `json:"user_id"`
+	Industry   sql.NullString `json:"industry"`
+	Size       sql.NullString `json:"size"`
+	ArchivedAt sql.NullTime   `json:"archived_at"`
+	IsFavorite bool           `json:"is_favorite"`
 }
 
 type Contact struct {
@@ -38,18 +84,62 @@ type Contact struct {
 	CreatedAt sql.NullTime   `json:"created_at"`
 	UpdatedAt sql.NullTime   `json:"updated_at"`
 	UserID    int32          `json:"user_id"`
+	Role      sql.NullString `json:"role"`
 }
 
-type Job struct {
+type ContactAttachment struct {
+	ID          int32        `json:"id"`
+	ContactID   int32        `json:"contact_id"`
+	Filename    string       `json:"filename"`
+	ContentType string       `json:"content_type"`
+	SizeBytes   int32        `json:"size_bytes"`
+	Data        []byte       `json:"data"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+type Document struct {
+	ID            int32        `json:"id"`
+	ApplicationID int32        `json:"application_id"`
+	Label         string       `json:"label"`
+	Url           string       `json:"url"`
+	Type          string       `json:"type"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	UpdatedAt     sql.NullTime `json:"updated_at"`
+}
+
+type Interview struct {
 	ID            int32          `json:"id"`
-	CompanyID     int32          `json:"company_id"`
+	ApplicationID int32          `json:"application_id"`
 	Title         string         `json:"title"`
-	Description   sql.NullString `json:"description"`
-	Requirements  sql.NullString `json:"requirements"`
-	Location      sql.NullString `json:"location"`
+	ScheduledAt   sql.NullTime   `json:"scheduled_at"`
+	Completed     bool           `json:"completed"`
+	Outcome       string         `json:"outcome"`
+	Feedback      sql.NullString `json:"feedback"`
 	CreatedAt     sql.NullTime   `json:"created_at"`
 	UpdatedAt     sql.NullTime   `json:"updated_at"`
-	ApplicationID int32          `json:"application_id"`
+}
+
+type Invite struct {
+	ID        int32         `json:"id"`
+	Code      string        `json:"code"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	UsedBy    sql.NullInt32 `json:"used_by"`
+	UsedAt    sql.NullTime  `json:"used_at"`
+	ExpiresAt sql.NullTime  `json:"expires_at"`
+	CreatedAt sql.NullTime  `json:"created_at"`
+}
+
+type Job struct {
+	ID             int32          `json:"id"`
+	CompanyID      int32          `json:"company_id"`
+	Title          string         `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Requirements   sql.NullString `json:"requirements"`
+	Location       sql.NullString `json:"location"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	ApplicationID  int32          `json:"application_id"`
+	EmploymentType sql.NullString `json:"employment_type"`
 }
 
 type RefreshToken struct {
@@ -61,6 +151,20 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime `json:"revoked_at"`
 }
 
+type RevokedAccessToken struct {
+	Jti       string    `json:"jti"`
+	UserID    int32     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+type Tag struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Name      string       `json:"name"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
 type User struct {
 	ID          int32          `json:"id"`
 	Email       string         `json:"email"`
@@ -69,4 +173,28 @@ type User struct {
 	UpdatedAt   sql.NullTime   `json:"updated_at"`
 	LastLogin   sql.NullTime   `json:"last_login"`
 	ClerkUserID sql.NullString `json:"clerk_user_id"`
+	Role        string         `json:"role"`
+	DisabledAt  sql.NullTime   `json:"disabled_at"`
+}
+
+type Webhook struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Url       string       `json:"url"`
+	Secret    string       `json:"secret"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int32          `json:"id"`
+	WebhookID      int32          `json:"webhook_id"`
+	Event          string         `json:"event"`
+	Payload        string         `json:"payload"`
+	Signature      string         `json:"signature"`
+	Status         string         `json:"status"`
+	Attempts       int32          `json:"attempts"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
 }
@@ -13,7 +13,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, name)
 VALUES ($1, $2)
-RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id
+RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at
 `
 
 type CreateUserParams struct {
@@ -33,6 +33,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
@@ -40,7 +42,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 const createUserWithClerkID = `-- name: CreateUserWithClerkID :one
 INSERT INTO users (clerk_user_id, email, name)
 VALUES ($1, $2, $3)
-RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id
+RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at
 `
 
 type CreateUserWithClerkIDParams struct {
@@ -61,6 +63,8 @@ func (q *Queries) CreateUserWithClerkID(ctx context.Context, arg CreateUserWithC
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
@@ -77,7 +81,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
 }
 
 const getUserByClerkID = `-- name: GetUserByClerkID :one
-SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id FROM users
+SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at FROM users
 WHERE clerk_user_id = $1
 LIMIT 1
 `
@@ -94,12 +98,14 @@ func (q *Queries) GetUserByClerkID(ctx context.Context, clerkUserID sql.NullStri
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id FROM users
+SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at FROM users
 WHERE LOWER(email) = LOWER($1)
 LIMIT 1
 `
@@ -116,12 +122,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, lower string) (User, error
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id FROM users
+SELECT id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at FROM users
 WHERE id = $1
 `
 
@@ -137,16 +145,58 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
+const getUserRoleByID = `-- name: GetUserRoleByID :one
+SELECT role FROM users
+WHERE id = $1
+`
+
+// Get a user's role ('user' or 'admin')
+func (q *Queries) GetUserRoleByID(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRowContext(ctx, getUserRoleByID, id)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const isUserDisabled = `-- name: IsUserDisabled :one
+SELECT disabled_at IS NOT NULL AS disabled FROM users
+WHERE id = $1
+`
+
+// Check whether a user was disabled by an admin (checked on every authenticated request)
+func (q *Queries) IsUserDisabled(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isUserDisabled, id)
+	var disabled bool
+	err := row.Scan(&disabled)
+	return disabled, err
+}
+
+const lockUserByID = `-- name: LockUserByID :one
+SELECT id FROM users
+WHERE id = $1
+FOR UPDATE
+`
+
+// Lock a user's row until the transaction ends, so checks on the user's totals (e.g. attachment limits)
+// and the insert they guard can't interleave with another request of the same user
+func (q *Queries) LockUserByID(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockUserByID, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id
+RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at
 `
 
 type UpdateUserParams struct {
@@ -166,6 +216,41 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.UpdatedAt,
 		&i.LastLogin,
 		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const updateUserEmailAndName = `-- name: UpdateUserEmailAndName :one
+UPDATE users
+SET email = $2,
+    name = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, email, name, created_at, updated_at, last_login, clerk_user_id, role, disabled_at
+`
+
+type UpdateUserEmailAndNameParams struct {
+	ID    int32          `json:"id"`
+	Email string         `json:"email"`
+	Name  sql.NullString `json:"name"`
+}
+
+// Update a user's email and name (callers check email uniqueness first; the unique index also enforces it)
+func (q *Queries) UpdateUserEmailAndName(ctx context.Context, arg UpdateUserEmailAndNameParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserEmailAndName, arg.ID, arg.Email, arg.Name)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.ClerkUserID,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
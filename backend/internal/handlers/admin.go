@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// AdminHandler handles instance-wide endpoints for administrators (behind middleware.AdminMiddleware)
+type AdminHandler struct {
+	queries *database.Queries
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(queries *database.Queries) *AdminHandler {
+	return &AdminHandler{
+		queries: queries,
+	}
+}
+
+// AdminStats is the response of GET /api/admin/stats
+type AdminStats struct {
+	TotalUsers        int64        `json:"total_users"`
+	TotalApplications int64        `json:"total_applications"`
+	Signups           []MonthCount `json:"signups"` // new users per month, oldest first
+}
+
+// GetStats handles GET /api/admin/stats (admins only)
+// Returns user and application totals across the instance and signups per month
+// for the last ?months=N months (default 12); months without signups have count 0
+func (h *AdminHandler) GetStats(c *gin.Context) {
+	months, start, ok := parseTimelineMonths(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	totalUsers, err := h.queries.CountUsers(ctx)
+	if err != nil {
+		sendInternalError(c, "Failed to count users", err)
+		return
+	}
+	totalApplications, err := h.queries.CountAllApplications(ctx)
+	if err != nil {
+		sendInternalError(c, "Failed to count applications", err)
+		return
+	}
+	rows, err := h.queries.GetSignupCountsByMonth(ctx, sql.NullTime{Time: start, Valid: true})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch signups", err)
+		return
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Month.Format("2006-01")] = row.Count
+	}
+
+	c.JSON(http.StatusOK, AdminStats{
+		TotalUsers:        totalUsers,
+		TotalApplications: totalApplications,
+		Signups:           fillMonths(start, months, counts),
+	})
+}
+
+// AdminUser is one user in GET /api/admin/users (never includes credentials)
+type AdminUser struct {
+	ID         int32      `json:"id"`
+	Email      string     `json:"email"`
+	Name       *string    `json:"name"`
+	Role       string     `json:"role"`
+	CreatedAt  *time.Time `json:"created_at"`
+	LastLogin  *time.Time `json:"last_login"`
+	DisabledAt *time.Time `json:"disabled_at"` // null while the account is active
+}
+
+// newAdminUser converts a user row of the admin queries (they all share GetUsersPaginatedRow's columns)
+func newAdminUser(user database.GetUsersPaginatedRow) AdminUser {
+	return AdminUser{
+		ID:         user.ID,
+		Email:      user.Email,
+		Name:       nullStringPtr(user.Name),
+		Role:       user.Role,
+		CreatedAt:  nullTimePtr(user.CreatedAt),
+		LastLogin:  nullTimePtr(user.LastLogin),
+		DisabledAt: nullTimePtr(user.DisabledAt),
+	}
+}
+
+// nullTimePtr returns a pointer to t's value, or nil when t is NULL
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// GetUsers handles GET /api/admin/users (admins only)
+// Returns all users newest first, paginated with ?page= and ?limit=
+// ?q= keeps users whose email contains the text (case-insensitive)
+func (h *AdminHandler) GetUsers(c *gin.Context) {
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	var emailSearch sql.NullString
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		emailSearch = sql.NullString{String: q, Valid: true}
+	}
+
+	ctx := c.Request.Context()
+
+	users, err := h.queries.GetUsersPaginated(ctx, database.GetUsersPaginatedParams{
+		EmailSearch: emailSearch,
+		RowLimit:    params.Limit,
+		RowOffset:   offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch users", err)
+		return
+	}
+	totalCount, err := h.queries.CountUsersMatching(ctx, emailSearch)
+	if err != nil {
+		sendInternalError(c, "Failed to count users", err)
+		return
+	}
+
+	data := make([]interface{}, len(users))
+	for i, user := range users {
+		data[i] = newAdminUser(user)
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
+// DisableUser handles POST /api/admin/users/:id/disable (admins only)
+// Suspends an account without deleting its data: the user's requests get 403 until re-enabled
+// Admins can't disable themselves; disabling a disabled user keeps the original disabled_at
+func (h *AdminHandler) DisableUser(c *gin.Context) {
+	h.setUserDisabled(c, true)
+}
+
+// EnableUser handles POST /api/admin/users/:id/enable (admins only)
+// Lifts a suspension so the user can sign in again
+func (h *AdminHandler) EnableUser(c *gin.Context) {
+	h.setUserDisabled(c, false)
+}
+
+// setUserDisabled disables or re-enables the user in the path and responds with the admin view of the user
+func (h *AdminHandler) setUserDisabled(c *gin.Context, disabled bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid user ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	adminID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+	if disabled && int32(id) == adminID {
+		sendBadRequest(c, "Invalid user", "You can't disable your own account")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var user database.GetUsersPaginatedRow
+	if disabled {
+		var row database.DisableUserByIDRow
+		row, err = h.queries.DisableUserByID(ctx, int32(id))
+		user = database.GetUsersPaginatedRow(row)
+	} else {
+		var row database.EnableUserByIDRow
+		row, err = h.queries.EnableUserByID(ctx, int32(id))
+		user = database.GetUsersPaginatedRow(row)
+	}
+	if handleDatabaseError(c, err, "User") {
+		return
+	}
+
+	c.JSON(http.StatusOK, newAdminUser(user))
+}
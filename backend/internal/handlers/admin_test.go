@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetAdminStats tests that instance stats are admin-only and cover every month in the range
+func TestGetAdminStats(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	adminUser, cleanupAdmin := createTestUser(t, queries, db, "test-admin-stats-admin@example.com")
+	defer cleanupAdmin()
+	regularUser, cleanupRegular := createTestUser(t, queries, db, "test-admin-stats-user@example.com")
+	defer cleanupRegular()
+	_, err := db.Exec("UPDATE users SET role = 'admin' WHERE id = $1", adminUser.ID)
+	require.NoError(t, err)
+	createTestApplication(t, queries, regularUser.ID, "applied", "")
+
+	getStats := func(token, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/admin/stats"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, getStats(regularUser.Token, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, getStats("", "").Code)
+	assert.Equal(t, http.StatusBadRequest, getStats(adminUser.Token, "?months=0").Code)
+
+	w := getStats(adminUser.Token, "?months=3")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var stats AdminStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.GreaterOrEqual(t, stats.TotalUsers, int64(2))
+	assert.GreaterOrEqual(t, stats.TotalApplications, int64(1))
+	require.Len(t, stats.Signups, 3)
+	current := stats.Signups[2]
+	assert.Equal(t, time.Now().UTC().Format("2006-01"), current.Month)
+	assert.GreaterOrEqual(t, current.Count, int64(2))
+}
+
+// TestGetAdminUsers tests the admin user list, its email search and that it leaves out credentials
+func TestGetAdminUsers(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	adminUser, cleanupAdmin := createTestUser(t, queries, db, "test-admin-users-admin@example.com")
+	defer cleanupAdmin()
+	regularUser, cleanupRegular := createTestUser(t, queries, db, "test-admin-users-Regular@example.com")
+	defer cleanupRegular()
+	_, err := db.Exec("UPDATE users SET role = 'admin' WHERE id = $1", adminUser.ID)
+	require.NoError(t, err)
+
+	getUsers := func(token, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/admin/users"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusForbidden, getUsers(regularUser.Token, "").Code)
+
+	// The search is a case-insensitive substring of the email
+	w := getUsers(adminUser.Token, "?q=admin-users-regular&limit=5")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "password")
+	var response struct {
+		Data []AdminUser    `json:"data"`
+		Meta PaginationMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, regularUser.ID, response.Data[0].ID)
+	assert.Equal(t, "user", response.Data[0].Role)
+	assert.Equal(t, int64(1), response.Meta.TotalCount)
+	assert.Equal(t, int32(5), response.Meta.Limit)
+
+	// Without a search every user is counted
+	w = getUsers(adminUser.Token, "?limit=1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+	assert.GreaterOrEqual(t, response.Meta.TotalCount, int64(2))
+}
+
+// TestDisableUser tests that a disabled user's requests are rejected until an admin re-enables them
+func TestDisableUser(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	adminUser, cleanupAdmin := createTestUser(t, queries, db, "test-disable-user-admin@example.com")
+	defer cleanupAdmin()
+	regularUser, cleanupRegular := createTestUser(t, queries, db, "test-disable-user@example.com")
+	defer cleanupRegular()
+	_, err := db.Exec("UPDATE users SET role = 'admin' WHERE id = $1", adminUser.ID)
+	require.NoError(t, err)
+
+	send := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	userPath := "/api/admin/users/" + strconv.Itoa(int(regularUser.ID))
+
+	assert.Equal(t, http.StatusForbidden, send("POST", userPath+"/disable", regularUser.Token).Code)
+	assert.Equal(t, http.StatusBadRequest, send("POST", "/api/admin/users/"+strconv.Itoa(int(adminUser.ID))+"/disable", adminUser.Token).Code)
+	assert.Equal(t, http.StatusNotFound, send("POST", "/api/admin/users/0/disable", adminUser.Token).Code)
+
+	// Disable: the user's token is rejected
+	w := send("POST", userPath+"/disable", adminUser.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var user AdminUser
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	require.NotNil(t, user.DisabledAt)
+	assert.Equal(t, http.StatusForbidden, send("GET", "/api/auth/me", regularUser.Token).Code)
+
+	// Enable: the same token works again
+	w = send("POST", userPath+"/enable", adminUser.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	assert.Nil(t, user.DisabledAt)
+	assert.Equal(t, http.StatusOK, send("GET", "/api/auth/me", regularUser.Token).Code)
+}
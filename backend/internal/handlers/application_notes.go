@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// ApplicationNoteHandler handles HTTP requests for an application's timestamped notes (activity log)
+type ApplicationNoteHandler struct {
+	db      *sql.DB
+	queries *database.Queries
+}
+
+// NewApplicationNoteHandler creates a new application note handler
+func NewApplicationNoteHandler(db *sql.DB, queries *database.Queries) *ApplicationNoteHandler {
+	return &ApplicationNoteHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// ApplicationNoteRequest represents the JSON body for creating or updating a note
+type ApplicationNoteRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=10000"`
+}
+
+// parseNotePath parses :id (application) and, if present, :noteId from the URL
+func parseNotePath(c *gin.Context) (applicationID int32, noteID int32, ok bool) {
+	return parseApplicationChildPath(c, "noteId", "Note")
+}
+
+// GetNotes handles GET /api/applications/:id/notes
+// Returns all notes of an application, oldest first (verifies ownership)
+func (h *ApplicationNoteHandler) GetNotes(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseNotePath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user (so an unknown application is a 404, not [])
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	notes, err := h.queries.GetApplicationNotesByApplicationIDAndUserID(ctx, database.GetApplicationNotesByApplicationIDAndUserIDParams{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch notes", err)
+		return
+	}
+	if notes == nil {
+		notes = []database.ApplicationNote{}
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// CreateNote handles POST /api/applications/:id/notes
+// Appends a note to an application (verifies ownership)
+func (h *ApplicationNoteHandler) CreateNote(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseNotePath(c)
+	if !ok {
+		return
+	}
+
+	var req ApplicationNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	note, err := h.queries.CreateApplicationNote(ctx, database.CreateApplicationNoteParams{
+		ApplicationID: applicationID,
+		Body:          req.Body,
+	})
+	if handleDatabaseError(c, err, "Note") {
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// UpdateNote handles PUT /api/applications/:id/notes/:noteId
+// Edits a note (verifies ownership through the application)
+func (h *ApplicationNoteHandler) UpdateNote(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, noteID, ok := parseNotePath(c)
+	if !ok {
+		return
+	}
+
+	var req ApplicationNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	note, err := h.queries.UpdateApplicationNote(ctx, database.UpdateApplicationNoteParams{
+		ID:            noteID,
+		ApplicationID: applicationID,
+		Body:          req.Body,
+		UserID:        userID,
+	})
+	if handleDatabaseError(c, err, "Note") {
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// DeleteNote handles DELETE /api/applications/:id/notes/:noteId
+// Deletes a note (verifies ownership through the application)
+func (h *ApplicationNoteHandler) DeleteNote(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, noteID, ok := parseNotePath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var note database.ApplicationNote
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		var err error
+		note, err = qtx.GetApplicationNoteByIDAndUserID(ctx, database.GetApplicationNoteByIDAndUserIDParams{
+			ID:            noteID,
+			ApplicationID: applicationID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return qtx.DeleteApplicationNote(ctx, database.DeleteApplicationNoteParams{
+			ID:            noteID,
+			ApplicationID: applicationID,
+			UserID:        userID,
+		})
+	})
+	if handleDatabaseError(c, err, "Note") {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note deleted successfully",
+		"id":      noteID,
+		"deleted": note,
+	})
+}
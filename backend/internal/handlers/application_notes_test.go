@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestApplicationNotesCRUD tests the /api/applications/:id/notes endpoints
+func TestApplicationNotesCRUD(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-application-notes@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-application-notes-other@example.com")
+	defer otherCleanup()
+
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+	basePath := "/api/applications/" + strconv.Itoa(int(application.ID)) + "/notes"
+
+	request := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			buf.Write(jsonBody)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test append (two notes, listed oldest first)
+	var note database.ApplicationNote
+	for _, body := range []string{"Recruiter screen went well", "Scheduled onsite"} {
+		w := request("POST", basePath, testUser.Token, map[string]interface{}{"body": body})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &note); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+	}
+	notePath := basePath + "/" + strconv.Itoa(int(note.ID))
+
+	// Test empty body is rejected
+	if w := request("POST", basePath, testUser.Token, map[string]interface{}{"body": "  "}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for empty body, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test list
+	w := request("GET", basePath, testUser.Token, nil)
+	var notes []database.ApplicationNote
+	if err := json.Unmarshal(w.Body.Bytes(), &notes); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Body != "Recruiter screen went well" {
+		t.Errorf("Expected 2 notes in chronological order, got %+v", notes)
+	}
+
+	// Test edit
+	w = request("PUT", notePath, testUser.Token, map[string]interface{}{"body": "Scheduled onsite for Friday"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &note); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if note.Body != "Scheduled onsite for Friday" {
+		t.Errorf("Unexpected updated note: %+v", note)
+	}
+
+	// Test another user cannot see, edit or delete the notes
+	if w = request("GET", basePath, otherUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's list, got %d", http.StatusNotFound, w.Code)
+	}
+	if w = request("POST", basePath, otherUser.Token, map[string]interface{}{"body": "Hijack"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's append, got %d", http.StatusNotFound, w.Code)
+	}
+	if w = request("PUT", notePath, otherUser.Token, map[string]interface{}{"body": "Hijack"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's edit, got %d", http.StatusNotFound, w.Code)
+	}
+	if w = request("DELETE", notePath, otherUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's delete, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Test delete
+	if w = request("DELETE", notePath, testUser.Token, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w = request("DELETE", notePath, testUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -1,29 +1,58 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
 type ApplicationHandler struct {
-	queries *database.Queries
+	db       *sql.DB
+	queries  *database.Queries
+	counts   *CountCache
+	webhooks *WebhookDispatcher // notified of created/updated/deleted applications; nil disables webhooks
 }
 
-func NewApplicationHandler(queries *database.Queries) *ApplicationHandler {
+func NewApplicationHandler(db *sql.DB, queries *database.Queries, counts *CountCache, webhooks *WebhookDispatcher) *ApplicationHandler {
 	return &ApplicationHandler{
-		queries: queries,
+		db:       db,
+		queries:  queries,
+		counts:   counts,
+		webhooks: webhooks,
 	}
 }
 
+// validApplicationSources lists the allowed values for an application's source
+// Keep in sync with the oneof tags on the create/update request structs
+var validApplicationSources = map[string]bool{
+	"linkedin":     true,
+	"indeed":       true,
+	"referral":     true,
+	"company_site": true,
+	"job_board":    true,
+	"recruiter":    true,
+	"other":        true,
+}
+
 // GetAllApplications handles GET /api/applications
 // Returns all applications, or filters by status if ?status= query parameter is provided
 // Supports pagination with ?page=1&limit=10 (optional, backward compatible)
-// Note: Status filter and pagination can be combined
+// Supports ?source=linkedin to filter by where the job was found
+// Supports ?with_flags=true to add has_job/has_resume/has_contact to each application
+// Supports ?missing_job=true to keep only applications without a job (incomplete records)
+// Supports ?missing_resume=true to keep only applications without a resume document
+// Supports ?missing_cover_letter=true to keep only applications without a cover letter
+// Archived applications are left out unless ?include_archived=true
+// Supports ?sort=created_at:desc (fields: applied_date, created_at, last_activity, updated_at); defaults to DEFAULT_SORT_APPLICATIONS
+// Supports ?expand=job.company,contact to embed related objects (paths: job, job.company, contact)
+// Note: Status/source filters and pagination can be combined
 func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
@@ -38,6 +67,39 @@ func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
 
+	// Batch fetch by IDs (e.g. a saved view): ?ids=1,2,3
+	if idsStr, ok := c.GetQuery("ids"); ok {
+		h.getApplicationsByIDs(c, userID, idsStr)
+		return
+	}
+
+	listSort, ok := resolveListSort(c, sortResourceApplications)
+	if !ok {
+		return
+	}
+
+	// Source, missing job, resume and cover letter filters, archived applications, completeness flags (?with_flags=true), expansions and non-default sorts use the combined filtered query
+	missingJob := c.Query("missing_job") == "true"
+	missingResume := c.Query("missing_resume") == "true"
+	missingCoverLetter := c.Query("missing_cover_letter") == "true"
+	includeArchived := c.Query("include_archived") == "true"
+	if source := c.Query("source"); source != "" || missingJob || missingResume || missingCoverLetter || includeArchived || c.Query("with_flags") == "true" || c.Query("expand") != "" || !listSort.isBuiltin(sortResourceApplications) {
+		if source != "" && !validApplicationSources[source] {
+			sendBadRequest(c, "Invalid source", "source must be one of: linkedin, indeed, referral, company_site, job_board, recruiter, other")
+			return
+		}
+		h.getFilteredApplications(c, userID, applicationListFilters{
+			Status:             status,
+			Source:             source,
+			MissingJob:         missingJob,
+			MissingResume:      missingResume,
+			MissingCoverLetter: missingCoverLetter,
+			IncludeArchived:    includeArchived,
+			Sort:               listSort,
+		})
+		return
+	}
+
 	// If status is provided but no pagination, return all filtered (backward compatible)
 	if status != "" && pageStr == "" && limitStr == "" {
 		applications, err := h.queries.GetApplicationsByStatusAndUserID(ctx, database.GetApplicationsByStatusAndUserIDParams{
@@ -81,10 +143,12 @@ func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 			return
 		}
 
-		// Fetch total count for pagination metadata
-		totalCount, err := h.queries.CountApplicationsByStatusAndUserID(ctx, database.CountApplicationsByStatusAndUserIDParams{
-			Status: status,
-			UserID: userID,
+		// Fetch total count for pagination metadata (cached per user+status)
+		totalCount, err := h.counts.Count(countCacheKey(countResourceApplications, userID, "status="+status), func() (int64, error) {
+			return h.queries.CountApplicationsByStatusAndUserID(ctx, database.CountApplicationsByStatusAndUserIDParams{
+				Status: status,
+				UserID: userID,
+			})
 		})
 		if err != nil {
 			sendInternalError(c, "Failed to count applications", err)
@@ -120,8 +184,10 @@ func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 		return
 	}
 
-	// Fetch total count
-	totalCount, err := h.queries.CountApplicationsByUserID(ctx, userID)
+	// Fetch total count (cached per user)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceApplications, userID, ""), func() (int64, error) {
+		return h.queries.CountApplicationsByUserID(ctx, userID)
+	})
 	if err != nil {
 		sendInternalError(c, "Failed to count applications", err)
 		return
@@ -145,8 +211,283 @@ func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 	})
 }
 
+// MaxBatchIDs caps how many IDs GET /api/applications?ids= and the bulk tag endpoints accept
+const MaxBatchIDs = 100
+
+// parseIDList parses a comma-separated list of positive IDs, dropping duplicates
+func parseIDList(idsStr string) ([]int32, error) {
+	parts := strings.Split(idsStr, ",")
+	ids := make([]int32, 0, len(parts))
+	seen := make(map[int32]bool, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid id %q: ids must be positive numbers", part)
+		}
+		if !seen[int32(id)] {
+			seen[int32(id)] = true
+			ids = append(ids, int32(id))
+		}
+	}
+	return ids, nil
+}
+
+// getApplicationsByIDs responds with the user's applications among the requested IDs
+// Results follow the order of the request; IDs that don't exist or aren't the user's are skipped
+func (h *ApplicationHandler) getApplicationsByIDs(c *gin.Context, userID int32, idsStr string) {
+	ids, err := parseIDList(idsStr)
+	if err != nil {
+		sendBadRequest(c, "Invalid ids parameter", err.Error())
+		return
+	}
+	if len(ids) > MaxBatchIDs {
+		sendBadRequest(c, "Too many ids", fmt.Sprintf("At most %d ids can be requested at once", MaxBatchIDs))
+		return
+	}
+
+	applications, err := h.queries.GetApplicationsByIDsAndUserID(c.Request.Context(), database.GetApplicationsByIDsAndUserIDParams{
+		Ids:    ids,
+		UserID: userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch applications", err)
+		return
+	}
+
+	// Restore request order
+	position := make(map[int32]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return position[applications[i].ID] < position[applications[j].ID]
+	})
+	if applications == nil {
+		applications = []database.Application{}
+	}
+
+	if expand := parseApplicationExpansions(c.Query("expand")); expand.any() {
+		data := make([]interface{}, len(applications))
+		for i, app := range applications {
+			data[i] = app
+		}
+		expanded, err := h.expandApplications(c.Request.Context(), userID, data, expand)
+		if err != nil {
+			sendInternalError(c, "Failed to expand applications", err)
+			return
+		}
+		c.JSON(http.StatusOK, expanded)
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// applicationListFilters holds the optional filters for the filtered applications list
+// Empty fields are not applied
+type applicationListFilters struct {
+	Status             string
+	Source             string
+	MissingJob         bool     // only applications without a job
+	MissingResume      bool     // only applications without a resume document
+	MissingCoverLetter bool     // only applications without a cover letter
+	IncludeArchived    bool     // also return archived applications
+	Sort               ListSort // order of the results; not part of cacheKey since it doesn't change counts
+}
+
+// cacheKey returns the count cache filter segment for these filters
+func (f applicationListFilters) cacheKey() string {
+	key := "status=" + f.Status + "&source=" + f.Source
+	if f.MissingJob {
+		key += "&missing_job=true"
+	}
+	if f.MissingResume {
+		key += "&missing_resume=true"
+	}
+	if f.MissingCoverLetter {
+		key += "&missing_cover_letter=true"
+	}
+	if f.IncludeArchived {
+		key += "&include_archived=true"
+	}
+	return key
+}
+
+// fetchFilteredApplications runs the filtered applications query
+// withFlags selects the variant that also returns has_job/has_resume/has_contact
+// expand embeds the requested related objects into each application
+func (h *ApplicationHandler) fetchFilteredApplications(ctx context.Context, arg database.GetApplicationsFilteredByUserIDParams, withFlags bool, expand applicationExpansions) ([]interface{}, error) {
+	if withFlags {
+		rows, err := h.queries.GetApplicationsWithFlagsFilteredByUserID(ctx, database.GetApplicationsWithFlagsFilteredByUserIDParams(arg))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]interface{}, len(rows))
+		for i, row := range rows {
+			data[i] = row
+		}
+		return h.expandApplications(ctx, arg.UserID, data, expand)
+	}
+
+	applications, err := h.queries.GetApplicationsFilteredByUserID(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]interface{}, len(applications))
+	for i, app := range applications {
+		data[i] = app
+	}
+	return h.expandApplications(ctx, arg.UserID, data, expand)
+}
+
+// getFilteredApplications responds with the user's applications matching filters
+// Returns a bare array without page/limit (backward compatible), otherwise a PaginatedResponse
+func (h *ApplicationHandler) getFilteredApplications(c *gin.Context, userID int32, filters applicationListFilters) {
+	ctx := c.Request.Context()
+
+	status := sql.NullString{String: filters.Status, Valid: filters.Status != ""}
+	source := sql.NullString{String: filters.Source, Valid: filters.Source != ""}
+	withFlags := c.Query("with_flags") == "true"
+	expand := parseApplicationExpansions(c.Query("expand"))
+
+	// No pagination params: return all matching applications
+	if c.Query("page") == "" && c.Query("limit") == "" {
+		data, err := h.fetchFilteredApplications(ctx, database.GetApplicationsFilteredByUserIDParams{
+			UserID:             userID,
+			Status:             status,
+			Source:             source,
+			MissingJob:         filters.MissingJob,
+			MissingResume:      filters.MissingResume,
+			MissingCoverLetter: filters.MissingCoverLetter,
+			IncludeArchived:    filters.IncludeArchived,
+			SortKey:            filters.Sort.key(),
+		}, withFlags, expand)
+		if err != nil {
+			sendInternalError(c, "Failed to fetch applications", err)
+			return
+		}
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	data, err := h.fetchFilteredApplications(ctx, database.GetApplicationsFilteredByUserIDParams{
+		UserID:             userID,
+		Status:             status,
+		Source:             source,
+		MissingJob:         filters.MissingJob,
+		MissingResume:      filters.MissingResume,
+		MissingCoverLetter: filters.MissingCoverLetter,
+		IncludeArchived:    filters.IncludeArchived,
+		SortKey:            filters.Sort.key(),
+		RowLimit:           sql.NullInt32{Int32: params.Limit, Valid: true},
+		RowOffset:          offset,
+	}, withFlags, expand)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch applications", err)
+		return
+	}
+
+	// Fetch total count (cached per user+filters)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceApplications, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountApplicationsFilteredByUserID(ctx, database.CountApplicationsFilteredByUserIDParams{
+			UserID:             userID,
+			Status:             status,
+			Source:             source,
+			MissingJob:         filters.MissingJob,
+			MissingResume:      filters.MissingResume,
+			MissingCoverLetter: filters.MissingCoverLetter,
+			IncludeArchived:    filters.IncludeArchived,
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count applications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
+// GetUsedStatuses handles GET /api/applications/used-statuses
+// Returns the distinct statuses present in the user's applications (alphabetical), e.g. for filter dropdowns
+// Unlike the canonical status list, statuses the user never used are left out
+func (h *ApplicationHandler) GetUsedStatuses(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	statuses, err := h.queries.GetUsedApplicationStatusesByUserID(c.Request.Context(), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch statuses", err)
+		return
+	}
+	if statuses == nil {
+		statuses = []string{}
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// CountApplications handles GET /api/applications/count
+// Returns {"count": n} for the user's applications, honoring the ?status=, ?source=, ?missing_job=, ?missing_resume=, ?missing_cover_letter= and ?include_archived= list filters
+// Shares the pagination count cache with the list endpoint
+func (h *ApplicationHandler) CountApplications(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	filters := applicationListFilters{
+		Status:             c.Query("status"),
+		Source:             c.Query("source"),
+		MissingJob:         c.Query("missing_job") == "true",
+		MissingResume:      c.Query("missing_resume") == "true",
+		MissingCoverLetter: c.Query("missing_cover_letter") == "true",
+		IncludeArchived:    c.Query("include_archived") == "true",
+	}
+	if filters.Source != "" && !validApplicationSources[filters.Source] {
+		sendBadRequest(c, "Invalid source", "source must be one of: linkedin, indeed, referral, company_site, job_board, recruiter, other")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	count, err := h.counts.Count(countCacheKey(countResourceApplications, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountApplicationsFilteredByUserID(ctx, database.CountApplicationsFilteredByUserIDParams{
+			UserID:             userID,
+			Status:             sql.NullString{String: filters.Status, Valid: filters.Status != ""},
+			Source:             sql.NullString{String: filters.Source, Valid: filters.Source != ""},
+			MissingJob:         filters.MissingJob,
+			MissingResume:      filters.MissingResume,
+			MissingCoverLetter: filters.MissingCoverLetter,
+			IncludeArchived:    filters.IncludeArchived,
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count applications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
 // GetApplicationByID handles GET /api/applications/:id
 // Returns a single application by ID (verifies ownership)
+// Supports ?expand=job.company,contact to embed related objects (paths: job, job.company, contact)
+// Supports ?include=history to embed the status history as "history", oldest first (opt-in: it costs a query)
 func (h *ApplicationHandler) GetApplicationByID(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
@@ -172,7 +513,56 @@ func (h *ApplicationHandler) GetApplicationByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, application)
+	setETag(c, application.UpdatedAt)
+
+	var response interface{} = application
+	if expand := parseApplicationExpansions(c.Query("expand")); expand.any() {
+		expanded, err := h.expandApplications(ctx, userID, []interface{}{application}, expand)
+		if err != nil {
+			sendInternalError(c, "Failed to expand application", err)
+			return
+		}
+		response = expanded[0]
+	}
+
+	if includesHistory(c.Query("include")) {
+		history, err := h.queries.GetApplicationStatusHistoryByApplicationIDAndUserID(ctx, database.GetApplicationStatusHistoryByApplicationIDAndUserIDParams{
+			ApplicationID: application.ID,
+			UserID:        userID,
+		})
+		if err != nil {
+			sendInternalError(c, "Failed to fetch status history", err)
+			return
+		}
+		if history == nil {
+			history = []database.ApplicationStatusHistory{}
+		}
+
+		if fields, ok := response.(map[string]interface{}); ok {
+			fields["history"] = history
+		} else {
+			response = applicationWithHistory{Application: application, History: history}
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// applicationWithHistory is an application with its status history embedded (?include=history)
+type applicationWithHistory struct {
+	database.Application
+	History []database.ApplicationStatusHistory `json:"history"`
+}
+
+// includesHistory reports whether a comma-separated ?include= list asks for the status history
+// Unknown tokens are ignored, as with ?expand=
+func includesHistory(raw string) bool {
+	for _, token := range strings.Split(raw, ",") {
+		if strings.EqualFold(strings.TrimSpace(token), "history") {
+			return true
+		}
+	}
+	return false
 }
 
 // GetJobByApplicationID handles GET /api/applications/:id/job
@@ -214,78 +604,304 @@ func (h *ApplicationHandler) GetJobByApplicationID(c *gin.Context) {
 // CreateApplicationRequest represents the JSON body for creating an application
 // Note: job_id is no longer required - jobs will be created after applications
 type CreateApplicationRequest struct {
-	Status      string `json:"status" binding:"required,oneof=applied interview offer rejected withdrawn accepted"`
-	AppliedDate string `json:"applied_date" binding:"required"` // ISO 8601 format: "2006-01-02" (validated manually)
-	ContactID   *int   `json:"contact_id"`                      // Optional contact ID
-	Notes       string `json:"notes" binding:"omitempty,max=5000"`
+	Status      string                `json:"status" binding:"required,oneof=applied interview offer rejected withdrawn accepted"`
+	AppliedDate string                `json:"applied_date"` // YYYY-MM-DD or RFC 3339, defaults to today (validated manually)
+	ContactID   *int                  `json:"contact_id"`   // Optional contact ID
+	Contact     *CreateContactRequest `json:"contact"`      // Optional inline contact (get-or-create), instead of contact_id
+	Notes       string                `json:"notes" binding:"omitempty,max=5000"`
+	Source      string                `json:"source" binding:"omitempty,oneof=linkedin indeed referral company_site job_board recruiter other"`
+	// Optional next concrete to-do; next_action_due uses YYYY-MM-DD or RFC 3339 (validated manually)
+	NextAction    string `json:"next_action" binding:"omitempty,max=500"`
+	NextActionDue string `json:"next_action_due"`
+	OfferDetails
+	ReferredByContactID *int   `json:"referred_by_contact_id"`                    // Optional contact who referred the user (one of the user's contacts)
+	ClosedReason        string `json:"closed_reason" binding:"omitempty,max=500"` // Optional reason, only when status is rejected or withdrawn
+	CoverLetter         string `json:"cover_letter" binding:"omitempty,max=50000"`
 }
 
-// CreateApplication handles POST /api/applications
-// Creates a new application
-func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
-	// Parse JSON body
-	var req CreateApplicationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		sendValidationError(c, err)
-		return
+// OfferDetails holds the optional outcome of an offer, only allowed when status is offer or accepted
+// offer_received_date uses YYYY-MM-DD or RFC 3339 (validated manually); offer_salary requires offer_currency
+type OfferDetails struct {
+	OfferSalary       *int64 `json:"offer_salary"`
+	OfferCurrency     string `json:"offer_currency" binding:"omitempty,iso4217"`
+	OfferReceivedDate string `json:"offer_received_date"`
+	Decision          string `json:"decision" binding:"omitempty,oneof=accepted declined pending"`
+}
+
+// parsedOfferDetails is OfferDetails converted to nullable columns
+type parsedOfferDetails struct {
+	Salary       sql.NullInt64
+	Currency     sql.NullString
+	ReceivedDate sql.NullTime
+	Decision     sql.NullString
+}
+
+// offerStatuses are the application statuses that may carry offer details
+var offerStatuses = map[string]bool{"offer": true, "accepted": true}
+
+// parseOfferDetails validates the offer fields from a request against its status
+// Sends a 400 response and returns false if offer fields are set for a non-offer status,
+// the salary is negative or has no currency, or the received date is malformed
+func parseOfferDetails(c *gin.Context, status string, offer OfferDetails) (parsedOfferDetails, bool) {
+	if offer == (OfferDetails{}) {
+		return parsedOfferDetails{}, true
+	}
+	if !offerStatuses[status] {
+		sendBadRequest(c, "Invalid offer details", "Offer fields are only allowed when status is offer or accepted")
+		return parsedOfferDetails{}, false
 	}
 
-	// Parse applied_date
-	appliedDate, err := time.Parse("2006-01-02", req.AppliedDate)
-	if err != nil {
-		sendBadRequest(c, "Invalid applied_date format", "Date must be in YYYY-MM-DD format (e.g., 2024-01-15)")
-		return
+	parsed := parsedOfferDetails{
+		Currency: sql.NullString{String: offer.OfferCurrency, Valid: offer.OfferCurrency != ""},
+		Decision: sql.NullString{String: offer.Decision, Valid: offer.Decision != ""},
 	}
+	if offer.OfferSalary != nil {
+		if *offer.OfferSalary < 0 {
+			sendBadRequest(c, "Invalid offer_salary", "offer_salary must not be negative")
+			return parsedOfferDetails{}, false
+		}
+		if !parsed.Currency.Valid {
+			sendBadRequest(c, "Invalid offer details", "offer_salary requires offer_currency")
+			return parsedOfferDetails{}, false
+		}
+		parsed.Salary = sql.NullInt64{Int64: *offer.OfferSalary, Valid: true}
+	}
+	if offer.OfferReceivedDate != "" {
+		receivedDate, err := parseDate(offer.OfferReceivedDate)
+		if err != nil {
+			sendDateError(c, "offer_received_date")
+			return parsedOfferDetails{}, false
+		}
+		parsed.ReceivedDate = sql.NullTime{Time: receivedDate, Valid: true}
+	}
+	return parsed, true
+}
 
-	// Get user_id from context (set by AuthMiddleware)
-	userID, ok := requireAuth(c)
+// closedStatuses are the application statuses that may carry a closed_reason
+var closedStatuses = map[string]bool{"rejected": true, "withdrawn": true}
+
+// parseClosedReason validates the closed_reason from a request against its status
+// Sends a 400 response and returns false if a reason is set for a status other than rejected or withdrawn
+func parseClosedReason(c *gin.Context, status, reason string) (sql.NullString, bool) {
+	if reason == "" {
+		return sql.NullString{}, true
+	}
+	if !closedStatuses[status] {
+		sendBadRequest(c, "Invalid closed_reason", "closed_reason is only allowed when status is rejected or withdrawn")
+		return sql.NullString{}, false
+	}
+	return sql.NullString{String: reason, Valid: true}, true
+}
+
+// createApplicationParams validates a create request and converts it to CreateApplicationParams
+// An inline "contact" is not resolved here (callers get-or-create it inside their transaction)
+// Sends a 400/500 response and returns false if the request is invalid
+func (h *ApplicationHandler) createApplicationParams(c *gin.Context, userID int32, req CreateApplicationRequest) (database.CreateApplicationParams, bool) {
+	// Parse applied_date, defaulting to today (UTC) when omitted
+	appliedDate := todayUTC()
+	if req.AppliedDate != "" {
+		var err error
+		appliedDate, err = parseDate(req.AppliedDate)
+		if err != nil {
+			sendDateError(c, "applied_date")
+			return database.CreateApplicationParams{}, false
+		}
+	}
+
+	nextAction, nextActionDue, ok := parseNextAction(c, req.NextAction, req.NextActionDue)
 	if !ok {
-		return
+		return database.CreateApplicationParams{}, false
 	}
 
-	// Get request context
-	ctx := c.Request.Context()
+	offer, ok := parseOfferDetails(c, req.Status, req.OfferDetails)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	closedReason, ok := parseClosedReason(c, req.Status, req.ClosedReason)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	if req.ContactID != nil && req.Contact != nil {
+		sendBadRequest(c, "Invalid contact", "Provide either contact_id or contact, not both")
+		return database.CreateApplicationParams{}, false
+	}
 
 	// Validate contact_id if provided (verify ownership)
 	var contactID sql.NullInt32
 	if req.ContactID != nil {
 		// Check if contact exists and belongs to this user
-		_, err := h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+		_, err := h.queries.GetContactByIDAndUserID(c.Request.Context(), database.GetContactByIDAndUserIDParams{
 			ID:     int32(*req.ContactID),
 			UserID: userID,
 		})
 		if err != nil {
 			if err == sql.ErrNoRows {
 				sendBadRequest(c, "Contact not found", "The specified contact ID does not exist or does not belong to you")
-				return
+				return database.CreateApplicationParams{}, false
 			}
 			sendInternalError(c, "Failed to validate contact", err)
-			return
+			return database.CreateApplicationParams{}, false
 		}
 		contactID = sql.NullInt32{Int32: int32(*req.ContactID), Valid: true}
 	}
 
-	// Create application (no job_id needed - jobs will reference applications)
-	application, err := h.queries.CreateApplication(ctx, database.CreateApplicationParams{
-		Status:      req.Status,
-		AppliedDate: appliedDate,
-		Notes:       sql.NullString{String: req.Notes, Valid: req.Notes != ""},
-		ContactID:   contactID,
-		UserID:      userID,
+	referredByContactID, ok := h.resolveReferredByContactID(c, userID, req.ReferredByContactID)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	return database.CreateApplicationParams{
+		Status:              req.Status,
+		AppliedDate:         appliedDate,
+		Notes:               sql.NullString{String: req.Notes, Valid: req.Notes != ""},
+		ContactID:           contactID,
+		UserID:              userID,
+		Source:              sql.NullString{String: req.Source, Valid: req.Source != ""},
+		NextAction:          nextAction,
+		NextActionDue:       nextActionDue,
+		OfferSalary:         offer.Salary,
+		OfferCurrency:       offer.Currency,
+		OfferReceivedDate:   offer.ReceivedDate,
+		Decision:            offer.Decision,
+		ReferredByContactID: referredByContactID,
+		ClosedReason:        closedReason,
+		CoverLetter:         sql.NullString{String: req.CoverLetter, Valid: req.CoverLetter != ""},
+	}, true
+}
+
+// CreateApplication handles POST /api/applications
+// Creates a new application
+// An inline "contact" object is get-or-created and linked in the same transaction
+func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
+	// Parse JSON body
+	var req CreateApplicationRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	params, ok := h.createApplicationParams(c, userID, req)
+	if !ok {
+		return
+	}
+
+	// Get request context
+	ctx := c.Request.Context()
+
+	// Create the inline contact (if any) and the application atomically
+	var application database.Application
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		if req.Contact != nil {
+			contact, _, err := getOrCreateContact(ctx, qtx, userID, *req.Contact)
+			if err != nil {
+				return err
+			}
+			params.ContactID = sql.NullInt32{Int32: contact.ID, Valid: true}
+		}
+
+		// Create application (no job_id needed - jobs will reference applications)
+		var err error
+		application, err = qtx.CreateApplication(ctx, params)
+		return err
 	})
 	if handleDatabaseError(c, err, "Application") {
 		return
 	}
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.webhooks.Publish(userID, WebhookEventApplicationCreated, application)
 
 	c.JSON(http.StatusCreated, application)
 }
 
 // UpdateApplicationRequest represents the JSON body for updating an application
+// Optional fields that are omitted leave the stored value unchanged; null or "" clears them
 type UpdateApplicationRequest struct {
-	Status      string `json:"status" binding:"required,oneof=applied interview offer rejected withdrawn accepted"`
-	AppliedDate string `json:"applied_date" binding:"required"` // ISO 8601 format: "2006-01-02" (validated manually)
-	ContactID   *int   `json:"contact_id"`                      // Optional contact ID (null to remove)
-	Notes       string `json:"notes" binding:"omitempty,max=5000"`
+	Status      string         `json:"status" binding:"required,oneof=applied interview offer rejected withdrawn accepted"`
+	AppliedDate string         `json:"applied_date" binding:"required"` // YYYY-MM-DD or RFC 3339 (validated manually)
+	ContactID   optionalInt    `json:"contact_id"`                      // Contact ID; null detaches the contact, omitted leaves it unchanged
+	Notes       string         `json:"notes" binding:"omitempty,max=5000"`
+	Source      optionalString `json:"source" binding:"omitempty,oneof=linkedin indeed referral company_site job_board recruiter other"`
+	// Optional next concrete to-do; both are left unchanged only when both are omitted
+	NextAction    optionalString `json:"next_action" binding:"omitempty,max=500"`
+	NextActionDue optionalString `json:"next_action_due"`
+	// Optional offer outcome; left unchanged only when every offer field is omitted
+	UpdateOfferDetails
+	ReferredByContactID optionalInt    `json:"referred_by_contact_id"`                    // Optional referring contact (null to remove)
+	ClosedReason        optionalString `json:"closed_reason" binding:"omitempty,max=500"` // Optional reason, only when status is rejected or withdrawn
+	CoverLetter         optionalString `json:"cover_letter" binding:"omitempty,max=50000"`
+}
+
+// UpdateOfferDetails is OfferDetails for updates, telling omitted fields from cleared ones
+type UpdateOfferDetails struct {
+	OfferSalary       optionalInt64  `json:"offer_salary"`
+	OfferCurrency     optionalString `json:"offer_currency" binding:"omitempty,iso4217"`
+	OfferReceivedDate optionalString `json:"offer_received_date"`
+	Decision          optionalString `json:"decision" binding:"omitempty,oneof=accepted declined pending"`
+}
+
+// set reports whether any offer field was present in the request
+func (o UpdateOfferDetails) set() bool {
+	return o.OfferSalary.Set || o.OfferCurrency.Set || o.OfferReceivedDate.Set || o.Decision.Set
+}
+
+// details returns the offer fields as OfferDetails, with omitted fields empty
+func (o UpdateOfferDetails) details() OfferDetails {
+	return OfferDetails{
+		OfferSalary:       o.OfferSalary.Value,
+		OfferCurrency:     o.OfferCurrency.Value,
+		OfferReceivedDate: o.OfferReceivedDate.Value,
+		Decision:          o.Decision.Value,
+	}
+}
+
+// parseNextAction validates the next_action/next_action_due pair from a request
+// Sends a 400 response and returns false if the due date is malformed or has no action
+func parseNextAction(c *gin.Context, action, due string) (sql.NullString, sql.NullTime, bool) {
+	nextAction := sql.NullString{String: action, Valid: action != ""}
+	if due == "" {
+		return nextAction, sql.NullTime{}, true
+	}
+
+	dueDate, err := parseDate(due)
+	if err != nil {
+		sendDateError(c, "next_action_due")
+		return sql.NullString{}, sql.NullTime{}, false
+	}
+	if !nextAction.Valid {
+		sendBadRequest(c, "Invalid next action", "next_action_due requires next_action")
+		return sql.NullString{}, sql.NullTime{}, false
+	}
+	return nextAction, sql.NullTime{Time: dueDate, Valid: true}, true
+}
+
+// resolveReferredByContactID verifies that the optional referring contact belongs to the user
+// Sends a 400/500 response and returns false if it doesn't exist or can't be checked
+func (h *ApplicationHandler) resolveReferredByContactID(c *gin.Context, userID int32, id *int) (sql.NullInt32, bool) {
+	if id == nil {
+		return sql.NullInt32{}, true
+	}
+
+	_, err := h.queries.GetContactByIDAndUserID(c.Request.Context(), database.GetContactByIDAndUserIDParams{
+		ID:     int32(*id),
+		UserID: userID,
+	})
+	if err == sql.ErrNoRows {
+		sendBadRequest(c, "Referring contact not found", "The specified referred_by_contact_id does not exist or does not belong to you")
+		return sql.NullInt32{}, false
+	}
+	if err != nil {
+		sendInternalError(c, "Failed to validate referring contact", err)
+		return sql.NullInt32{}, false
+	}
+	return sql.NullInt32{Int32: int32(*id), Valid: true}, true
 }
 
 // UpdateApplication handles PUT /api/applications/:id
@@ -301,18 +917,43 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 
 	// Parse JSON body
 	var req UpdateApplicationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
 
+	// Optional optimistic concurrency: If-Match carries the version the client last saw
+	expectedUpdatedAt, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Parse applied_date
-	appliedDate, err := time.Parse("2006-01-02", req.AppliedDate)
+	appliedDate, err := parseDate(req.AppliedDate)
 	if err != nil {
-		sendBadRequest(c, "Invalid applied_date format", "Date must be in YYYY-MM-DD format (e.g., 2024-01-15)")
+		sendDateError(c, "applied_date")
+		return
+	}
+
+	nextAction, nextActionDue, ok := parseNextAction(c, req.NextAction.Value, req.NextActionDue.Value)
+	if !ok {
 		return
 	}
 
+	offer, ok := parseOfferDetails(c, req.Status, req.UpdateOfferDetails.details())
+	if !ok {
+		return
+	}
+
+	closedReason, ok := parseClosedReason(c, req.Status, req.ClosedReason.Value)
+	if !ok {
+		return
+	}
+
+	// Omitted offer details and closed_reason are only kept while the new status still allows them
+	keepOffer := !req.UpdateOfferDetails.set() && offerStatuses[req.Status]
+	keepClosedReason := !req.ClosedReason.Set && closedStatuses[req.Status]
+
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
 	if !ok {
@@ -324,10 +965,10 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 
 	// Validate contact_id if provided (verify ownership)
 	var contactID sql.NullInt32
-	if req.ContactID != nil {
+	if req.ContactID.Value != nil {
 		// Check if contact exists and belongs to this user
 		_, err := h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
-			ID:     int32(*req.ContactID),
+			ID:     int32(*req.ContactID.Value),
 			UserID: userID,
 		})
 		if err != nil {
@@ -338,22 +979,55 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 			sendInternalError(c, "Failed to validate contact", err)
 			return
 		}
-		contactID = sql.NullInt32{Int32: int32(*req.ContactID), Valid: true}
+		contactID = sql.NullInt32{Int32: int32(*req.ContactID.Value), Valid: true}
+	}
+
+	referredByContactID, ok := h.resolveReferredByContactID(c, userID, req.ReferredByContactID.Value)
+	if !ok {
+		return
 	}
 
 	// Update application (verifies ownership via user_id)
 	application, err := h.queries.UpdateApplication(ctx, database.UpdateApplicationParams{
-		ID:          int32(id),
-		Status:      req.Status,
-		AppliedDate: appliedDate,
-		Notes:       sql.NullString{String: req.Notes, Valid: req.Notes != ""},
-		ContactID:   contactID,
-		UserID:      userID,
+		ID:                  int32(id),
+		Status:              req.Status,
+		AppliedDate:         appliedDate,
+		Notes:               sql.NullString{String: req.Notes, Valid: req.Notes != ""},
+		KeepContact:         !req.ContactID.Set,
+		ContactID:           contactID,
+		UserID:              userID,
+		KeepSource:          !req.Source.Set,
+		Source:              sql.NullString{String: req.Source.Value, Valid: req.Source.Value != ""},
+		KeepNextAction:      !req.NextAction.Set && !req.NextActionDue.Set,
+		NextAction:          nextAction,
+		NextActionDue:       nextActionDue,
+		KeepOffer:           keepOffer,
+		OfferSalary:         offer.Salary,
+		OfferCurrency:       offer.Currency,
+		OfferReceivedDate:   offer.ReceivedDate,
+		Decision:            offer.Decision,
+		KeepReferredBy:      !req.ReferredByContactID.Set,
+		ReferredByContactID: referredByContactID,
+		KeepClosedReason:    keepClosedReason,
+		ClosedReason:        closedReason,
+		KeepCoverLetter:     !req.CoverLetter.Set,
+		CoverLetter:         sql.NullString{String: req.CoverLetter.Value, Valid: req.CoverLetter.Value != ""},
+		ExpectedUpdatedAt:   expectedUpdatedAt,
 	})
-	if handleDatabaseError(c, err, "Application") {
+	if handleConditionalUpdateError(c, err, expectedUpdatedAt, "Application", func() error {
+		_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		return err
+	}) {
 		return
 	}
+	// Status may have changed, which shifts the status-filtered counts
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.webhooks.Publish(userID, WebhookEventApplicationUpdated, application)
 
+	setETag(c, application.UpdatedAt)
 	c.JSON(http.StatusOK, application)
 }
 
@@ -377,27 +1051,37 @@ func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	// Get request context
 	ctx := c.Request.Context()
 
-	// Check if application exists and belongs to user
-	_, err = h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
-		ID:     int32(id),
-		UserID: userID,
-	})
-	if handleDatabaseError(c, err, "Application") {
-		return
-	}
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var application database.Application
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if application exists and belongs to user
+		var err error
+		application, err = qtx.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
 
-	// Delete application (verifies ownership via user_id)
-	err = h.queries.DeleteApplication(ctx, database.DeleteApplicationParams{
-		ID:     int32(id),
-		UserID: userID,
+		// Delete application (verifies ownership via user_id)
+		return qtx.DeleteApplication(ctx, database.DeleteApplicationParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
 	})
 	if handleDatabaseError(c, err, "Application") {
 		return
 	}
+	// Deleting an application cascades to its job
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.counts.Invalidate(countResourceJobs, userID)
+	h.webhooks.Publish(userID, WebhookEventApplicationDeleted, application)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Application deleted successfully",
 		"id": id,
+		"deleted": application,
 	})
 }
 
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// BulkArchiveApplicationsRequest represents the JSON body for POST /api/applications/bulk-archive
+type BulkArchiveApplicationsRequest struct {
+	ApplicationIDs []int32 `json:"application_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+// ArchiveApplication handles POST /api/applications/:id/archive
+// Archives an application so it is hidden from the default lists and reminders (verifies ownership)
+// Archived applications keep their history and still count in stats
+func (h *ApplicationHandler) ArchiveApplication(c *gin.Context) {
+	h.setApplicationArchived(c, true)
+}
+
+// UnarchiveApplication handles POST /api/applications/:id/unarchive
+// Restores an archived application to the default lists (verifies ownership)
+func (h *ApplicationHandler) UnarchiveApplication(c *gin.Context) {
+	h.setApplicationArchived(c, false)
+}
+
+// setApplicationArchived archives or unarchives the application in the path and responds with it
+func (h *ApplicationHandler) setApplicationArchived(c *gin.Context, archived bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid application ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var application database.Application
+	if archived {
+		application, err = h.queries.ArchiveApplicationByIDAndUserID(ctx, database.ArchiveApplicationByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+	} else {
+		application, err = h.queries.UnarchiveApplicationByIDAndUserID(ctx, database.UnarchiveApplicationByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+	}
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.webhooks.Publish(userID, WebhookEventApplicationUpdated, application)
+
+	c.JSON(http.StatusOK, application)
+}
+
+// BulkArchiveApplications handles POST /api/applications/bulk-archive
+// Archives every listed application owned by the user in one transaction, at most MaxBatchIDs per request
+// The response lists the archived IDs under "application_ids" and the rest under "skipped_ids"
+// (applications that don't exist, aren't the user's, or were already archived)
+func (h *ApplicationHandler) BulkArchiveApplications(c *gin.Context) {
+	// Parse JSON body
+	var req BulkArchiveApplicationsRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ids := uniqueIDs(req.ApplicationIDs)
+	if len(ids) > MaxBatchIDs {
+		sendBadRequest(c, "Too many application_ids", fmt.Sprintf("At most %d application_ids can be archived at once", MaxBatchIDs))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// One statement archives every owned application, so the batch succeeds or fails as a whole
+	var archived []database.Application
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		var err error
+		archived, err = qtx.ArchiveApplicationsByIDsAndUserID(ctx, database.ArchiveApplicationsByIDsAndUserIDParams{
+			Ids:    ids,
+			UserID: userID,
+		})
+		return err
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to archive applications", err)
+		return
+	}
+
+	archivedIDs := make([]int32, len(archived))
+	for i, application := range archived {
+		archivedIDs[i] = application.ID
+		h.webhooks.Publish(userID, WebhookEventApplicationUpdated, application)
+	}
+	if len(archived) > 0 {
+		h.counts.Invalidate(countResourceApplications, userID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         fmt.Sprintf("Archived %d application(s)", len(archived)),
+		"application_ids": archivedIDs,
+		"skipped_ids":     skippedIDs(ids, archivedIDs),
+	})
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// SearchApplicationsRequest represents the JSON body for POST /api/applications/search
+// Every filter is optional and they combine with AND; unknown fields are ignored
+type SearchApplicationsRequest struct {
+	Statuses    []string `json:"statuses" binding:"omitempty,max=6,dive,oneof=applied interview offer rejected withdrawn accepted"` // matches any of the given statuses
+	Source      string   `json:"source" binding:"omitempty,oneof=linkedin indeed referral company_site job_board recruiter other"`
+	CompanyID   *int32   `json:"company_id" binding:"omitempty,min=1"` // company of the application's job
+	TagID       *int32   `json:"tag_id" binding:"omitempty,min=1"`
+	AppliedFrom string   `json:"applied_from"` // YYYY-MM-DD, inclusive (validated manually)
+	AppliedTo   string   `json:"applied_to"`   // YYYY-MM-DD, inclusive (validated manually)
+	Sort        string   `json:"sort"`         // same format as ?sort= on GET /api/applications
+	Page        int32    `json:"page" binding:"omitempty,min=1"`
+	Limit       int32    `json:"limit" binding:"omitempty,min=1,max=100"`
+	// Archived applications are left out unless include_archived is true
+	IncludeArchived bool `json:"include_archived"`
+}
+
+// parseSearchDate parses an optional date bound (see parseDate); an empty value means no bound
+func parseSearchDate(value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	date, err := parseDate(value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: truncateToDay(date), Valid: true}, nil
+}
+
+// SearchApplications handles POST /api/applications/search
+// Filters the user's applications by several fields at once and returns a PaginatedResponse
+// Defaults to page 1 with DefaultPageSize results, ordered like GET /api/applications
+// The body is optional: an empty body searches with no filters
+func (h *ApplicationHandler) SearchApplications(c *gin.Context) {
+	// Parse JSON body
+	var req SearchApplicationsRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, errEmptyBody) {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	appliedFrom, err := parseSearchDate(req.AppliedFrom)
+	if err != nil {
+		sendDateError(c, "applied_from")
+		return
+	}
+	appliedTo, err := parseSearchDate(req.AppliedTo)
+	if err != nil {
+		sendDateError(c, "applied_to")
+		return
+	}
+	if appliedFrom.Valid && appliedTo.Valid && appliedFrom.Time.After(appliedTo.Time) {
+		sendBadRequest(c, "Invalid date range", "applied_from must not be after applied_to")
+		return
+	}
+
+	listSort := defaultListSort(sortResourceApplications)
+	if req.Sort != "" {
+		listSort, err = parseListSort(sortResourceApplications, req.Sort)
+		if err != nil {
+			sendBadRequest(c, "Invalid sort", err.Error())
+			return
+		}
+	}
+
+	params := PaginationParams{Page: req.Page, Limit: req.Limit}
+	if params.Page == 0 {
+		params.Page = DefaultPage
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultPageSize
+	}
+	// Clamp deep pages like ParsePaginationParams
+	if maxPage := int32(maxPageFor(int(params.Limit))); params.Page > maxPage {
+		params.Page = maxPage
+	}
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	var companyID, tagID sql.NullInt32
+	if req.CompanyID != nil {
+		companyID = sql.NullInt32{Int32: *req.CompanyID, Valid: true}
+	}
+	if req.TagID != nil {
+		tagID = sql.NullInt32{Int32: *req.TagID, Valid: true}
+	}
+	countParams := database.CountSearchApplicationsByUserIDParams{
+		UserID:          userID,
+		Statuses:        req.Statuses,
+		Source:          sql.NullString{String: req.Source, Valid: req.Source != ""},
+		CompanyID:       companyID,
+		TagID:           tagID,
+		AppliedFrom:     appliedFrom,
+		AppliedTo:       appliedTo,
+		IncludeArchived: req.IncludeArchived,
+	}
+	if len(req.Statuses) == 0 {
+		countParams.Statuses = nil // no status filter
+	}
+
+	ctx := c.Request.Context()
+
+	applications, err := h.queries.SearchApplicationsByUserID(ctx, database.SearchApplicationsByUserIDParams{
+		UserID:          countParams.UserID,
+		Statuses:        countParams.Statuses,
+		Source:          countParams.Source,
+		CompanyID:       countParams.CompanyID,
+		TagID:           countParams.TagID,
+		AppliedFrom:     countParams.AppliedFrom,
+		AppliedTo:       countParams.AppliedTo,
+		IncludeArchived: countParams.IncludeArchived,
+		SortKey:         listSort.key(),
+		RowLimit:        params.Limit,
+		RowOffset:       offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to search applications", err)
+		return
+	}
+
+	totalCount, err := h.queries.CountSearchApplicationsByUserID(ctx, countParams)
+	if err != nil {
+		sendInternalError(c, "Failed to count applications", err)
+		return
+	}
+
+	data := make([]interface{}, len(applications))
+	for i, app := range applications {
+		data[i] = app
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -199,6 +200,169 @@ func TestGetAllApplications_WithStatusFilter(t *testing.T) {
 	}
 }
 
+// TestGetAllApplications_WithSourceFilter tests GET /api/applications?source=linkedin
+func TestGetAllApplications_WithSourceFilter(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-source@example.com")
+	defer cleanup()
+
+	linkedin := createTestApplication(t, queries, testUser.ID, "applied", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "applied", "referral")
+
+	req := httptest.NewRequest("GET", "/api/applications?source=linkedin", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var applications []database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 1 || applications[0].ID != linkedin.ID {
+		t.Errorf("Expected only the linkedin application, got %+v", applications)
+	}
+
+	// Test invalid source
+	req = httptest.NewRequest("GET", "/api/applications?source=carrier-pigeon", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestGetAllApplications_ByIDs tests GET /api/applications?ids=
+func TestGetAllApplications_ByIDs(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-by-ids@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-applications-by-ids-other@example.com")
+	defer otherCleanup()
+
+	first := createTestApplication(t, queries, testUser.ID, "applied", "")
+	second := createTestApplication(t, queries, testUser.ID, "interview", "")
+	foreign := createTestApplication(t, queries, otherUser.ID, "applied", "")
+
+	getByIDs := func(ids string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/applications?ids="+ids, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test request order is preserved and other users' applications are skipped
+	ids := strconv.Itoa(int(second.ID)) + "," + strconv.Itoa(int(foreign.ID)) + "," + strconv.Itoa(int(first.ID))
+	w := getByIDs(ids)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var applications []database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 2 {
+		t.Fatalf("Expected 2 applications, got %d", len(applications))
+	}
+	if applications[0].ID != second.ID || applications[1].ID != first.ID {
+		t.Errorf("Expected order [%d %d], got [%d %d]", second.ID, first.ID, applications[0].ID, applications[1].ID)
+	}
+
+	// Test non-numeric ids
+	if w = getByIDs("1,abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for non-numeric id, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test too many ids
+	many := make([]string, MaxBatchIDs+1)
+	for i := range many {
+		many[i] = strconv.Itoa(i + 1)
+	}
+	if w = getByIDs(strings.Join(many, ",")); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for too many ids, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestGetAllApplications_WithFlags tests GET /api/applications?with_flags=true
+func TestGetAllApplications_WithFlags(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-with-flags@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	complete := createTestApplication(t, queries, testUser.ID, "applied", "")
+	bare := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	// Attach a job and a resume to the first application only
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Flags Corp",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{
+		ApplicationID: complete.ID,
+		CompanyID:     company.ID,
+		Title:         "Engineer",
+	}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	if _, err := queries.CreateDocument(ctx, database.CreateDocumentParams{
+		ApplicationID: complete.ID,
+		Label:         "Resume",
+		Url:           "https://example.com/resume.pdf",
+		Type:          "resume",
+	}); err != nil {
+		t.Fatalf("Failed to create test document: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/applications?with_flags=true", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var rows []database.GetApplicationsWithFlagsFilteredByUserIDRow
+	if err := json.Unmarshal(w.Body.Bytes(), &rows); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 applications, got %d", len(rows))
+	}
+	for _, row := range rows {
+		switch row.ID {
+		case complete.ID:
+			if !row.HasJob || !row.HasResume || row.HasContact {
+				t.Errorf("Expected job and resume flags only, got %+v", row)
+			}
+		case bare.ID:
+			if row.HasJob || row.HasResume || row.HasContact {
+				t.Errorf("Expected no flags, got %+v", row)
+			}
+		}
+	}
+}
+
 // TestGetApplicationByID tests GET /api/applications/:id
 func TestGetApplicationByID(t *testing.T) {
 	router, queries, db := setupTestRouter(t)
@@ -449,6 +613,90 @@ func TestCreateApplication(t *testing.T) {
 	}
 }
 
+// TestCreateApplication_WithInlineContact tests POST /api/applications with an inline contact object
+func TestCreateApplication_WithInlineContact(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-inline-contact@example.com")
+	defer cleanup()
+
+	appliedDate := time.Now().Format("2006-01-02")
+	createWithContact := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/applications", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test creation with a new inline contact
+	w := createWithContact(map[string]interface{}{
+		"status":       "applied",
+		"applied_date": appliedDate,
+		"contact": map[string]interface{}{
+			"name":  "Recruiter Rita",
+			"email": "rita@example.com",
+		},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var first database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !first.ContactID.Valid {
+		t.Fatal("Expected application to be linked to the inline contact")
+	}
+
+	// Test get-or-create: same email (different case) reuses the contact
+	w = createWithContact(map[string]interface{}{
+		"status":       "applied",
+		"applied_date": appliedDate,
+		"contact": map[string]interface{}{
+			"name":  "Rita",
+			"email": "RITA@example.com",
+		},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var second database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if second.ContactID.Int32 != first.ContactID.Int32 {
+		t.Errorf("Expected contact %d to be reused, got %d", first.ContactID.Int32, second.ContactID.Int32)
+	}
+
+	// Test contact_id and contact together are rejected
+	w = createWithContact(map[string]interface{}{
+		"status":       "applied",
+		"applied_date": appliedDate,
+		"contact_id":   first.ContactID.Int32,
+		"contact":      map[string]interface{}{"name": "Someone"},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	// Test invalid inline contact fails validation (nothing is created)
+	w = createWithContact(map[string]interface{}{
+		"status":       "applied",
+		"applied_date": appliedDate,
+		"contact":      map[string]interface{}{"name": "Bad Email", "email": "not-an-email"},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
 // TestUpdateApplication tests PUT /api/applications/:id
 func TestUpdateApplication(t *testing.T) {
 	router, queries, db := setupTestRouter(t)
@@ -540,6 +788,63 @@ func TestUpdateApplication(t *testing.T) {
 	}
 }
 
+// TestUpdateApplication_IfMatch tests optimistic concurrency on PUT /api/applications/:id
+func TestUpdateApplication_IfMatch(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-if-match@example.com")
+	defer cleanup()
+
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	update := func(ifMatch string, status string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"status":       status,
+			"applied_date": time.Now().Format("2006-01-02"),
+		})
+		req := httptest.NewRequest("PUT", "/api/applications/"+strconv.Itoa(int(application.ID)), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test update with the current ETag succeeds and returns a new ETag
+	currentETag := resourceETag(application.UpdatedAt)
+	w := update(currentETag, "interview")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	newETag := w.Header().Get("ETag")
+	if newETag == "" || newETag == currentETag {
+		t.Errorf("Expected a new ETag after update, got %q", newETag)
+	}
+
+	// Test update with the stale ETag is rejected
+	w = update(currentETag, "offer")
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusPreconditionFailed, w.Code, w.Body.String())
+	}
+
+	// Test update without If-Match keeps last-write-wins behavior
+	w = update("", "offer")
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Test malformed If-Match
+	w = update(`"not-a-version"`, "offer")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
+
 // TestDeleteApplication tests DELETE /api/applications/:id
 func TestDeleteApplication(t *testing.T) {
 	router, queries, db := setupTestRouter(t)
@@ -906,3 +1211,853 @@ func TestGetAllApplications_PaginationEdgeCases(t *testing.T) {
 	}
 }
 
+
+// TestCreateApplication_OfferDetails tests offer fields on POST /api/applications
+func TestCreateApplication_OfferDetails(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-offer@example.com")
+	defer cleanup()
+
+	tests := []struct {
+		name           string
+		body           map[string]interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "Offer with salary and currency",
+			body:           map[string]interface{}{"status": "offer", "offer_salary": 120000, "offer_currency": "USD", "offer_received_date": "2024-02-01", "decision": "pending"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Offer fields on a non-offer status",
+			body:           map[string]interface{}{"status": "applied", "offer_salary": 120000, "offer_currency": "USD"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Salary without currency",
+			body:           map[string]interface{}{"status": "offer", "offer_salary": 120000},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Negative salary",
+			body:           map[string]interface{}{"status": "offer", "offer_salary": -1, "offer_currency": "USD"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown currency",
+			body:           map[string]interface{}{"status": "offer", "offer_salary": 120000, "offer_currency": "XYZ"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid decision",
+			body:           map[string]interface{}{"status": "accepted", "decision": "maybe"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid received date",
+			body:           map[string]interface{}{"status": "offer", "offer_received_date": "01/02/2024"},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.body["applied_date"] = "2024-01-15"
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/applications", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusCreated {
+				return
+			}
+
+			var application database.Application
+			if err := json.Unmarshal(w.Body.Bytes(), &application); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if application.OfferSalary.Int64 != 120000 || application.OfferCurrency.String != "USD" || application.Decision.String != "pending" {
+				t.Errorf("Unexpected offer details: %+v", application)
+			}
+		})
+	}
+}
+
+// TestCountApplications tests GET /api/applications/count
+func TestCountApplications(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-count@example.com")
+	defer cleanup()
+
+	createTestApplication(t, queries, testUser.ID, "applied", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "applied", "referral")
+	createTestApplication(t, queries, testUser.ID, "interview", "linkedin")
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedCount  int64
+	}{
+		{"All applications", "", http.StatusOK, 3},
+		{"Status filter", "?status=applied", http.StatusOK, 2},
+		{"Status and source filters", "?status=applied&source=linkedin", http.StatusOK, 1},
+		{"Invalid source", "?source=fax", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/applications/count"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Count int64 `json:"count"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Count != tt.expectedCount {
+				t.Errorf("Expected count %d, got %d", tt.expectedCount, response.Count)
+			}
+		})
+	}
+}
+
+// TestGetApplicationByID_IncludeHistory tests GET /api/applications/:id?include=history
+func TestGetApplicationByID_IncludeHistory(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-history@example.com")
+	defer cleanup()
+
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+	path := "/api/applications/" + strconv.Itoa(int(application.ID))
+
+	// Move the application to interview, then save it again without a status change
+	for i := 0; i < 2; i++ {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"status": "interview", "applied_date": "2024-01-15"})
+		req := httptest.NewRequest("PUT", path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	get := func(query string) map[string]json.RawMessage {
+		req := httptest.NewRequest("GET", path+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response map[string]json.RawMessage
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	// Test history is opt-in
+	if _, ok := get("")["history"]; ok {
+		t.Error("Expected no history without ?include=history")
+	}
+
+	for _, query := range []string{"?include=history", "?include=history&expand=job"} {
+		response := get(query)
+		var history []database.ApplicationStatusHistory
+		if err := json.Unmarshal(response["history"], &history); err != nil {
+			t.Fatalf("Failed to parse history for %q: %v", query, err)
+		}
+		if len(history) != 2 || history[0].Status != "applied" || history[1].Status != "interview" {
+			t.Errorf("Expected history [applied interview] for %q, got %+v", query, history)
+		}
+		if _, ok := response["status"]; !ok {
+			t.Errorf("Expected the application's own fields for %q", query)
+		}
+	}
+}
+
+// TestUpdateApplication_ContactID tests that contact_id set attaches, null detaches and omitted keeps the contact
+func TestUpdateApplication_ContactID(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-update-contact@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	contact, err := queries.CreateContact(ctx, database.CreateContactParams{
+		Name:   "Recruiter",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+
+	application, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+		Status:      "applied",
+		AppliedDate: time.Now(),
+		UserID:      testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+
+	update := func(body map[string]interface{}) database.Application {
+		body["status"] = "applied"
+		body["applied_date"] = time.Now().Format("2006-01-02")
+		jsonBody, _ := json.Marshal(body)
+
+		req := httptest.NewRequest("PUT", "/api/applications/"+strconv.Itoa(int(application.ID)), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var updated database.Application
+		if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return updated
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		updated := update(map[string]interface{}{"contact_id": contact.ID})
+		if !updated.ContactID.Valid || updated.ContactID.Int32 != contact.ID {
+			t.Errorf("Expected contact_id %d, got %+v", contact.ID, updated.ContactID)
+		}
+	})
+
+	t.Run("Unchanged when omitted", func(t *testing.T) {
+		updated := update(map[string]interface{}{"notes": "No contact change"})
+		if !updated.ContactID.Valid || updated.ContactID.Int32 != contact.ID {
+			t.Errorf("Expected contact_id %d to be kept, got %+v", contact.ID, updated.ContactID)
+		}
+	})
+
+	t.Run("Cleared with null", func(t *testing.T) {
+		updated := update(map[string]interface{}{"contact_id": nil})
+		if updated.ContactID.Valid {
+			t.Errorf("Expected contact_id to be cleared, got %+v", updated.ContactID)
+		}
+	})
+}
+
+// TestUpdateApplication_OmittedFieldsKept tests that a PUT with only status and applied_date keeps the optional fields
+func TestUpdateApplication_OmittedFieldsKept(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-update-omitted@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	referrer, err := queries.CreateContact(ctx, database.CreateContactParams{
+		Name:   "Referrer",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+
+	update := func(id int32, body map[string]interface{}) database.Application {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("PUT", "/api/applications/"+strconv.Itoa(int(id)), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var updated database.Application
+		if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return updated
+	}
+	today := time.Now().Format("2006-01-02")
+
+	t.Run("Offer application", func(t *testing.T) {
+		application, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+			Status:              "offer",
+			AppliedDate:         time.Now(),
+			UserID:              testUser.ID,
+			Source:              sql.NullString{String: "referral", Valid: true},
+			NextAction:          sql.NullString{String: "Reply to recruiter", Valid: true},
+			NextActionDue:       sql.NullTime{Time: time.Now(), Valid: true},
+			OfferSalary:         sql.NullInt64{Int64: 100000, Valid: true},
+			OfferCurrency:       sql.NullString{String: "USD", Valid: true},
+			Decision:            sql.NullString{String: "pending", Valid: true},
+			ReferredByContactID: sql.NullInt32{Int32: referrer.ID, Valid: true},
+			CoverLetter:         sql.NullString{String: "Dear hiring manager", Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test application: %v", err)
+		}
+
+		updated := update(application.ID, map[string]interface{}{"status": "offer", "applied_date": today})
+		if updated.Source.String != "referral" {
+			t.Errorf("Expected source to be kept, got %+v", updated.Source)
+		}
+		if updated.NextAction.String != "Reply to recruiter" || !updated.NextActionDue.Valid {
+			t.Errorf("Expected next action to be kept, got %+v / %+v", updated.NextAction, updated.NextActionDue)
+		}
+		if updated.OfferSalary.Int64 != 100000 || updated.OfferCurrency.String != "USD" || updated.Decision.String != "pending" {
+			t.Errorf("Expected offer details to be kept, got %+v %+v %+v", updated.OfferSalary, updated.OfferCurrency, updated.Decision)
+		}
+		if updated.ReferredByContactID.Int32 != referrer.ID {
+			t.Errorf("Expected referred_by_contact_id %d to be kept, got %+v", referrer.ID, updated.ReferredByContactID)
+		}
+		if updated.CoverLetter.String != "Dear hiring manager" {
+			t.Errorf("Expected cover_letter to be kept, got %+v", updated.CoverLetter)
+		}
+
+		// Explicit null and "" still clear
+		updated = update(application.ID, map[string]interface{}{
+			"status":                 "offer",
+			"applied_date":           today,
+			"source":                 nil,
+			"next_action":            "",
+			"referred_by_contact_id": nil,
+			"cover_letter":           "",
+		})
+		if updated.Source.Valid || updated.NextAction.Valid || updated.NextActionDue.Valid || updated.ReferredByContactID.Valid || updated.CoverLetter.Valid {
+			t.Errorf("Expected cleared fields, got %+v", updated)
+		}
+		if !updated.OfferCurrency.Valid {
+			t.Errorf("Expected omitted offer details to be kept, got %+v", updated.OfferCurrency)
+		}
+
+		// Omitted offer details are dropped once the status no longer allows them
+		updated = update(application.ID, map[string]interface{}{"status": "interview", "applied_date": today})
+		if updated.OfferSalary.Valid || updated.OfferCurrency.Valid || updated.Decision.Valid {
+			t.Errorf("Expected offer details to be cleared for status interview, got %+v", updated)
+		}
+	})
+
+	t.Run("Rejected application", func(t *testing.T) {
+		application, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+			Status:       "rejected",
+			AppliedDate:  time.Now(),
+			UserID:       testUser.ID,
+			ClosedReason: sql.NullString{String: "Position filled", Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test application: %v", err)
+		}
+
+		updated := update(application.ID, map[string]interface{}{"status": "rejected", "applied_date": today})
+		if updated.ClosedReason.String != "Position filled" {
+			t.Errorf("Expected closed_reason to be kept, got %+v", updated.ClosedReason)
+		}
+
+		updated = update(application.ID, map[string]interface{}{"status": "applied", "applied_date": today})
+		if updated.ClosedReason.Valid {
+			t.Errorf("Expected closed_reason to be cleared for status applied, got %+v", updated.ClosedReason)
+		}
+	})
+}
+
+// TestGetUsedStatuses tests GET /api/applications/used-statuses
+func TestGetUsedStatuses(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-used-statuses@example.com")
+	defer cleanup()
+
+	getStatuses := func() []string {
+		req := httptest.NewRequest("GET", "/api/applications/used-statuses", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var statuses []string
+		if err := json.Unmarshal(w.Body.Bytes(), &statuses); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return statuses
+	}
+
+	// Test no applications yet
+	if statuses := getStatuses(); statuses == nil || len(statuses) != 0 {
+		t.Errorf("Expected an empty array, got %v", statuses)
+	}
+
+	createTestApplication(t, queries, testUser.ID, "rejected", "")
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	statuses := getStatuses()
+	if len(statuses) != 2 || statuses[0] != "applied" || statuses[1] != "rejected" {
+		t.Errorf("Expected [applied rejected], got %v", statuses)
+	}
+}
+
+// TestGetAllApplications_MissingJob tests GET /api/applications?missing_job=true
+func TestGetAllApplications_MissingJob(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-missing-job@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Test Company for MissingJob",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	withJob := createTestApplication(t, queries, testUser.ID, "applied", "")
+	withoutJob := createTestApplication(t, queries, testUser.ID, "applied", "")
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: withJob.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	var applications []database.Application
+	if err := json.Unmarshal(get("/api/applications?missing_job=true").Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 1 || applications[0].ID != withoutJob.ID {
+		t.Errorf("Expected only application %d, got %+v", withoutJob.ID, applications)
+	}
+
+	var paginated PaginatedResponse
+	if err := json.Unmarshal(get("/api/applications?missing_job=true&page=1&limit=10").Body.Bytes(), &paginated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if paginated.Meta.TotalCount != 1 || len(paginated.Data) != 1 {
+		t.Errorf("Expected 1 application in total, got %+v", paginated.Meta)
+	}
+
+	var count map[string]int64
+	if err := json.Unmarshal(get("/api/applications/count?missing_job=true").Body.Bytes(), &count); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if count["count"] != 1 {
+		t.Errorf("Expected count 1, got %v", count)
+	}
+}
+
+// TestGetAllApplications_MissingResume tests GET /api/applications?missing_resume=true
+func TestGetAllApplications_MissingResume(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-missing-resume@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	withResume := createTestApplication(t, queries, testUser.ID, "applied", "")
+	withCoverLetter := createTestApplication(t, queries, testUser.ID, "applied", "")
+	withoutDocuments := createTestApplication(t, queries, testUser.ID, "applied", "")
+	for _, doc := range []database.CreateDocumentParams{
+		{ApplicationID: withResume.ID, Label: "Resume", Url: "https://example.com/resume", Type: "resume"},
+		{ApplicationID: withCoverLetter.ID, Label: "Cover letter", Url: "https://example.com/cover", Type: "cover_letter"},
+	} {
+		if _, err := queries.CreateDocument(ctx, doc); err != nil {
+			t.Fatalf("Failed to create test document: %v", err)
+		}
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	var applications []database.Application
+	if err := json.Unmarshal(get("/api/applications?missing_resume=true&sort=created_at:asc").Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 2 || applications[0].ID != withCoverLetter.ID || applications[1].ID != withoutDocuments.ID {
+		t.Errorf("Expected applications %d and %d, got %+v", withCoverLetter.ID, withoutDocuments.ID, applications)
+	}
+
+	var paginated PaginatedResponse
+	if err := json.Unmarshal(get("/api/applications?missing_resume=true&page=1&limit=1").Body.Bytes(), &paginated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if paginated.Meta.TotalCount != 2 || paginated.Meta.TotalPages != 2 || len(paginated.Data) != 1 {
+		t.Errorf("Expected 2 applications over 2 pages, got %+v", paginated.Meta)
+	}
+
+	var count map[string]int64
+	if err := json.Unmarshal(get("/api/applications/count?missing_resume=true").Body.Bytes(), &count); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if count["count"] != 2 {
+		t.Errorf("Expected count 2, got %v", count)
+	}
+}
+
+// TestGetAllApplications_MissingCoverLetter tests the cover_letter field and GET /api/applications?missing_cover_letter=true
+func TestGetAllApplications_MissingCoverLetter(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-missing-cover-letter@example.com")
+	defer cleanup()
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/applications", `{"status": "applied", "cover_letter": "Dear hiring manager"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var withCoverLetter database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &withCoverLetter); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if withCoverLetter.CoverLetter.String != "Dear hiring manager" {
+		t.Errorf("Expected cover letter to be stored, got %+v", withCoverLetter.CoverLetter)
+	}
+	withoutCoverLetter := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	w = send("POST", "/api/applications", `{"status": "applied", "cover_letter": "`+strings.Repeat("a", 50001)+`"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an oversized cover letter, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w = send("GET", "/api/applications?missing_cover_letter=true", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var applications []database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 1 || applications[0].ID != withoutCoverLetter.ID {
+		t.Errorf("Expected only application %d, got %+v", withoutCoverLetter.ID, applications)
+	}
+
+	// Updating without a cover letter clears it
+	w = send("PUT", "/api/applications/"+strconv.Itoa(int(withCoverLetter.ID)), `{"status": "applied", "applied_date": "2024-01-15"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var count map[string]int64
+	if err := json.Unmarshal(send("GET", "/api/applications/count?missing_cover_letter=true", "").Body.Bytes(), &count); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if count["count"] != 2 {
+		t.Errorf("Expected count 2, got %v", count)
+	}
+}
+
+// TestGetAllApplications_SortByLastActivity tests that job and note changes bump last_activity_at for ?sort=last_activity
+func TestGetAllApplications_SortByLastActivity(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-last-activity@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Test Company for LastActivity", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	withNote := createTestApplication(t, queries, testUser.ID, "applied", "")
+	withJob := createTestApplication(t, queries, testUser.ID, "applied", "")
+	untouched := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: withJob.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	if _, err := queries.CreateApplicationNote(ctx, database.CreateApplicationNoteParams{ApplicationID: withNote.ID, Body: "Followed up"}); err != nil {
+		t.Fatalf("Failed to create test note: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/applications?sort=last_activity:desc", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var applications []database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(applications) != 3 || applications[0].ID != withNote.ID || applications[1].ID != withJob.ID || applications[2].ID != untouched.ID {
+		t.Errorf("Expected applications %d, %d, %d, got %+v", withNote.ID, withJob.ID, untouched.ID, applications)
+	}
+	if !applications[0].LastActivityAt.Valid || !applications[0].LastActivityAt.Time.After(untouched.LastActivityAt.Time) {
+		t.Errorf("Expected last_activity_at after %v, got %+v", untouched.LastActivityAt, applications[0].LastActivityAt)
+	}
+}
+
+// TestSearchApplications tests POST /api/applications/search
+func TestSearchApplications(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-search@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Test Company for Search",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	tag, err := queries.CreateTag(ctx, database.CreateTagParams{Name: "search-test", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test tag: %v", err)
+	}
+	match := createTestApplication(t, queries, testUser.ID, "interview", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "applied", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "offer", "referral")
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: match.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	if _, err := queries.AttachTagToApplications(ctx, database.AttachTagToApplicationsParams{TagID: tag.ID, ApplicationIds: []int32{match.ID}, UserID: testUser.ID}); err != nil {
+		t.Fatalf("Failed to attach test tag: %v", err)
+	}
+
+	search := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/applications/search", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	searchOK := func(body map[string]interface{}) PaginatedResponse {
+		w := search(body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response PaginatedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+	today := time.Now().Format("2006-01-02")
+
+	t.Run("No filters", func(t *testing.T) {
+		response := searchOK(map[string]interface{}{})
+		if response.Meta.TotalCount != 3 || response.Meta.Page != 1 || response.Meta.Limit != DefaultPageSize {
+			t.Errorf("Expected 3 applications on page 1, got %+v", response.Meta)
+		}
+	})
+
+	t.Run("Combined filters", func(t *testing.T) {
+		response := searchOK(map[string]interface{}{
+			"statuses":     []string{"applied", "interview"},
+			"source":       "linkedin",
+			"company_id":   company.ID,
+			"tag_id":       tag.ID,
+			"applied_from": today,
+			"applied_to":   today,
+			"favorite":     true, // unknown fields are ignored
+		})
+		if response.Meta.TotalCount != 1 || len(response.Data) != 1 {
+			t.Fatalf("Expected 1 application, got %+v", response.Meta)
+		}
+		if id := response.Data[0].(map[string]interface{})["id"]; id != float64(match.ID) {
+			t.Errorf("Expected application %d, got %v", match.ID, id)
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		response := searchOK(map[string]interface{}{"statuses": []string{"applied", "offer"}, "page": 2, "limit": 1})
+		if response.Meta.TotalCount != 2 || response.Meta.TotalPages != 2 || len(response.Data) != 1 {
+			t.Errorf("Expected 1 of 2 applications, got %+v (%d rows)", response.Meta, len(response.Data))
+		}
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{"statuses": []string{"pending"}},
+			{"source": "newspaper"},
+			{"company_id": 0},
+			{"applied_from": "15/01/2024"},
+			{"applied_from": "2024-02-01", "applied_to": "2024-01-01"},
+			{"sort": "title:asc"},
+			{"limit": 500},
+		} {
+			if w := search(body); w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d for %v, got %d", http.StatusBadRequest, body, w.Code)
+			}
+		}
+	})
+}
+
+// TestBulkArchiveApplications tests POST /api/applications/bulk-archive and the archived list filter
+func TestBulkArchiveApplications(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-bulk-archive@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-applications-bulk-archive-other@example.com")
+	defer otherCleanup()
+
+	first := createTestApplication(t, queries, testUser.ID, "rejected", "linkedin")
+	second := createTestApplication(t, queries, testUser.ID, "withdrawn", "indeed")
+	active := createTestApplication(t, queries, testUser.ID, "interview", "referral")
+	foreign := createTestApplication(t, queries, otherUser.ID, "applied", "linkedin")
+
+	send := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listIDs := func(path string) []int32 {
+		w := send("GET", path, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var applications []database.Application
+		if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		ids := make([]int32, len(applications))
+		for i, application := range applications {
+			ids[i] = application.ID
+		}
+		return ids
+	}
+
+	// Archive two applications; the other user's ID and a duplicate are skipped
+	w := send("POST", "/api/applications/bulk-archive", map[string]interface{}{
+		"application_ids": []int32{first.ID, second.ID, foreign.ID, first.ID},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var result struct {
+		ApplicationIDs []int32 `json:"application_ids"`
+		SkippedIDs     []int32 `json:"skipped_ids"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(result.ApplicationIDs) != 2 || len(result.SkippedIDs) != 1 || result.SkippedIDs[0] != foreign.ID {
+		t.Errorf("Expected 2 archived and the foreign ID skipped, got %+v", result)
+	}
+
+	// Archiving again skips already archived applications
+	w = send("POST", "/api/applications/bulk-archive", map[string]interface{}{"application_ids": []int32{first.ID}})
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(result.ApplicationIDs) != 0 || len(result.SkippedIDs) != 1 {
+		t.Errorf("Expected the archived application to be skipped, got %+v", result)
+	}
+
+	// Archived applications are hidden by default and listed with ?include_archived=true
+	if ids := listIDs("/api/applications"); len(ids) != 1 || ids[0] != active.ID {
+		t.Errorf("Expected only the active application, got %v", ids)
+	}
+	if ids := listIDs("/api/applications?include_archived=true"); len(ids) != 3 {
+		t.Errorf("Expected 3 applications including archived, got %v", ids)
+	}
+
+	// The other user's application is untouched
+	other, err := queries.GetApplicationByIDAndUserID(context.Background(), database.GetApplicationByIDAndUserIDParams{ID: foreign.ID, UserID: otherUser.ID})
+	if err != nil || other.ArchivedAt.Valid {
+		t.Errorf("Expected the other user's application to stay unarchived, got %+v (err %v)", other, err)
+	}
+
+	// Unarchive restores an application to the default list
+	w = send("POST", "/api/applications/"+strconv.Itoa(int(first.ID))+"/unarchive", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if ids := listIDs("/api/applications"); len(ids) != 2 {
+		t.Errorf("Expected 2 applications after unarchiving, got %v", ids)
+	}
+
+	// The list length is capped
+	tooMany := make([]int32, MaxBatchIDs+1)
+	for i := range tooMany {
+		tooMany[i] = int32(i + 1)
+	}
+	if w = send("POST", "/api/applications/bulk-archive", map[string]interface{}{"application_ids": tooMany}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for too many IDs, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w = send("POST", "/api/applications/bulk-archive", map[string]interface{}{"application_ids": []int32{}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for no IDs, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be uploaded as an attachment (10 MB)
+	MaxAttachmentSize = 10 << 20
+	// attachmentFormField is the multipart form field holding the uploaded file
+	attachmentFormField = "file"
+	// maxAttachmentFilenameLength matches the filename column
+	maxAttachmentFilenameLength = 255
+
+	// DefaultMaxAttachmentsPerContact is the default number of files one contact can hold
+	DefaultMaxAttachmentsPerContact = 20
+	// DefaultAttachmentStorageQuota is the default total size of a user's attachments (100 MB)
+	DefaultAttachmentStorageQuota = 100 << 20
+)
+
+// MaxAttachmentsPerContact caps the files attached to one contact (0 disables the limit)
+var MaxAttachmentsPerContact = DefaultMaxAttachmentsPerContact
+
+// AttachmentStorageQuota caps the total bytes a user can store as attachments (0 disables the quota)
+var AttachmentStorageQuota int64 = DefaultAttachmentStorageQuota
+
+// allowedAttachmentTypes lists the file types that can be uploaded (scans, photos and PDFs)
+// The type is sniffed from the file's content; the client's Content-Type is ignored
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+// attachmentUpload is a validated uploaded file
+type attachmentUpload struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// readAttachmentUpload reads the file in the "file" form field of a multipart request
+// and checks it against MaxAttachmentSize and allowedAttachmentTypes
+// Sends a 400 (or 413 for an oversized file) response and returns false if the upload is invalid
+func readAttachmentUpload(c *gin.Context) (attachmentUpload, bool) {
+	// Leave room for the multipart framing around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAttachmentSize+1<<20)
+
+	fileHeader, err := c.FormFile(attachmentFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sendAttachmentTooLarge(c)
+			return attachmentUpload{}, false
+		}
+		sendBadRequest(c, "Invalid upload", "Send the file as multipart/form-data in the \""+attachmentFormField+"\" field")
+		return attachmentUpload{}, false
+	}
+	if fileHeader.Size > MaxAttachmentSize {
+		sendAttachmentTooLarge(c)
+		return attachmentUpload{}, false
+	}
+	if fileHeader.Size == 0 {
+		sendBadRequest(c, "Invalid upload", "The file is empty")
+		return attachmentUpload{}, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		sendInternalError(c, "Failed to read upload", err)
+		return attachmentUpload{}, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxAttachmentSize+1))
+	if err != nil {
+		sendInternalError(c, "Failed to read upload", err)
+		return attachmentUpload{}, false
+	}
+	if len(data) > MaxAttachmentSize {
+		sendAttachmentTooLarge(c)
+		return attachmentUpload{}, false
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !allowedAttachmentTypes[contentType] {
+		sendBadRequest(c, "Unsupported file type", "Attachments must be PDF, JPEG, PNG, GIF or WebP files")
+		return attachmentUpload{}, false
+	}
+
+	return attachmentUpload{
+		Filename:    cleanAttachmentFilename(fileHeader.Filename),
+		ContentType: contentType,
+		Data:        data,
+	}, true
+}
+
+// sendAttachmentTooLarge sends a 413 Request Entity Too Large error for an oversized upload
+func sendAttachmentTooLarge(c *gin.Context) {
+	sendError(c, http.StatusRequestEntityTooLarge, "File too large", fmt.Sprintf("Attachments can be at most %d MB", MaxAttachmentSize>>20))
+}
+
+// sendAttachmentLimitReached sends a 409 Conflict error when a contact already holds MaxAttachmentsPerContact files
+func sendAttachmentLimitReached(c *gin.Context) {
+	sendError(c, http.StatusConflict, "Too many attachments", fmt.Sprintf("A contact can have at most %d attachments; delete one first", MaxAttachmentsPerContact))
+}
+
+// sendAttachmentQuotaExceeded sends a 413 Request Entity Too Large error when an upload would exceed AttachmentStorageQuota
+func sendAttachmentQuotaExceeded(c *gin.Context, used int64) {
+	sendError(c, http.StatusRequestEntityTooLarge, "Storage quota exceeded",
+		fmt.Sprintf("Attachments can use at most %.1f MB in total (%.1f MB used); delete some attachments first", float64(AttachmentStorageQuota)/(1<<20), float64(used)/(1<<20)))
+}
+
+// cleanAttachmentFilename keeps the base name of an uploaded file without control characters,
+// truncated to the filename column; an empty name becomes "attachment"
+func cleanAttachmentFilename(name string) string {
+	// Browsers on Windows may send the full path
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	for len(name) > maxAttachmentFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// sendAttachmentFile responds with a stored attachment as a download
+func sendAttachmentFile(c *gin.Context, filename, contentType string, data []byte) {
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, data)
+}
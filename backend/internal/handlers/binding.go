@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Validate optional fields by their value, so tags like "omitempty,max=500" work on them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+			return field.Interface().(optionalString).Value
+		}, optionalString{})
+	}
+}
+
+// errEmptyBody is returned by bindJSON when the request has no JSON body at all
+// It wraps io.EOF so handlers whose body is optional can still accept an empty one
+var errEmptyBody = fmt.Errorf("request body is required: %w", io.EOF)
+
+// errInvalidJSONBody is returned by bindJSON when the body is not valid UTF-8 or not well-formed JSON
+// The decoder's message is dropped so binary input is never echoed back in responses or logs
+var errInvalidJSONBody = errors.New("invalid JSON body")
+
+// trimmedJSONBinding decodes a JSON body, trims every string field, then validates
+// Trimming happens before validation so whitespace-only required fields fail "required"
+type trimmedJSONBinding struct{}
+
+func (trimmedJSONBinding) Name() string {
+	return "json"
+}
+
+func (trimmedJSONBinding) Bind(req *http.Request, obj interface{}) error {
+	if req == nil {
+		return errors.New("invalid request")
+	}
+	if req.Body == nil {
+		return errEmptyBody
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	// encoding/json silently replaces invalid UTF-8 in strings with U+FFFD, so check up front
+	if !utf8.Valid(data) {
+		return errInvalidJSONBody
+	}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(obj); err != nil {
+		// Decode reports io.EOF only when the body is empty or whitespace (truncated JSON is io.ErrUnexpectedEOF)
+		if errors.Is(err, io.EOF) {
+			return errEmptyBody
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return errInvalidJSONBody
+		}
+		return err
+	}
+	trimStrings(obj)
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// bindJSON binds the request body into obj with all string inputs trimmed
+// Use this instead of c.ShouldBindJSON in create/update handlers
+func bindJSON(c *gin.Context, obj interface{}) error {
+	return c.ShouldBindWith(obj, trimmedJSONBinding{})
+}
+
+// trimStrings trims leading/trailing whitespace on every string field reachable from v
+// Handles nested structs, pointers, and slices (e.g. batch request items)
+func trimStrings(v interface{}) {
+	trimValue(reflect.ValueOf(v))
+}
+
+func trimValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			trimValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				trimValue(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			trimValue(v.Index(i))
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(strings.TrimSpace(v.String()))
+		}
+	}
+}
+
+// optionalInt is a JSON integer field that distinguishes an omitted field from an explicit null
+// Set is false when the field was omitted; when Set, Value is nil for null
+type optionalInt struct {
+	Set   bool
+	Value *int
+}
+
+// UnmarshalJSON is only called when the field is present in the body (including as null)
+func (o *optionalInt) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	var v int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.Value = &v
+	return nil
+}
+
+// optionalString is a JSON string field that distinguishes an omitted field from an explicit null or ""
+// Set is false when the field was omitted; when Set, Value is "" for null
+// Validation tags apply to Value (see init)
+type optionalString struct {
+	Set   bool
+	Value string
+}
+
+// UnmarshalJSON is only called when the field is present in the body (including as null)
+func (o *optionalString) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = ""
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// optionalInt64 is optionalInt for 64-bit values
+type optionalInt64 struct {
+	Set   bool
+	Value *int64
+}
+
+// UnmarshalJSON is only called when the field is present in the body (including as null)
+func (o *optionalInt64) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	var v int64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.Value = &v
+	return nil
+}
@@ -0,0 +1,431 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// TestTrimStrings tests that trimStrings trims nested, pointer and slice string fields
+func TestTrimStrings(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	type request struct {
+		Name    string
+		Notes   *string
+		Items   []item
+		Nested  item
+		private string
+	}
+
+	notes := "  some notes \n"
+	req := request{
+		Name:    "  Acme  ",
+		Notes:   &notes,
+		Items:   []item{{Name: "\tfirst "}, {Name: "second"}},
+		Nested:  item{Name: " nested "},
+		private: "  untouched  ",
+	}
+
+	trimStrings(&req)
+
+	if req.Name != "Acme" {
+		t.Errorf("Expected name 'Acme', got %q", req.Name)
+	}
+	if *req.Notes != "some notes" {
+		t.Errorf("Expected notes 'some notes', got %q", *req.Notes)
+	}
+	if req.Items[0].Name != "first" || req.Items[1].Name != "second" {
+		t.Errorf("Expected slice items to be trimmed, got %+v", req.Items)
+	}
+	if req.Nested.Name != "nested" {
+		t.Errorf("Expected nested name 'nested', got %q", req.Nested.Name)
+	}
+	if req.private != "  untouched  " {
+		t.Errorf("Expected unexported field to be left alone, got %q", req.private)
+	}
+}
+
+// TestWhitespaceOnlyNames tests that whitespace-only required names fail validation for every resource
+func TestWhitespaceOnlyNames(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-binding-whitespace@example.com")
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   map[string]interface{}
+	}{
+		{
+			name:   "Create company",
+			method: "POST",
+			path:   "/api/companies",
+			body:   map[string]interface{}{"name": "   "},
+		},
+		{
+			name:   "Update company",
+			method: "PUT",
+			path:   "/api/companies/1",
+			body:   map[string]interface{}{"name": "\t\n"},
+		},
+		{
+			name:   "Create contact",
+			method: "POST",
+			path:   "/api/contacts",
+			body:   map[string]interface{}{"name": "   "},
+		},
+		{
+			name:   "Update contact",
+			method: "PUT",
+			path:   "/api/contacts/1",
+			body:   map[string]interface{}{"name": "  "},
+		},
+		{
+			name:   "Create job",
+			method: "POST",
+			path:   "/api/jobs",
+			body:   map[string]interface{}{"application_id": 1, "company_id": 1, "title": "   "},
+		},
+		{
+			name:   "Update job",
+			method: "PUT",
+			path:   "/api/jobs/1",
+			body:   map[string]interface{}{"title": " "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestNameLengthLimits tests that name fields are capped at their column width (VARCHAR counts characters, not bytes)
+func TestNameLengthLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func(value string) interface{}
+		max     int
+	}{
+		{"Create company", func(v string) interface{} { return &CreateCompanyRequest{Name: v} }, 255},
+		{"Update company", func(v string) interface{} { return &UpdateCompanyRequest{Name: v} }, 255},
+		{"Create contact", func(v string) interface{} { return &CreateContactRequest{Name: v} }, 255},
+		{"Update contact", func(v string) interface{} { return &UpdateContactRequest{Name: v} }, 255},
+		{"Create job", func(v string) interface{} { return &CreateJobRequest{ApplicationID: 1, CompanyID: 1, Title: v} }, 255},
+		{"Update job", func(v string) interface{} { return &UpdateJobRequest{Title: v} }, 255},
+		{"Create tag", func(v string) interface{} { return &TagRequest{Name: v} }, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := binding.Validator.ValidateStruct(tt.request(strings.Repeat("é", tt.max))); err != nil {
+				t.Errorf("Expected %d characters to be accepted, got %v", tt.max, err)
+			}
+			if err := binding.Validator.ValidateStruct(tt.request(strings.Repeat("a", tt.max+1))); err == nil {
+				t.Errorf("Expected %d characters to be rejected", tt.max+1)
+			}
+		})
+	}
+}
+
+// TestOversizedNames tests that oversized names are rejected with a field-level error for every resource
+func TestOversizedNames(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-binding-oversized@example.com")
+	defer cleanup()
+
+	oversized := strings.Repeat("a", 100*1024)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		field  string
+		body   map[string]interface{}
+	}{
+		{"Create company", "POST", "/api/companies", "name", map[string]interface{}{"name": oversized}},
+		{"Update company", "PUT", "/api/companies/1", "name", map[string]interface{}{"name": oversized}},
+		{"Create contact", "POST", "/api/contacts", "name", map[string]interface{}{"name": oversized}},
+		{"Update contact", "PUT", "/api/contacts/1", "name", map[string]interface{}{"name": oversized}},
+		{"Create job", "POST", "/api/jobs", "title", map[string]interface{}{"application_id": 1, "company_id": 1, "title": oversized}},
+		{"Update job", "PUT", "/api/jobs/1", "title", map[string]interface{}{"title": oversized}},
+		{"Create tag", "POST", "/api/tags", "name", map[string]interface{}{"name": oversized}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+			var response ValidationErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if !strings.Contains(response.Fields[tt.field], "at most") {
+				t.Errorf("Expected a max length error for %s, got %+v", tt.field, response.Fields)
+			}
+		})
+	}
+}
+
+// TestOptionalInt tests that optionalInt tells an omitted field from an explicit null
+func TestOptionalInt(t *testing.T) {
+	seven := 7
+	tests := []struct {
+		name          string
+		body          string
+		expectedSet   bool
+		expectedValue *int
+		expectErr     bool
+	}{
+		{"Omitted", `{}`, false, nil, false},
+		{"Null", `{"id": null}`, true, nil, false},
+		{"Value", `{"id": 7}`, true, &seven, false},
+		{"Wrong type", `{"id": "7"}`, false, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req struct {
+				ID optionalInt `json:"id"`
+			}
+			err := json.Unmarshal([]byte(tt.body), &req)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", req.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if req.ID.Set != tt.expectedSet {
+				t.Errorf("Expected Set %v, got %v", tt.expectedSet, req.ID.Set)
+			}
+			if (req.ID.Value == nil) != (tt.expectedValue == nil) || (req.ID.Value != nil && *req.ID.Value != *tt.expectedValue) {
+				t.Errorf("Expected value %v, got %v", tt.expectedValue, req.ID.Value)
+			}
+		})
+	}
+}
+
+// TestOptionalString tests that optionalString tells an omitted field from null and is validated by its value
+func TestOptionalString(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectedSet   bool
+		expectedValue string
+		expectErr     bool
+	}{
+		{"Omitted", `{}`, false, "", false},
+		{"Null", `{"source": null}`, true, "", false},
+		{"Empty", `{"source": ""}`, true, "", false},
+		{"Value", `{"source": "  referral "}`, true, "referral", false},
+		{"Invalid value", `{"source": "fax"}`, false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req struct {
+				Source optionalString `json:"source" binding:"omitempty,oneof=referral other"`
+			}
+			httpReq := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			err := trimmedJSONBinding{}.Bind(httpReq, &req)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", req.Source)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if req.Source.Set != tt.expectedSet || req.Source.Value != tt.expectedValue {
+				t.Errorf("Expected {%v %q}, got %+v", tt.expectedSet, tt.expectedValue, req.Source)
+			}
+		})
+	}
+}
+
+// TestEmptyBody tests that create/update endpoints reject a missing body with a dedicated error
+func TestEmptyBody(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-binding-empty-body@example.com")
+	defer cleanup()
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{"PUT", "/api/auth/me"},
+		{"PATCH", "/api/auth/me"},
+		{"POST", "/api/companies"},
+		{"POST", "/api/companies/batch"},
+		{"PUT", "/api/companies/1"},
+		{"PATCH", "/api/companies/1/favorite"},
+		{"POST", "/api/jobs"},
+		{"PUT", "/api/jobs/1"},
+		{"PATCH", "/api/jobs/1"},
+		{"POST", "/api/applications"},
+		{"PUT", "/api/applications/1"},
+		{"POST", "/api/applications/1/snooze"},
+		{"POST", "/api/applications/1/documents"},
+		{"PUT", "/api/applications/1/documents/1"},
+		{"POST", "/api/applications/1/notes"},
+		{"PUT", "/api/applications/1/notes/1"},
+		{"POST", "/api/applications/1/interviews"},
+		{"PATCH", "/api/applications/1/interviews/1"},
+		{"POST", "/api/track"},
+		{"POST", "/api/import/json"},
+		{"POST", "/api/contacts"},
+		{"PUT", "/api/contacts/1"},
+		{"POST", "/api/tags"},
+		{"POST", "/api/tags/1/apply"},
+		{"POST", "/api/tags/1/remove"},
+		{"POST", "/api/webhooks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			for _, body := range []string{"", "  \n"} {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", "Bearer "+testUser.Token)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+				}
+				var response ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to parse response: %v", err)
+				}
+				if response.Error != "Request body is required" || response.Fields != nil {
+					t.Errorf("Expected a request body error without field errors, got %+v", response)
+				}
+			}
+		})
+	}
+}
+
+// TestBindJSON_EmptyBody tests that an empty or whitespace body is reported as errEmptyBody, not a decode error
+func TestBindJSON_EmptyBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		isEmpty bool
+	}{
+		{"Empty", "", true},
+		{"Whitespace", " \r\n\t", true},
+		{"Truncated", `{"name":`, false},
+		{"Malformed", "not json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			var payload TagRequest
+			err := trimmedJSONBinding{}.Bind(req, &payload)
+			if errors.Is(err, errEmptyBody) != tt.isEmpty {
+				t.Errorf("Expected errEmptyBody=%v, got %v", tt.isEmpty, err)
+			}
+		})
+	}
+}
+
+// TestBindJSON_InvalidBody tests that binary, non-UTF-8 and malformed bodies get a clean 400
+// that doesn't echo the input back
+func TestBindJSON_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/bind", func(c *gin.Context) {
+		var req struct {
+			Name string `json:"name" binding:"required"`
+		}
+		if err := bindJSON(c, &req); err != nil {
+			sendValidationError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"name": req.Name})
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Binary junk", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"},
+		{"Invalid UTF-8 in a string", `{"name": "caf` + "\xe9" + `"}`},
+		{"Truncated JSON", `{"name": "Acme"`},
+		{"Not JSON", `name=Acme`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/bind", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != "Invalid JSON body" {
+				t.Errorf("Expected error 'Invalid JSON body', got %q", response.Error)
+			}
+			if strings.ContainsAny(w.Body.String(), "\x89\xe9") || strings.Contains(w.Body.String(), "\\u") {
+				t.Errorf("Expected the input not to be echoed, got %s", w.Body.String())
+			}
+		})
+	}
+
+	// Valid UTF-8 still binds
+	req := httptest.NewRequest("POST", "/bind", strings.NewReader(`{"name": "Café"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Café") {
+		t.Errorf("Expected valid UTF-8 to bind, got %d: %s", w.Code, w.Body.String())
+	}
+}
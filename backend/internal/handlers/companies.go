@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
@@ -13,13 +17,17 @@ import (
 
 // CompanyHandler handles HTTP requests for companies
 type CompanyHandler struct {
+	db      *sql.DB
 	queries *database.Queries
+	counts  *CountCache
 }
 
 // NewCompanyHandler creates a new company handler
-func NewCompanyHandler(queries *database.Queries) *CompanyHandler {
+func NewCompanyHandler(db *sql.DB, queries *database.Queries, counts *CountCache) *CompanyHandler {
 	return &CompanyHandler{
+		db:      db,
 		queries: queries,
+		counts:  counts,
 	}
 }
 
@@ -53,6 +61,10 @@ func normalizeCompanyName(name string) string {
 // GetAllCompanies handles GET /api/companies
 // Returns all companies or paginated companies if page/limit query params are provided
 // Query params: ?page=1&limit=10 (optional, backward compatible)
+// Supports ?sort=created_at:desc (fields: created_at, name); defaults to DEFAULT_SORT_COMPANIES
+// Archived companies are left out unless ?include_archived=true
+// ?has_website=true|false keeps only companies with/without a website (e.g. to find records to enrich)
+// ?favorite=true|false keeps only starred/unstarred companies; ?favorites_first=true lists starred companies first
 func (h *CompanyHandler) GetAllCompanies(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
@@ -62,6 +74,35 @@ func (h *CompanyHandler) GetAllCompanies(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
+	listSort, ok := resolveListSort(c, sortResourceCompanies)
+	if !ok {
+		return
+	}
+
+	hasWebsite, ok := parseBoolFilter(c, "has_website")
+	if !ok {
+		return
+	}
+	favorite, ok := parseBoolFilter(c, "favorite")
+	if !ok {
+		return
+	}
+
+	// Industry, website and favorite filters, archived companies and non-default sorts use the combined filtered query
+	includeArchived := c.Query("include_archived") == "true"
+	favoritesFirst := c.Query("favorites_first") == "true"
+	if industry := strings.TrimSpace(c.Query("industry")); industry != "" || includeArchived || hasWebsite.Valid || favorite.Valid || favoritesFirst || !listSort.isBuiltin(sortResourceCompanies) {
+		h.getFilteredCompanies(c, userID, companyListFilters{
+			Industry:        industry,
+			IncludeArchived: includeArchived,
+			HasWebsite:      hasWebsite,
+			Favorite:        favorite,
+			FavoritesFirst:  favoritesFirst,
+			Sort:            listSort,
+		})
+		return
+	}
+
 	// Check if pagination parameters are provided
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
@@ -92,8 +133,10 @@ func (h *CompanyHandler) GetAllCompanies(c *gin.Context) {
 		return
 	}
 
-	// Fetch total count
-	totalCount, err := h.queries.CountCompaniesByUserID(ctx, userID)
+	// Fetch total count (cached per user)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceCompanies, userID, ""), func() (int64, error) {
+		return h.queries.CountCompaniesByUserID(ctx, userID)
+	})
 	if err != nil {
 		sendInternalError(c, "Failed to count companies", err)
 		return
@@ -117,6 +160,202 @@ func (h *CompanyHandler) GetAllCompanies(c *gin.Context) {
 	})
 }
 
+// CountCompanies handles GET /api/companies/count
+// Returns {"count": n} for the user's companies, honoring the ?industry=, ?include_archived=, ?has_website= and ?favorite= list filters
+// Shares the pagination count cache with the list endpoint
+func (h *CompanyHandler) CountCompanies(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	hasWebsite, ok := parseBoolFilter(c, "has_website")
+	if !ok {
+		return
+	}
+	favorite, ok := parseBoolFilter(c, "favorite")
+	if !ok {
+		return
+	}
+
+	filters := companyListFilters{
+		Industry:        strings.TrimSpace(c.Query("industry")),
+		IncludeArchived: c.Query("include_archived") == "true",
+		HasWebsite:      hasWebsite,
+		Favorite:        favorite,
+	}
+	ctx := c.Request.Context()
+
+	count, err := h.counts.Count(countCacheKey(countResourceCompanies, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountCompaniesFilteredByUserID(ctx, database.CountCompaniesFilteredByUserIDParams{
+			UserID:          userID,
+			Industry:        sql.NullString{String: filters.Industry, Valid: filters.Industry != ""},
+			IncludeArchived: filters.IncludeArchived,
+			HasWebsite:      filters.HasWebsite,
+			Favorite:        filters.Favorite,
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count companies", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// companyListFilters holds the optional filters for the filtered companies list
+// Empty fields are not applied
+type companyListFilters struct {
+	Industry        string
+	IncludeArchived bool         // also return archived companies
+	HasWebsite      sql.NullBool // NULL: any; true/false: only companies with/without a website
+	Favorite        sql.NullBool // NULL: any; true/false: only starred/unstarred companies
+	FavoritesFirst  bool         // list starred companies first; like Sort, not part of cacheKey
+	Sort            ListSort     // order of the results; not part of cacheKey since it doesn't change counts
+}
+
+// cacheKey returns the count cache filter segment for these filters
+func (f companyListFilters) cacheKey() string {
+	key := "industry=" + strings.ToLower(f.Industry) + "&include_archived=" + strconv.FormatBool(f.IncludeArchived)
+	if f.HasWebsite.Valid {
+		key += "&has_website=" + strconv.FormatBool(f.HasWebsite.Bool)
+	}
+	if f.Favorite.Valid {
+		key += "&favorite=" + strconv.FormatBool(f.Favorite.Bool)
+	}
+	return key
+}
+
+// parseBoolFilter parses an optional true|false query filter such as ?has_website= or ?favorite=
+// Sends a 400 response and returns false for any other value
+func parseBoolFilter(c *gin.Context, name string) (sql.NullBool, bool) {
+	switch c.Query(name) {
+	case "":
+		return sql.NullBool{}, true
+	case "true":
+		return sql.NullBool{Bool: true, Valid: true}, true
+	case "false":
+		return sql.NullBool{Bool: false, Valid: true}, true
+	default:
+		sendBadRequest(c, "Invalid "+name, name+" must be true or false")
+		return sql.NullBool{}, false
+	}
+}
+
+// getFilteredCompanies responds with the user's companies matching filters
+// Returns a bare array without page/limit (backward compatible), otherwise a PaginatedResponse
+func (h *CompanyHandler) getFilteredCompanies(c *gin.Context, userID int32, filters companyListFilters) {
+	ctx := c.Request.Context()
+
+	industry := sql.NullString{String: filters.Industry, Valid: filters.Industry != ""}
+
+	// No pagination params: return all matching companies
+	if c.Query("page") == "" && c.Query("limit") == "" {
+		companies, err := h.queries.GetCompaniesFilteredByUserID(ctx, database.GetCompaniesFilteredByUserIDParams{
+			UserID:          userID,
+			Industry:        industry,
+			IncludeArchived: filters.IncludeArchived,
+			HasWebsite:      filters.HasWebsite,
+			Favorite:        filters.Favorite,
+			FavoritesFirst:  filters.FavoritesFirst,
+			SortKey:         filters.Sort.key(),
+		})
+		if err != nil {
+			sendInternalError(c, "Failed to fetch companies", err)
+			return
+		}
+		c.JSON(http.StatusOK, companies)
+		return
+	}
+
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	companies, err := h.queries.GetCompaniesFilteredByUserID(ctx, database.GetCompaniesFilteredByUserIDParams{
+		UserID:          userID,
+		Industry:        industry,
+		IncludeArchived: filters.IncludeArchived,
+		HasWebsite:      filters.HasWebsite,
+		Favorite:        filters.Favorite,
+		FavoritesFirst:  filters.FavoritesFirst,
+		SortKey:         filters.Sort.key(),
+		RowLimit:        sql.NullInt32{Int32: params.Limit, Valid: true},
+		RowOffset:       offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch companies", err)
+		return
+	}
+
+	// Fetch total count (cached per user+filters)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceCompanies, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountCompaniesFilteredByUserID(ctx, database.CountCompaniesFilteredByUserIDParams{
+			UserID:          userID,
+			Industry:        industry,
+			IncludeArchived: filters.IncludeArchived,
+			HasWebsite:      filters.HasWebsite,
+			Favorite:        filters.Favorite,
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count companies", err)
+		return
+	}
+
+	// Convert to interface{} for paginated response
+	data := make([]interface{}, len(companies))
+	for i, company := range companies {
+		data[i] = company
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
+// CheckCompany handles GET /api/companies/check?name=...
+// Returns the existing company that CreateCompany would match for this name (same normalization),
+// or 204 No Content if none; nothing is created, so the UI can warn before submitting
+func (h *CompanyHandler) CheckCompany(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	normalizedName := normalizeCompanyName(c.Query("name"))
+	if normalizedName == "" {
+		sendBadRequest(c, "Missing name parameter", "name is required")
+		return
+	}
+	if utf8.RuneCountInString(normalizedName) > 255 {
+		sendBadRequest(c, "Invalid name parameter", "name must be at most 255 characters")
+		return
+	}
+
+	company, err := h.queries.GetCompanyByNameAndUserID(c.Request.Context(), database.GetCompanyByNameAndUserIDParams{
+		Btrim:  normalizedName,
+		UserID: userID,
+	})
+	if err == sql.ErrNoRows {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		sendInternalError(c, "Failed to check for existing company", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, company)
+}
+
 // GetCompanyByID handles GET /api/companies/:id
 // Returns a single company by ID (verifies ownership)
 func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
@@ -144,13 +383,16 @@ func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, company.UpdatedAt)
 	c.JSON(http.StatusOK, company)
 }
 
 // CreateCompanyRequest represents the JSON body for creating a company
 type CreateCompanyRequest struct {
-	Name    string `json:"name" binding:"required,min=1,max=255"`
-	Website string `json:"website" binding:"omitempty,url,max=255"`
+	Name     string `json:"name" binding:"required,min=1,max=255"`
+	Website  string `json:"website" binding:"omitempty,url,max=255"`
+	Industry string `json:"industry" binding:"omitempty,max=100"`                                              // Free text, e.g. "Fintech"
+	Size     string `json:"size" binding:"omitempty,oneof=1-10 11-50 51-200 201-500 501-1000 1001-5000 5001+"` // Employee count bucket
 }
 
 // CreateCompany handles POST /api/companies
@@ -158,7 +400,7 @@ type CreateCompanyRequest struct {
 func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 	// Parse JSON body
 	var req CreateCompanyRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
@@ -175,55 +417,143 @@ func (h *CompanyHandler) CreateCompany(c *gin.Context) {
 	// Get request context
 	ctx := c.Request.Context()
 
-	// Check if company with this normalized name already exists for this user
-	existingCompany, err := h.queries.GetCompanyByNameAndUserID(ctx, database.GetCompanyByNameAndUserIDParams{
-		Btrim:  normalizedName,
-		UserID: userID,
+	// Get-or-create in one atomic upsert, so concurrent requests for the same name can't race
+	company, created, err := upsertCompany(ctx, h.queries, database.UpsertCompanyParams{
+		Name:     normalizedName,
+		Website:  sql.NullString{String: req.Website, Valid: req.Website != ""},
+		UserID:   userID,
+		Industry: sql.NullString{String: req.Industry, Valid: req.Industry != ""},
+		Size:     sql.NullString{String: req.Size, Valid: req.Size != ""},
 	})
-	if err == nil {
-		// Company exists - return it (get-or-create pattern)
-		c.JSON(http.StatusOK, existingCompany)
+	if handleDatabaseError(c, err, "Company") {
 		return
 	}
-	// If error is not "no rows", it's a real database error
-	if err != nil && err != sql.ErrNoRows {
-		sendInternalError(c, "Failed to check for existing company", err)
+
+	if !created {
+		// Company exists - return it (get-or-create pattern)
+		c.JSON(http.StatusOK, company)
 		return
 	}
 
-	// Company doesn't exist - create it
-	company, err := h.queries.CreateCompany(ctx, database.CreateCompanyParams{
-		Name:    normalizedName,
-		Website: sql.NullString{String: req.Website, Valid: req.Website != ""},
+	h.counts.Invalidate(countResourceCompanies, userID)
+
+	// Return newly created company
+	c.JSON(http.StatusCreated, company)
+}
+
+// upsertCompany runs UpsertCompany and returns the company and whether it was newly created
+// The name in params must already be normalized with normalizeCompanyName
+func upsertCompany(ctx context.Context, queries *database.Queries, params database.UpsertCompanyParams) (database.Company, bool, error) {
+	row, err := queries.UpsertCompany(ctx, params)
+	if err != nil {
+		return database.Company{}, false, err
+	}
+	return database.Company{
+		ID:         row.ID,
+		Name:       row.Name,
+		Website:    row.Website,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		UserID:     row.UserID,
+		Industry:   row.Industry,
+		Size:       row.Size,
+		ArchivedAt: row.ArchivedAt,
+		IsFavorite: row.IsFavorite,
+	}, row.Inserted, nil
+}
+
+// getOrCreateCompany returns the user's existing company with the same normalized name,
+// or creates a new one with the given website
+// Returns the company and whether it was newly created.
+func getOrCreateCompany(ctx context.Context, queries *database.Queries, userID int32, name, website string) (database.Company, bool, error) {
+	return upsertCompany(ctx, queries, database.UpsertCompanyParams{
+		Name:    normalizeCompanyName(name),
+		Website: sql.NullString{String: website, Valid: website != ""},
 		UserID:  userID,
 	})
-	if err != nil {
-		// Check for race condition (another request created it between our check and create)
-		errStr := strings.ToLower(err.Error())
-		if strings.Contains(errStr, "duplicate") || strings.Contains(errStr, "unique") {
-			// Fetch the company that was just created by another request
-			existingCompany, fetchErr := h.queries.GetCompanyByNameAndUserID(ctx, database.GetCompanyByNameAndUserIDParams{
-				Btrim:  normalizedName,
-				UserID: userID,
-			})
-			if fetchErr == nil {
-				c.JSON(http.StatusOK, existingCompany)
-				return
+}
+
+// MaxCompanyBatchSize caps how many companies POST /api/companies/batch accepts
+const MaxCompanyBatchSize = 100
+
+// BatchCompanyItem is one company of a batch create request
+type BatchCompanyItem struct {
+	Name    string `json:"name" binding:"required,min=1,max=255"`
+	Website string `json:"website" binding:"omitempty,url,max=255"`
+}
+
+// BatchCreateCompaniesRequest represents the JSON body for creating companies in bulk
+type BatchCreateCompaniesRequest struct {
+	Companies []BatchCompanyItem `json:"companies" binding:"required,min=1,dive"`
+}
+
+// BatchCompanyResult is the outcome of one batch item: the matched or created company
+type BatchCompanyResult struct {
+	Company database.Company `json:"company"`
+	Created bool             `json:"created"` // false if an existing company (same normalized name) was returned
+}
+
+// BatchCreateCompanies handles POST /api/companies/batch
+// Get-or-creates each company like POST /api/companies (same name normalization and dedupe), all in one transaction
+// Results are in request order; items repeating a name earlier in the batch return that company as existing
+func (h *CompanyHandler) BatchCreateCompanies(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req BatchCreateCompaniesRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+	if len(req.Companies) > MaxCompanyBatchSize {
+		sendBadRequest(c, "Too many companies", fmt.Sprintf("At most %d companies can be created at once", MaxCompanyBatchSize))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	results := make([]BatchCompanyResult, 0, len(req.Companies))
+	created := 0
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Reset in case withTx retries the transaction
+		results = results[:0]
+		created = 0
+
+		for _, item := range req.Companies {
+			company, wasCreated, err := getOrCreateCompany(ctx, qtx, userID, item.Name, item.Website)
+			if err != nil {
+				return err
+			}
+			results = append(results, BatchCompanyResult{Company: company, Created: wasCreated})
+			if wasCreated {
+				created++
 			}
 		}
-		if handleDatabaseError(c, err, "Company") {
-			return
-		}
+		return nil
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+	if created > 0 {
+		h.counts.Invalidate(countResourceCompanies, userID)
 	}
 
-	// Return newly created company
-	c.JSON(http.StatusCreated, company)
+	c.JSON(http.StatusOK, gin.H{
+		"results":  results,
+		"created":  created,
+		"existing": len(results) - created,
+	})
 }
 
 // UpdateCompanyRequest represents the JSON body for updating a company
 type UpdateCompanyRequest struct {
-	Name    string `json:"name" binding:"required,min=1,max=255"`
-	Website string `json:"website" binding:"omitempty,url,max=255"`
+	Name     string         `json:"name" binding:"required,min=1,max=255"`
+	Website  string         `json:"website" binding:"omitempty,url,max=255"`
+	Industry optionalString `json:"industry" binding:"omitempty,max=100"`                                              // Free text, e.g. "Fintech"; omitted leaves it unchanged, null or "" clears it
+	Size     optionalString `json:"size" binding:"omitempty,oneof=1-10 11-50 51-200 201-500 501-1000 1001-5000 5001+"` // Employee count bucket; omitted leaves it unchanged
 }
 
 // UpdateCompany handles PUT /api/companies/:id
@@ -239,11 +569,17 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 
 	// Parse JSON body
 	var req UpdateCompanyRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
 
+	// Optional optimistic concurrency: If-Match carries the version the client last saw
+	expectedUpdatedAt, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
 	if !ok {
@@ -283,20 +619,104 @@ func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
 
 	// Update company with normalized name (verifies ownership via user_id)
 	company, err := h.queries.UpdateCompany(ctx, database.UpdateCompanyParams{
-		ID:      int32(id),
-		Name:    normalizedName,
-		Website: sql.NullString{String: req.Website, Valid: req.Website != ""},
-		UserID:  userID,
+		ID:                int32(id),
+		Name:              normalizedName,
+		Website:           sql.NullString{String: req.Website, Valid: req.Website != ""},
+		KeepIndustry:      !req.Industry.Set,
+		Industry:          sql.NullString{String: req.Industry.Value, Valid: req.Industry.Value != ""},
+		KeepSize:          !req.Size.Set,
+		Size:              sql.NullString{String: req.Size.Value, Valid: req.Size.Value != ""},
+		UserID:            userID,
+		ExpectedUpdatedAt: expectedUpdatedAt,
 	})
-	if handleDatabaseError(c, err, "Company") {
+	if handleConditionalUpdateError(c, err, expectedUpdatedAt, "Company", func() error {
+		_, err := h.queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		return err
+	}) {
 		return
 	}
 
+	// Industry may have changed, which shifts the industry-filtered counts
+	h.counts.Invalidate(countResourceCompanies, userID)
+
+	setETag(c, company.UpdatedAt)
 	c.JSON(http.StatusOK, company)
 }
 
+// CompanyDeleteImpact is what deleting a company would affect
+type CompanyDeleteImpact struct {
+	CompanyID          int32 `json:"company_id"`
+	Jobs               int64 `json:"jobs"`                // cascade-deleted with the company
+	Applications       int64 `json:"applications"`        // kept, but lose their job
+	ActiveApplications int64 `json:"active_applications"` // of those, still applied, interview or offer and not archived
+	RequiresConfirm    bool  `json:"requires_confirm"`    // DELETE needs ?confirm=true
+}
+
+// errCompanyHasDependents aborts a company delete that needs ?confirm=true
+var errCompanyHasDependents = errors.New("company has dependents")
+
+// companyDeleteImpact counts what deleting the company would affect
+// Ownership of the company must be verified before calling this
+func companyDeleteImpact(ctx context.Context, queries *database.Queries, companyID, userID int32) (CompanyDeleteImpact, error) {
+	row, err := queries.GetCompanyDeleteImpact(ctx, database.GetCompanyDeleteImpactParams{
+		CompanyID: companyID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return CompanyDeleteImpact{}, err
+	}
+	// Each application has at most one job, so every job stands for one affected application
+	return CompanyDeleteImpact{
+		CompanyID:          companyID,
+		Jobs:               row.Jobs,
+		Applications:       row.Jobs,
+		ActiveApplications: row.ActiveApplications,
+		RequiresConfirm:    row.Jobs > 0,
+	}, nil
+}
+
+// GetCompanyDeleteImpact handles GET /api/companies/:id/delete-impact
+// Returns how many jobs would be cascade-deleted and how many applications would lose their job,
+// so the UI can ask for confirmation before DELETE /api/companies/:id?confirm=true (verifies ownership)
+func (h *CompanyHandler) GetCompanyDeleteImpact(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the company exists and belongs to the user
+	_, err = h.queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+
+	impact, err := companyDeleteImpact(ctx, h.queries, int32(id), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to count company dependents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
+
 // DeleteCompany handles DELETE /api/companies/:id
-// Deletes a company by ID
+// Deletes a company by ID; its jobs are cascade-deleted
+// Responds 409 when the company has jobs unless ?confirm=true (see GET /api/companies/:id/delete-impact)
 func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 	// Get ID from URL parameter
 	idStr := c.Param("id")
@@ -315,27 +735,181 @@ func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
 	// Get request context
 	ctx := c.Request.Context()
 
-	// Check if company exists and belongs to user
-	_, err = h.queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
-		ID:     int32(id),
-		UserID: userID,
+	confirm := c.Query("confirm") == "true"
+
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	// and a job can't be added between the dependents check and the delete
+	var company database.Company
+	var impact CompanyDeleteImpact
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if company exists and belongs to user
+		var err error
+		company, err = qtx.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		impact, err = companyDeleteImpact(ctx, qtx, int32(id), userID)
+		if err != nil {
+			return err
+		}
+		if impact.RequiresConfirm && !confirm {
+			return errCompanyHasDependents
+		}
+
+		// Delete company (verifies ownership via user_id)
+		return qtx.DeleteCompany(ctx, database.DeleteCompanyParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
 	})
+	if errors.Is(err, errCompanyHasDependents) {
+		sendError(c, http.StatusConflict, "Company has dependents",
+			fmt.Sprintf("Deleting this company deletes %d job(s) and unlinks %d application(s); use ?confirm=true to delete anyway", impact.Jobs, impact.Applications))
+		return
+	}
 	if handleDatabaseError(c, err, "Company") {
 		return
 	}
+	// Deleting a company cascades to its jobs
+	h.counts.Invalidate(countResourceCompanies, userID)
+	h.counts.Invalidate(countResourceJobs, userID)
 
-	// Delete company (verifies ownership via user_id)
-	err = h.queries.DeleteCompany(ctx, database.DeleteCompanyParams{
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Company deleted successfully",
+		"id": id,
+		"deleted": company,
+	})
+}
+
+// ArchiveCompany handles POST /api/companies/:id/archive
+// Archives a company so it is hidden from the default lists (verifies ownership)
+// Responds 409 while the company has non-archived applications unless ?force=true
+func (h *CompanyHandler) ArchiveCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	force := c.Query("force") == "true"
+	ctx := c.Request.Context()
+
+	// Check and archive in one transaction so an application can't become active in between
+	var company database.Company
+	var active int64
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Verify the company exists and belongs to the user (so an unknown company is a 404, not a 409)
+		_, err := qtx.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			active, err = qtx.CountActiveApplicationsByCompanyID(ctx, database.CountActiveApplicationsByCompanyIDParams{
+				CompanyID: int32(id),
+				UserID:    userID,
+			})
+			if err != nil || active > 0 {
+				return err
+			}
+		}
+
+		company, err = qtx.ArchiveCompany(ctx, database.ArchiveCompanyParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		return err
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+	if active > 0 {
+		sendError(c, http.StatusConflict, "Company has active applications",
+			fmt.Sprintf("%d application(s) for this company are still active; use ?force=true to archive anyway", active))
+		return
+	}
+	h.counts.Invalidate(countResourceCompanies, userID)
+
+	c.JSON(http.StatusOK, company)
+}
+
+// UnarchiveCompany handles POST /api/companies/:id/unarchive
+// Restores an archived company to the default lists (verifies ownership)
+func (h *CompanyHandler) UnarchiveCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	company, err := h.queries.UnarchiveCompany(c.Request.Context(), database.UnarchiveCompanyParams{
 		ID:     int32(id),
 		UserID: userID,
 	})
 	if handleDatabaseError(c, err, "Company") {
 		return
 	}
+	h.counts.Invalidate(countResourceCompanies, userID)
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Company deleted successfully",
-		"id": id,
-	})
+	c.JSON(http.StatusOK, company)
+}
+
+// FavoriteCompanyRequest represents the JSON body for PATCH /api/companies/:id/favorite
+type FavoriteCompanyRequest struct {
+	IsFavorite *bool `json:"is_favorite" binding:"required"` // pointer so an explicit false isn't treated as missing
 }
 
+// FavoriteCompany handles PATCH /api/companies/:id/favorite
+// Stars or unstars a company, e.g. for a "dream companies" list (verifies ownership)
+// Setting the current value again is a no-op apart from updated_at
+func (h *CompanyHandler) FavoriteCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Parse JSON body
+	var req FavoriteCompanyRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	company, err := h.queries.SetCompanyFavorite(c.Request.Context(), database.SetCompanyFavoriteParams{
+		IsFavorite: *req.IsFavorite,
+		ID:         int32(id),
+		UserID:     userID,
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+	h.counts.Invalidate(countResourceCompanies, userID)
+
+	c.JSON(http.StatusOK, company)
+}
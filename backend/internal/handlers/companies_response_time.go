@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// MinResponseTimeSamples is how many applications that got a response a company needs
+// before GET /api/companies/:id/response-time estimates a median
+const MinResponseTimeSamples = 2
+
+// CompanyResponseTime is how long a company typically takes to respond to the user's applications
+type CompanyResponseTime struct {
+	CompanyID  int32    `json:"company_id"`
+	MedianDays *float64 `json:"median_days"` // null with fewer than MinResponseTimeSamples samples
+	Samples    int64    `json:"samples"`     // applications that moved on from "applied"
+}
+
+// GetCompanyResponseTime handles GET /api/companies/:id/response-time
+// Returns the median days (one decimal) from applied_date to the first status change after "applied",
+// across the company's applications, computed from the status history (verifies ownership)
+func (h *CompanyHandler) GetCompanyResponseTime(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the company exists and belongs to the user
+	_, err = h.queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+
+	row, err := h.queries.GetCompanyResponseTime(ctx, database.GetCompanyResponseTimeParams{
+		CompanyID: int32(id),
+		UserID:    userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to compute response time", err)
+		return
+	}
+
+	response := CompanyResponseTime{
+		CompanyID: int32(id),
+		Samples:   row.Samples,
+	}
+	if row.Samples >= MinResponseTimeSamples {
+		median := math.Round(row.MedianDays*10) / 10
+		response.MedianDays = &median
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -8,7 +8,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
@@ -219,6 +222,69 @@ func TestCreateCompany(t *testing.T) {
 	}
 }
 
+// TestCreateCompany_Concurrent tests that concurrent POST /api/companies with the same name create one company
+func TestCreateCompany_Concurrent(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-concurrent@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-concurrent-other@example.com")
+	defer otherCleanup()
+
+	post := func(token, name string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"name": name})
+		req := httptest.NewRequest("POST", "/api/companies", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	const requests = 8
+	responses := make([]*httptest.ResponseRecorder, requests)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Names differ only in case and spacing, so they normalize to the same company
+			responses[i] = post(testUser.Token, strings.Repeat(" ", i%2)+"Race Condition Corp")
+		}(i)
+	}
+	wg.Wait()
+
+	var createdCount int
+	var companyID int32
+	for _, w := range responses {
+		if w.Code == http.StatusCreated {
+			createdCount++
+		} else if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 or 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var company database.Company
+		if err := json.Unmarshal(w.Body.Bytes(), &company); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if companyID == 0 {
+			companyID = company.ID
+		} else if company.ID != companyID {
+			t.Errorf("Expected every request to return company %d, got %d", companyID, company.ID)
+		}
+	}
+	if createdCount != 1 {
+		t.Errorf("Expected exactly one 201 response, got %d", createdCount)
+	}
+
+	// Names are unique per user, so another user gets their own company
+	w := post(otherUser.Token, "Race Condition Corp")
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status %d for another user, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
 // TestUpdateCompany tests PUT /api/companies/:id
 func TestUpdateCompany(t *testing.T) {
 	router, queries, db := setupTestRouter(t)
@@ -231,9 +297,11 @@ func TestUpdateCompany(t *testing.T) {
 
 	// Create a test company
 	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
-		Name:    "Original Company Name",
-		Website: sql.NullString{String: "https://original.com", Valid: true},
-		UserID:  testUser.ID,
+		Name:     "Original Company Name",
+		Website:  sql.NullString{String: "https://original.com", Valid: true},
+		UserID:   testUser.ID,
+		Industry: sql.NullString{String: "Fintech", Valid: true},
+		Size:     sql.NullString{String: "51-200", Valid: true},
 	})
 	if err != nil {
 		t.Fatalf("Failed to create test company: %v", err)
@@ -268,6 +336,32 @@ func TestUpdateCompany(t *testing.T) {
 	if updated.Name != "Updated Company Name" {
 		t.Errorf("Expected name 'Updated Company Name', got %s", updated.Name)
 	}
+	// industry and size were omitted, so they are kept
+	if updated.Industry.String != "Fintech" || updated.Size.String != "51-200" {
+		t.Errorf("Expected industry and size to be kept, got %+v / %+v", updated.Industry, updated.Size)
+	}
+
+	// Explicit null clears them
+	clearBody, _ := json.Marshal(map[string]interface{}{
+		"name":     "Updated Company Name",
+		"industry": nil,
+		"size":     nil,
+	})
+	req = httptest.NewRequest("PUT", "/api/companies/"+strconv.Itoa(int(company.ID)), bytes.NewBuffer(clearBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if updated.Industry.Valid || updated.Size.Valid {
+		t.Errorf("Expected industry and size to be cleared, got %+v / %+v", updated.Industry, updated.Size)
+	}
 
 	// Test not found
 	req = httptest.NewRequest("PUT", "/api/companies/99999", bytes.NewBuffer(jsonBody))
@@ -513,3 +607,786 @@ func TestGetAllCompanies_PaginationEdgeCases(t *testing.T) {
 	}
 }
 
+
+// TestGetAllCompanies_WithIndustryFilter tests GET /api/companies?industry= and industry/size validation
+func TestGetAllCompanies_WithIndustryFilter(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-industry@example.com")
+	defer cleanup()
+
+	createCompany := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/companies", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Create companies with metadata
+	for _, body := range []map[string]interface{}{
+		{"name": "Fintech One", "industry": "Fintech", "size": "11-50"},
+		{"name": "Fintech Two", "industry": "fintech", "size": "5001+"},
+		{"name": "Health Co", "industry": "Healthcare"},
+	} {
+		if w := createCompany(body); w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+	}
+
+	// Test invalid size bucket
+	if w := createCompany(map[string]interface{}{"name": "Bad Size", "size": "12"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid size, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test industry filter (case-insensitive)
+	req := httptest.NewRequest("GET", "/api/companies?industry=FINTECH", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var companies []database.Company
+	if err := json.Unmarshal(w.Body.Bytes(), &companies); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(companies) != 2 {
+		t.Errorf("Expected 2 fintech companies, got %d", len(companies))
+	}
+	for _, company := range companies {
+		if !company.Size.Valid {
+			t.Errorf("Expected size to be set for %s", company.Name)
+		}
+	}
+
+	// Test industry filter with pagination
+	req = httptest.NewRequest("GET", "/api/companies?industry=healthcare&page=1&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var paginated PaginatedResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &paginated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if paginated.Meta.TotalCount != 1 {
+		t.Errorf("Expected total count 1, got %d", paginated.Meta.TotalCount)
+	}
+}
+
+// TestCheckCompany tests GET /api/companies/check (get-or-create preview)
+func TestCheckCompany(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-check@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-check-other@example.com")
+	defer otherCleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Google", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	check := func(query, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/companies/check"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test a differently written name matches like CreateCompany would
+	w := check("?name=%20%20gOOGLE%20", testUser.Token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var matched database.Company
+	if err := json.Unmarshal(w.Body.Bytes(), &matched); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if matched.ID != company.ID {
+		t.Errorf("Expected company %d, got %d", company.ID, matched.ID)
+	}
+
+	// Test no match, and other users' companies are not matched
+	if w = check("?name=Goog", testUser.Token); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d for no match, got %d", http.StatusNoContent, w.Code)
+	}
+	if w = check("?name=google", otherUser.Token); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d for other user, got %d", http.StatusNoContent, w.Code)
+	}
+
+	// Test nothing was created by checking
+	companies, err := queries.GetCompaniesByUserID(ctx, testUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to fetch companies: %v", err)
+	}
+	if len(companies) != 1 {
+		t.Errorf("Expected 1 company after checks, got %d", len(companies))
+	}
+
+	// Test missing name
+	if w = check("?name=%20", testUser.Token); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for blank name, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestCountCompanies tests GET /api/companies/count
+func TestCountCompanies(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-count@example.com")
+	defer cleanup()
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Count Fintech", "industry": "Fintech"},
+		{"name": "Count Health", "industry": "Healthcare"},
+	} {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/companies", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+	}
+
+	for query, expected := range map[string]int64{"": 2, "?industry=fintech": 1, "?industry=retail": 0} {
+		req := httptest.NewRequest("GET", "/api/companies/count"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response struct {
+			Count int64 `json:"count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Count != expected {
+			t.Errorf("Expected count %d for %q, got %d", expected, query, response.Count)
+		}
+	}
+}
+
+// TestArchiveCompany tests POST /api/companies/:id/archive and the include_archived list filter
+func TestArchiveCompany(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-archive@example.com")
+	defer cleanup()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Archive Corp",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	// An interviewing application keeps the company active
+	application := createTestApplication(t, queries, testUser.ID, "interview", "")
+	_, err = queries.CreateJob(ctx, database.CreateJobParams{
+		ApplicationID: application.ID,
+		CompanyID:     company.ID,
+		Title:         "Archive Job",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+
+	archive := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/companies/"+strconv.Itoa(int(company.ID))+"/archive"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listCompanies := func(query string) []database.Company {
+		req := httptest.NewRequest("GET", "/api/companies"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var companies []database.Company
+		if err := json.Unmarshal(w.Body.Bytes(), &companies); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return companies
+	}
+
+	t.Run("Active applications conflict", func(t *testing.T) {
+		w := archive("")
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Force archive", func(t *testing.T) {
+		w := archive("?force=true")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var archived database.Company
+		if err := json.Unmarshal(w.Body.Bytes(), &archived); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if !archived.ArchivedAt.Valid {
+			t.Error("Expected archived_at to be set")
+		}
+	})
+
+	t.Run("Archived companies are hidden by default", func(t *testing.T) {
+		if companies := listCompanies(""); len(companies) != 0 {
+			t.Errorf("Expected 0 companies, got %d", len(companies))
+		}
+		if companies := listCompanies("?include_archived=true"); len(companies) != 1 {
+			t.Errorf("Expected 1 company with include_archived, got %d", len(companies))
+		}
+	})
+
+	t.Run("Unarchive", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/companies/"+strconv.Itoa(int(company.ID))+"/unarchive", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if companies := listCompanies(""); len(companies) != 1 {
+			t.Errorf("Expected 1 company after unarchive, got %d", len(companies))
+		}
+	})
+
+	t.Run("Archived applications don't block", func(t *testing.T) {
+		_, err := queries.ArchiveApplicationByIDAndUserID(ctx, database.ArchiveApplicationByIDAndUserIDParams{
+			ID:     application.ID,
+			UserID: testUser.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to archive test application: %v", err)
+		}
+
+		w := archive("")
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+	})
+}
+
+// TestGetAllCompanies_HasWebsiteFilter tests GET /api/companies?has_website=true|false
+func TestGetAllCompanies_HasWebsiteFilter(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-has-website@example.com")
+	defer cleanup()
+
+	for _, params := range []database.CreateCompanyParams{
+		{Name: "With Website", Website: sql.NullString{String: "https://example.com", Valid: true}, UserID: testUser.ID},
+		{Name: "Without Website", UserID: testUser.ID},
+		{Name: "Empty Website", Website: sql.NullString{String: "", Valid: true}, UserID: testUser.ID},
+	} {
+		if _, err := queries.CreateCompany(ctx, params); err != nil {
+			t.Fatalf("Failed to create test company: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"?has_website=true", 1},
+		{"?has_website=false", 2},
+		{"?has_website=false&page=1&limit=1", 1},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/companies"+tt.query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %q, got %d. Body: %s", http.StatusOK, tt.query, w.Code, w.Body.String())
+		}
+
+		var count int
+		if strings.Contains(tt.query, "page=") {
+			var response PaginatedResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			count = len(response.Data)
+			if response.Meta.TotalCount != 2 {
+				t.Errorf("Expected total_count 2 for %q, got %d", tt.query, response.Meta.TotalCount)
+			}
+		} else {
+			var companies []database.Company
+			if err := json.Unmarshal(w.Body.Bytes(), &companies); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			count = len(companies)
+		}
+		if count != tt.expected {
+			t.Errorf("Expected %d companies for %q, got %d", tt.expected, tt.query, count)
+		}
+	}
+
+	// Test invalid value
+	req := httptest.NewRequest("GET", "/api/companies?has_website=yes", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid has_website, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestBatchCreateCompanies tests POST /api/companies/batch (get-or-create per item)
+func TestBatchCreateCompanies(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-batch@example.com")
+	defer cleanup()
+
+	existing, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Existing Corp",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	batch := func(body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/companies/batch", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := batch(map[string]interface{}{"companies": []map[string]string{
+		{"name": "new  startup", "website": "https://startup.example.com"},
+		{"name": "existing corp"},
+		{"name": "New Startup"},
+	}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Results  []BatchCompanyResult `json:"results"`
+		Created  int                  `json:"created"`
+		Existing int                  `json:"existing"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Created != 1 || response.Existing != 2 || len(response.Results) != 3 {
+		t.Fatalf("Expected 1 created and 2 existing, got %+v", response)
+	}
+	if !response.Results[0].Created || response.Results[0].Company.Name != "New Startup" {
+		t.Errorf("Expected the first item to create \"New Startup\", got %+v", response.Results[0])
+	}
+	if response.Results[1].Created || response.Results[1].Company.ID != existing.ID {
+		t.Errorf("Expected the second item to match company %d, got %+v", existing.ID, response.Results[1])
+	}
+	if response.Results[2].Created || response.Results[2].Company.ID != response.Results[0].Company.ID {
+		t.Errorf("Expected the third item to match the company created by the first, got %+v", response.Results[2])
+	}
+
+	// Test invalid batches
+	tooMany := make([]map[string]string, MaxCompanyBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = map[string]string{"name": "Company " + strconv.Itoa(i)}
+	}
+	for name, body := range map[string]interface{}{
+		"empty":        map[string]interface{}{"companies": []map[string]string{}},
+		"missing name": map[string]interface{}{"companies": []map[string]string{{"website": "https://example.com"}}},
+		"too many":     map[string]interface{}{"companies": tooMany},
+	} {
+		if w := batch(body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s batch, got %d", http.StatusBadRequest, name, w.Code)
+		}
+	}
+}
+
+// TestFavoriteCompany tests PATCH /api/companies/:id/favorite and the ?favorite= / ?favorites_first= list options
+func TestFavoriteCompany(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-favorite@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-favorite-other@example.com")
+	defer otherCleanup()
+
+	var companies []database.Company
+	for _, name := range []string{"Alpha Corp", "Beta Corp", "Gamma Corp"} {
+		company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: name, UserID: testUser.ID})
+		if err != nil {
+			t.Fatalf("Failed to create test company: %v", err)
+		}
+		companies = append(companies, company)
+	}
+	dream := companies[2]
+
+	request := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var reader *bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(jsonBody)
+		} else {
+			reader = bytes.NewBuffer(nil)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	favoritePath := "/api/companies/" + strconv.Itoa(int(dream.ID)) + "/favorite"
+
+	w := request("PATCH", favoritePath, testUser.Token, map[string]interface{}{"is_favorite": true})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var starred database.Company
+	if err := json.Unmarshal(w.Body.Bytes(), &starred); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !starred.IsFavorite {
+		t.Errorf("Expected company %d to be a favorite", dream.ID)
+	}
+
+	t.Run("Favorite filter", func(t *testing.T) {
+		var favorites []database.Company
+		if err := json.Unmarshal(request("GET", "/api/companies?favorite=true", testUser.Token, nil).Body.Bytes(), &favorites); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(favorites) != 1 || favorites[0].ID != dream.ID {
+			t.Errorf("Expected only company %d, got %+v", dream.ID, favorites)
+		}
+
+		var paginated PaginatedResponse
+		if err := json.Unmarshal(request("GET", "/api/companies?favorite=false&page=1&limit=10", testUser.Token, nil).Body.Bytes(), &paginated); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if paginated.Meta.TotalCount != 2 {
+			t.Errorf("Expected 2 non-favorite companies, got %+v", paginated.Meta)
+		}
+
+		if w := request("GET", "/api/companies?favorite=yes", testUser.Token, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("Favorites first", func(t *testing.T) {
+		var listed []database.Company
+		if err := json.Unmarshal(request("GET", "/api/companies?favorites_first=true", testUser.Token, nil).Body.Bytes(), &listed); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(listed) != 3 || listed[0].ID != dream.ID || listed[1].ID != companies[0].ID {
+			t.Errorf("Expected %d first, then the rest by name, got %+v", dream.ID, listed)
+		}
+	})
+
+	t.Run("Unfavorite", func(t *testing.T) {
+		w := request("PATCH", favoritePath, testUser.Token, map[string]interface{}{"is_favorite": false})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var company database.Company
+		if err := json.Unmarshal(w.Body.Bytes(), &company); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if company.IsFavorite {
+			t.Errorf("Expected company %d to no longer be a favorite", dream.ID)
+		}
+	})
+
+	t.Run("Missing is_favorite", func(t *testing.T) {
+		if w := request("PATCH", favoritePath, testUser.Token, map[string]interface{}{}); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("Other user's company", func(t *testing.T) {
+		if w := request("PATCH", favoritePath, otherUser.Token, map[string]interface{}{"is_favorite": true}); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+// TestGetCompanyActivity tests GET /api/companies/:id/activity
+func TestGetCompanyActivity(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-activity@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-activity-other@example.com")
+	defer otherCleanup()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Activity Corp", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+	createTestApplication(t, queries, testUser.ID, "applied", "") // no job at this company, so not in the timeline
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: application.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	if _, err := db.Exec("UPDATE applications SET status = 'interview' WHERE id = $1", application.ID); err != nil {
+		t.Fatalf("Failed to update application status: %v", err)
+	}
+	scheduledAt := time.Now().Add(48 * time.Hour)
+	if _, err := queries.CreateInterview(ctx, database.CreateInterviewParams{
+		ApplicationID: application.ID,
+		Title:         "Onsite",
+		ScheduledAt:   sql.NullTime{Time: scheduledAt, Valid: true},
+	}); err != nil {
+		t.Fatalf("Failed to create test interview: %v", err)
+	}
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	activityPath := "/api/companies/" + strconv.Itoa(int(company.ID)) + "/activity"
+
+	w := get(activityPath, testUser.Token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data []database.GetCompanyActivityByUserIDRow `json:"data"`
+		Meta PaginationMeta                           `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Meta.TotalCount != 3 || len(response.Data) != 3 {
+		t.Fatalf("Expected 3 events, got %+v", response)
+	}
+	// Newest first: the upcoming interview, then the status change, then the application itself
+	expected := []string{"interview", "status_changed", "application_created"}
+	for i, event := range response.Data {
+		if event.EventType != expected[i] || event.ApplicationID != application.ID || event.JobTitle != "Engineer" {
+			t.Errorf("Expected event %d to be %s for application %d, got %+v", i, expected[i], application.ID, event)
+		}
+	}
+	if response.Data[0].InterviewTitle.String != "Onsite" || response.Data[1].Status.String != "interview" {
+		t.Errorf("Unexpected event details: %+v", response.Data)
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		var paginated PaginatedResponse
+		if err := json.Unmarshal(get(activityPath+"?page=2&limit=2", testUser.Token).Body.Bytes(), &paginated); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if paginated.Meta.TotalPages != 2 || len(paginated.Data) != 1 {
+			t.Errorf("Expected the last of 3 events on page 2, got %+v (%d events)", paginated.Meta, len(paginated.Data))
+		}
+	})
+
+	t.Run("Other user's company", func(t *testing.T) {
+		if w := get(activityPath, otherUser.Token); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+// TestDeleteCompany_Dependents tests GET /api/companies/:id/delete-impact and the ?confirm=true requirement on DELETE
+func TestDeleteCompany_Dependents(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-delete-impact@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-delete-impact-other@example.com")
+	defer otherCleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Company With Jobs", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	active := createTestApplication(t, queries, testUser.ID, "interview", "")
+	closed := createTestApplication(t, queries, testUser.ID, "rejected", "")
+	// An archived application is not active, whatever its status
+	archived := createTestApplication(t, queries, testUser.ID, "interview", "")
+	if _, err := queries.ArchiveApplicationByIDAndUserID(ctx, database.ArchiveApplicationByIDAndUserIDParams{ID: archived.ID, UserID: testUser.ID}); err != nil {
+		t.Fatalf("Failed to archive test application: %v", err)
+	}
+	for _, application := range []database.Application{active, closed, archived} {
+		if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: application.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+			t.Fatalf("Failed to create test job: %v", err)
+		}
+	}
+
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	companyPath := "/api/companies/" + strconv.Itoa(int(company.ID))
+
+	w := request("GET", companyPath+"/delete-impact", testUser.Token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var impact CompanyDeleteImpact
+	if err := json.Unmarshal(w.Body.Bytes(), &impact); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if impact.Jobs != 3 || impact.Applications != 3 || impact.ActiveApplications != 1 || !impact.RequiresConfirm {
+		t.Errorf("Expected 3 jobs, 3 applications (1 active) and a required confirm, got %+v", impact)
+	}
+
+	if w := request("GET", companyPath+"/delete-impact", otherUser.Token); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's company, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := request("DELETE", companyPath+"?confirm=true", otherUser.Token); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's company, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Without confirm nothing is deleted
+	if w := request("DELETE", companyPath, testUser.Token); w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if _, err := queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{ID: company.ID, UserID: testUser.ID}); err != nil {
+		t.Fatalf("Expected the company to still exist: %v", err)
+	}
+
+	if w := request("DELETE", companyPath+"?confirm=true", testUser.Token); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	// The applications are kept without their job
+	if _, err := queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{ID: active.ID, UserID: testUser.ID}); err != nil {
+		t.Errorf("Expected application %d to be kept: %v", active.ID, err)
+	}
+}
+
+// TestGetCompanyResponseTime tests GET /api/companies/:id/response-time
+func TestGetCompanyResponseTime(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-companies-response-time@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-companies-response-time-other@example.com")
+	defer otherCleanup()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Responsive Corp", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	// Applied 10 and 4 days ago, answered today; the third application is still waiting
+	var applications []database.Application
+	for _, daysAgo := range []int{10, 4, 1} {
+		application := createTestApplication(t, queries, testUser.ID, "applied", "")
+		if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: application.ID, CompanyID: company.ID, Title: "Engineer"}); err != nil {
+			t.Fatalf("Failed to create test job: %v", err)
+		}
+		if _, err := db.Exec("UPDATE applications SET applied_date = CURRENT_DATE - $1::int WHERE id = $2", daysAgo, application.ID); err != nil {
+			t.Fatalf("Failed to update applied date: %v", err)
+		}
+		applications = append(applications, application)
+	}
+
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/companies/"+strconv.Itoa(int(company.ID))+"/response-time", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	getResponseTime := func() CompanyResponseTime {
+		w := get(testUser.Token)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response CompanyResponseTime
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+	respond := func(application database.Application, status string) {
+		if _, err := db.Exec("UPDATE applications SET status = $1 WHERE id = $2", status, application.ID); err != nil {
+			t.Fatalf("Failed to update application status: %v", err)
+		}
+	}
+
+	// One response is not enough data for an estimate
+	respond(applications[0], "interview")
+	response := getResponseTime()
+	if response.MedianDays != nil || response.Samples != 1 {
+		t.Errorf("Expected no estimate from 1 sample, got %+v", response)
+	}
+
+	// Later status changes don't move the first response
+	respond(applications[0], "offer")
+	respond(applications[1], "rejected")
+	response = getResponseTime()
+	if response.Samples != 2 || response.MedianDays == nil {
+		t.Fatalf("Expected an estimate from 2 samples, got %+v", response)
+	}
+	if *response.MedianDays < 7 || *response.MedianDays > 8 {
+		t.Errorf("Expected a median of about 7 days, got %v", *response.MedianDays)
+	}
+
+	if w := get(otherUser.Token); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's company, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// GetCompanyActivity handles GET /api/companies/:id/activity
+// Returns a timeline of the company's activity, newest first, as a PaginatedResponse (?page=1&limit=10)
+// Events come from the applications linked to the company through their jobs: application_created,
+// status_changed (one per later status change) and interview (at its scheduled time)
+func (h *CompanyHandler) GetCompanyActivity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid company ID", "ID must be a number")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the company exists and belongs to the user (an empty timeline would hide a wrong ID)
+	_, err = h.queries.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Company") {
+		return
+	}
+
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	events, err := h.queries.GetCompanyActivityByUserID(ctx, database.GetCompanyActivityByUserIDParams{
+		CompanyID: int32(id),
+		UserID:    userID,
+		Limit:     params.Limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch company activity", err)
+		return
+	}
+
+	totalCount, err := h.queries.CountCompanyActivityByUserID(ctx, database.CountCompanyActivityByUserIDParams{
+		CompanyID: int32(id),
+		UserID:    userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count company activity", err)
+		return
+	}
+
+	data := make([]interface{}, len(events))
+	for i, event := range events {
+		data[i] = event
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
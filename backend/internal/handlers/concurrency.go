@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resourceETag builds a strong ETag from a resource's updated_at
+// Microsecond precision matches Postgres TIMESTAMP, so the value round-trips exactly
+func resourceETag(updatedAt sql.NullTime) string {
+	if !updatedAt.Valid {
+		return ""
+	}
+	return `"` + strconv.FormatInt(updatedAt.Time.UnixMicro(), 10) + `"`
+}
+
+// setETag sets the ETag response header so clients can send it back in If-Match
+func setETag(c *gin.Context, updatedAt sql.NullTime) {
+	if etag := resourceETag(updatedAt); etag != "" {
+		c.Header("ETag", etag)
+	}
+}
+
+// parseIfMatch reads the optional If-Match header for conditional updates
+// Accepts an ETag returned by this API or the resource's updated_at (RFC 3339)
+// Returns an invalid NullTime when the header is absent or "*" (last write wins)
+func parseIfMatch(c *gin.Context) (sql.NullTime, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return sql.NullTime{}, nil
+	}
+
+	// ETag form: "1700000000123456" (weak W/ prefix tolerated)
+	etag := strings.TrimPrefix(value, "W/")
+	if len(etag) >= 2 && strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`) {
+		micros, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+		if err != nil {
+			return sql.NullTime{}, errors.New("If-Match must be an ETag returned by the API or an RFC 3339 updated_at timestamp")
+		}
+		return sql.NullTime{Time: time.UnixMicro(micros).UTC(), Valid: true}, nil
+	}
+
+	// Timestamp form: the updated_at value from a previous response
+	updatedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return sql.NullTime{}, errors.New("If-Match must be an ETag returned by the API or an RFC 3339 updated_at timestamp")
+	}
+	return sql.NullTime{Time: updatedAt.UTC(), Valid: true}, nil
+}
+
+// requireIfMatch parses If-Match and sends a 400 response if it is malformed
+// Returns false if a response was already sent
+func requireIfMatch(c *gin.Context) (sql.NullTime, bool) {
+	expected, err := parseIfMatch(c)
+	if err != nil {
+		sendBadRequest(c, "Invalid If-Match header", err.Error())
+		return sql.NullTime{}, false
+	}
+	return expected, true
+}
+
+// handleConditionalUpdateError is handleDatabaseError for updates guarded by If-Match
+// A conditional update that matched no row is either stale (412) or missing (404); exists tells them apart
+func handleConditionalUpdateError(c *gin.Context, err error, expected sql.NullTime, resource string, exists func() error) bool {
+	if err == sql.ErrNoRows && expected.Valid {
+		if existsErr := exists(); existsErr != nil {
+			return handleDatabaseError(c, existsErr, resource)
+		}
+		sendError(c, http.StatusPreconditionFailed, resource+" was modified by another request",
+			"Reload the "+strings.ToLower(resource)+" and retry with its current ETag")
+		return true
+	}
+	return handleDatabaseError(c, err, resource)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// TestParseIfMatch tests parsing of the If-Match header used for conditional updates
+func TestParseIfMatch(t *testing.T) {
+	updatedAt := sql.NullTime{Time: time.Date(2024, 1, 15, 10, 30, 0, 123456000, time.UTC), Valid: true}
+	etag := resourceETag(updatedAt)
+
+	tests := []struct {
+		name      string
+		header    string
+		expected  sql.NullTime
+		expectErr bool
+	}{
+		{"No header", "", sql.NullTime{}, false},
+		{"Wildcard", "*", sql.NullTime{}, false},
+		{"ETag", etag, updatedAt, false},
+		{"Weak ETag", "W/" + etag, updatedAt, false},
+		{"Timestamp", "2024-01-15T10:30:00.123456Z", updatedAt, false},
+		{"Malformed ETag", `"abc"`, sql.NullTime{}, true},
+		{"Malformed value", "yesterday", sql.NullTime{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newQueryContext("")
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			got, err := parseIfMatch(c)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.header)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got.Valid != tt.expected.Valid || !got.Time.Equal(tt.expected.Time) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/clerk/clerk-sdk-go/v2/jwks"
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
@@ -10,19 +13,40 @@ import (
 // Config holds shared dependencies for all handlers
 type Config struct {
 	DB            *database.Queries
+	DBConn        *sql.DB // raw connection, used to begin transactions
 	ClerkJWKS     *jwks.Client
-	UseLegacyAuth bool // if true, use LegacyAuthMiddleware (tests only)
+	CountCache    *CountCache        // optional; nil disables caching of pagination counts
+	Webhooks      *WebhookDispatcher // optional; nil disables webhook deliveries
+	UseLegacyAuth bool               // if true, use LegacyAuthMiddleware (tests only)
+}
+
+// RequestTimeoutOverrides sets per-route request timeouts for middleware.RequestTimeoutMiddleware
+// Streaming exports can legitimately run longer than REQUEST_TIMEOUT, so they have none (0)
+var RequestTimeoutOverrides = map[string]time.Duration{
+	"/api/contacts/export": 0,
 }
 
 // SetupRoutes registers all API routes with the Gin router
 func (cfg *Config) SetupRoutes(r *gin.Engine) {
 	authMiddleware := cfg.authMiddleware()
 	// Initialize handlers
-	companyHandler := NewCompanyHandler(cfg.DB)
-	jobHandler := NewJobHandler(cfg.DB)
-	applicationHandler := NewApplicationHandler(cfg.DB)
-	contactHandler := NewContactHandler(cfg.DB)
-	userHandler := NewUserHandler(cfg.DB)
+	companyHandler := NewCompanyHandler(cfg.DBConn, cfg.DB, cfg.CountCache)
+	jobHandler := NewJobHandler(cfg.DBConn, cfg.DB, cfg.CountCache)
+	applicationHandler := NewApplicationHandler(cfg.DBConn, cfg.DB, cfg.CountCache, cfg.Webhooks)
+	contactHandler := NewContactHandler(cfg.DBConn, cfg.DB)
+	userHandler := NewUserHandler(cfg.DB, cfg.introspectionJWKS())
+	statsHandler := NewStatsHandler(cfg.DB)
+	reminderHandler := NewReminderHandler(cfg.DB)
+	dashboardHandler := NewDashboardHandler(cfg.DB)
+	documentHandler := NewDocumentHandler(cfg.DBConn, cfg.DB, cfg.CountCache)
+	noteHandler := NewApplicationNoteHandler(cfg.DBConn, cfg.DB)
+	tagHandler := NewTagHandler(cfg.DBConn, cfg.DB)
+	webhookHandler := NewWebhookHandler(cfg.DB, cfg.Webhooks)
+	shareHandler := NewShareHandler(cfg.DB)
+	interviewHandler := NewInterviewHandler(cfg.DB)
+	importHandler := NewImportHandler(cfg.DBConn, cfg.DB, cfg.CountCache, cfg.Webhooks)
+	inviteHandler := NewInviteHandler(cfg.DB)
+	adminHandler := NewAdminHandler(cfg.DB)
 
 	// API routes
 	api := r.Group("/api")
@@ -36,6 +60,17 @@ func (cfg *Config) SetupRoutes(r *gin.Engine) {
 			authPublic.POST("/register", userHandler.Register)
 			authPublic.POST("/login", userHandler.Login)
 			authPublic.POST("/refresh", userHandler.Refresh)
+			// Token check for other services: body {"token": ...}, returns its claims or 401
+			// Accepts the tokens of the auth middleware in use (Clerk session tokens, or legacy access tokens)
+			authPublic.POST("/introspect", userHandler.Introspect)
+		}
+
+		// Shared application view (public - the share token is the credential)
+		// Rate limited like the auth routes to slow down token guessing
+		sharedPublic := api.Group("/shared")
+		sharedPublic.Use(middleware.RateLimitMiddleware(5.0, 10))
+		{
+			sharedPublic.GET("/:token", shareHandler.GetSharedApplication)
 		}
 
 		// Auth routes (protected)
@@ -45,6 +80,8 @@ func (cfg *Config) SetupRoutes(r *gin.Engine) {
 			authProtected.POST("/logout", userHandler.Logout)
 			authProtected.GET("/me", userHandler.Me)
 			authProtected.PUT("/me", userHandler.UpdateMe)
+			authProtected.PATCH("/me", userHandler.PatchMe)
+			authProtected.GET("/me/usage", userHandler.Usage)
 		}
 
 		// Protected routes
@@ -56,43 +93,175 @@ func (cfg *Config) SetupRoutes(r *gin.Engine) {
 			// Nested route: Get jobs by company (must be before /companies/:id)
 			// Use :id instead of :companyId to avoid route conflict
 			protected.GET("/companies/:id/jobs", jobHandler.GetJobsByCompanyID)
+			// Merged timeline: applications created, status changes and interviews through the company's jobs
+			protected.GET("/companies/:id/activity", companyHandler.GetCompanyActivity)
+			// What DELETE would affect; DELETE needs ?confirm=true while the company has jobs
+			protected.GET("/companies/:id/delete-impact", companyHandler.GetCompanyDeleteImpact)
+			// Median days until the company responds, from the status history (null without enough data)
+			protected.GET("/companies/:id/response-time", companyHandler.GetCompanyResponseTime)
+			// Lightweight count honoring the list filters (must be before /companies/:id)
+			protected.GET("/companies/count", companyHandler.CountCompanies)
+			// Preview of the get-or-create match for a name (must be before /companies/:id)
+			protected.GET("/companies/check", companyHandler.CheckCompany)
 			protected.GET("/companies/:id", companyHandler.GetCompanyByID)
 			protected.POST("/companies", companyHandler.CreateCompany)
+			// Bulk get-or-create: body {"companies": [{"name": ..., "website": ...}]}, at most MaxCompanyBatchSize per request
+			protected.POST("/companies/batch", companyHandler.BatchCreateCompanies)
 			protected.PUT("/companies/:id", companyHandler.UpdateCompany)
 			protected.DELETE("/companies/:id", companyHandler.DeleteCompany)
+			// Archived companies are hidden from lists unless ?include_archived=true
+			protected.POST("/companies/:id/archive", companyHandler.ArchiveCompany)
+			protected.POST("/companies/:id/unarchive", companyHandler.UnarchiveCompany)
+			// Star/unstar: body {"is_favorite": true}; lists take ?favorite= and ?favorites_first=true
+			protected.PATCH("/companies/:id/favorite", companyHandler.FavoriteCompany)
 
 			// Job routes
 			protected.GET("/jobs", jobHandler.GetAllJobs)
+			// Lightweight count honoring the list filters (must be before /jobs/:id)
+			protected.GET("/jobs/count", jobHandler.CountJobs)
 			protected.GET("/jobs/:id", jobHandler.GetJobByID)
 			protected.POST("/jobs", jobHandler.CreateJob)
+			// Quick add: fetch a job posting URL and return a prefilled draft (nothing is saved)
+			protected.POST("/jobs/parse", jobHandler.ParseJobURL)
 			protected.PUT("/jobs/:id", jobHandler.UpdateJob)
+			// Move a job to another application: body {"application_id": ...}
+			protected.PATCH("/jobs/:id", jobHandler.MoveJob)
+			// Copy a job to another application: body {"application_id": ..., "company_id": ...} (company_id optional)
+			protected.POST("/jobs/:id/clone", jobHandler.CloneJob)
 			protected.DELETE("/jobs/:id", jobHandler.DeleteJob)
 
 			// Application routes
 			protected.GET("/applications", applicationHandler.GetAllApplications)
 			// Note: Get applications by status is handled via query parameter in GetAllApplications
 			// Example: GET /api/applications?status=applied
+			// Follow-up report: applications still in "applied" after ?days= (must be before /applications/:id)
+			protected.GET("/applications/stale", reminderHandler.GetStaleApplications)
+			// Lightweight count honoring the list filters (must be before /applications/:id)
+			protected.GET("/applications/count", applicationHandler.CountApplications)
+			// Distinct statuses actually in use, for filter dropdowns (must be before /applications/:id)
+			protected.GET("/applications/used-statuses", applicationHandler.GetUsedStatuses)
+			// Multi-field filtering with a JSON body (statuses, source, company, tag, applied date range)
+			protected.POST("/applications/search", applicationHandler.SearchApplications)
+			// Bulk archive: body {"application_ids": [...]}, at most MaxBatchIDs per request (must be before /applications/:id)
+			protected.POST("/applications/bulk-archive", applicationHandler.BulkArchiveApplications)
 			// Nested route: Get job by application (must be before /applications/:id)
 			protected.GET("/applications/:id/job", applicationHandler.GetJobByApplicationID)
+			// Nested routes: document links of an application
+			protected.GET("/applications/:id/documents", documentHandler.GetDocuments)
+			protected.POST("/applications/:id/documents", documentHandler.CreateDocument)
+			protected.PUT("/applications/:id/documents/:documentId", documentHandler.UpdateDocument)
+			protected.DELETE("/applications/:id/documents/:documentId", documentHandler.DeleteDocument)
+			// Nested routes: timestamped notes (activity log) of an application
+			protected.GET("/applications/:id/notes", noteHandler.GetNotes)
+			protected.POST("/applications/:id/notes", noteHandler.CreateNote)
+			protected.PUT("/applications/:id/notes/:noteId", noteHandler.UpdateNote)
+			protected.DELETE("/applications/:id/notes/:noteId", noteHandler.DeleteNote)
+			// Interview rounds: PATCH records completion, outcome and feedback
+			protected.GET("/applications/:id/interviews", interviewHandler.GetInterviews)
+			protected.POST("/applications/:id/interviews", interviewHandler.CreateInterview)
+			protected.PATCH("/applications/:id/interviews/:interviewId", interviewHandler.UpdateInterview)
+			// Nested routes: read-only share links of an application (DELETE revokes all of them)
+			protected.POST("/applications/:id/share", shareHandler.CreateShare)
+			protected.DELETE("/applications/:id/share", shareHandler.RevokeShares)
+			// Nested route: tags attached to an application
+			protected.GET("/applications/:id/tags", tagHandler.GetApplicationTags)
+			// Push the next action due date out: body {"until": "2024-02-01"}
+			protected.POST("/applications/:id/snooze", applicationHandler.SnoozeApplication)
+			// Archived applications are hidden from lists and reminders unless ?include_archived=true
+			protected.POST("/applications/:id/archive", applicationHandler.ArchiveApplication)
+			protected.POST("/applications/:id/unarchive", applicationHandler.UnarchiveApplication)
 			protected.GET("/applications/:id", applicationHandler.GetApplicationByID)
 			protected.POST("/applications", applicationHandler.CreateApplication)
 			protected.PUT("/applications/:id", applicationHandler.UpdateApplication)
 			protected.DELETE("/applications/:id", applicationHandler.DeleteApplication)
+			// Combined create: company (get-or-create) + application + job in one transaction
+			protected.POST("/track", applicationHandler.TrackApplication)
+
+			// Import routes: recreate companies, contacts, applications and jobs from a versioned JSON file
+			protected.POST("/import/json", importHandler.ImportJSON)
 
 			// Contact routes
 			protected.GET("/contacts", contactHandler.GetAllContacts)
+			// Streamed CSV/JSON backup of all contacts (must be before /contacts/:id)
+			protected.GET("/contacts/export", contactHandler.ExportContacts)
+			// Search across name, email, phone, LinkedIn and role: ?q= (must be before /contacts/:id)
+			protected.GET("/contacts/search", contactHandler.SearchContacts)
+			// Bulk create from a multipart .vcf file (one result per vCard)
+			protected.POST("/contacts/import", contactHandler.ImportContacts)
 			protected.GET("/contacts/:id", contactHandler.GetContactByID)
+			protected.GET("/contacts/:id/referrals", contactHandler.GetContactReferrals)
 			protected.POST("/contacts", contactHandler.CreateContact)
 			protected.PUT("/contacts/:id", contactHandler.UpdateContact)
 			protected.DELETE("/contacts/:id", contactHandler.DeleteContact)
+			protected.POST("/contacts/:id/merge", contactHandler.MergeContact)
+			// File attachments of a contact (e.g. a scanned business card)
+			protected.GET("/contacts/:id/attachments", contactHandler.GetContactAttachments)
+			protected.POST("/contacts/:id/attachments", contactHandler.UploadContactAttachment)
+			protected.GET("/contacts/:id/attachments/:attachmentId", contactHandler.DownloadContactAttachment)
+			protected.DELETE("/contacts/:id/attachments/:attachmentId", contactHandler.DeleteContactAttachment)
+
+			// Tag routes
+			protected.GET("/tags", tagHandler.GetTags)
+			protected.POST("/tags", tagHandler.CreateTag)
+			protected.DELETE("/tags/:id", tagHandler.DeleteTag)
+			// Bulk tagging: body {"application_ids": [...]}, at most MaxBatchIDs per request
+			protected.POST("/tags/:id/apply", tagHandler.ApplyTag)
+			protected.POST("/tags/:id/remove", tagHandler.RemoveTag)
+
+			// Webhook routes (deliveries default to ?status=failed, the dead-lettered ones)
+			protected.GET("/webhooks", webhookHandler.GetWebhooks)
+			protected.POST("/webhooks", webhookHandler.CreateWebhook)
+			protected.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+			protected.GET("/webhooks/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+			protected.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhookDelivery)
+
+			// Stats routes
+			protected.GET("/stats/by-source", statsHandler.GetStatsBySource)
+			protected.GET("/stats/by-industry", statsHandler.GetStatsByIndustry)
+			protected.GET("/stats/by-company", statsHandler.GetStatsByCompany)
+			protected.GET("/stats/offers", statsHandler.GetOfferStats)
+			protected.GET("/stats/referrals", statsHandler.GetReferralStats)
+			protected.GET("/stats/rejection-reasons", statsHandler.GetRejectionReasonStats)
+			protected.GET("/stats/applications/timeline", statsHandler.GetApplicationsTimeline)
+
+			// Reminder routes
+			protected.GET("/reminders", reminderHandler.GetReminders)
+
+			// Dashboard route (home screen payload)
+			protected.GET("/dashboard", dashboardHandler.GetDashboard)
+		}
+
+		// Admin routes (users with role "admin"), rate limited like the public auth routes
+		admin := api.Group("/admin")
+		admin.Use(middleware.RateLimitMiddleware(5.0, 10), authMiddleware, middleware.AdminMiddleware(cfg.DB))
+		{
+			// Instance-wide totals and signups per month (?months=N, default 12)
+			admin.GET("/stats", adminHandler.GetStats)
+			// All users, paginated (?page=, ?limit=), ?q= searches emails
+			admin.GET("/users", adminHandler.GetUsers)
+			// Suspend or restore an account (a disabled user's requests get 403; data is kept)
+			admin.POST("/users/:id/disable", adminHandler.DisableUser)
+			admin.POST("/users/:id/enable", adminHandler.EnableUser)
+
+			// Invite codes for sign-up while REGISTRATION_ENABLED=false
+			admin.POST("/invites", inviteHandler.CreateInvite)
 		}
 	}
 }
 
+// introspectionJWKS returns the JWKS client Introspect verifies tokens with: nil with legacy auth,
+// so the endpoint accepts the same tokens as authMiddleware
+func (cfg *Config) introspectionJWKS() *jwks.Client {
+	if cfg.UseLegacyAuth {
+		return nil
+	}
+	return cfg.ClerkJWKS
+}
+
 func (cfg *Config) authMiddleware() gin.HandlerFunc {
 	if cfg.UseLegacyAuth {
-		return middleware.LegacyAuthMiddleware()
+		return middleware.LegacyAuthMiddleware(cfg.DB)
 	}
-	return middleware.ClerkAuthMiddleware(cfg.DB, cfg.ClerkJWKS)
+	return middleware.ClerkAuthMiddleware(cfg.DBConn, cfg.DB, cfg.ClerkJWKS)
 }
 
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// parseContactAttachmentPath parses :id (contact) and, if present, :attachmentId from the URL
+// Sends a 400 response and returns false if either is not a number
+func parseContactAttachmentPath(c *gin.Context) (contactID int32, attachmentID int32, ok bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid contact ID", "Contact ID must be a number")
+		return 0, 0, false
+	}
+
+	if attachmentIDStr := c.Param("attachmentId"); attachmentIDStr != "" {
+		childID, err := strconv.Atoi(attachmentIDStr)
+		if err != nil {
+			sendBadRequest(c, "Invalid attachment ID", "Attachment ID must be a number")
+			return 0, 0, false
+		}
+		attachmentID = int32(childID)
+	}
+
+	return int32(id), attachmentID, true
+}
+
+// GetContactAttachments handles GET /api/contacts/:id/attachments
+// Returns the files attached to a contact without their content, oldest first (verifies ownership)
+func (h *ContactHandler) GetContactAttachments(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	contactID, _, ok := parseContactAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the contact exists and belongs to the user (so an unknown contact is a 404, not [])
+	_, err := h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+		ID:     contactID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Contact") {
+		return
+	}
+
+	attachments, err := h.queries.GetContactAttachmentsByContactIDAndUserID(ctx, database.GetContactAttachmentsByContactIDAndUserIDParams{
+		ContactID: contactID,
+		UserID:    userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch attachments", err)
+		return
+	}
+	if attachments == nil {
+		attachments = []database.GetContactAttachmentsByContactIDAndUserIDRow{}
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// errAttachmentLimitReached and errAttachmentQuotaExceeded abort an attachment upload transaction
+var (
+	errAttachmentLimitReached  = errors.New("contact attachment limit reached")
+	errAttachmentQuotaExceeded = errors.New("attachment storage quota exceeded")
+)
+
+// UploadContactAttachment handles POST /api/contacts/:id/attachments
+// Stores a file (e.g. a scanned business card) sent as multipart/form-data in the "file" field (verifies ownership)
+// Files are limited to MaxAttachmentSize and allowedAttachmentTypes; a contact holds at most MaxAttachmentsPerContact
+// files (409 beyond) and all of a user's attachments fit in AttachmentStorageQuota (413 beyond)
+func (h *ContactHandler) UploadContactAttachment(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	contactID, _, ok := parseContactAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the contact exists and belongs to the user before reading the upload
+	_, err := h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+		ID:     contactID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Contact") {
+		return
+	}
+
+	// Check the per-contact limit before reading the upload (checked again when saving)
+	if MaxAttachmentsPerContact > 0 {
+		count, err := h.queries.CountContactAttachmentsByContactID(ctx, contactID)
+		if err != nil {
+			sendInternalError(c, "Failed to count attachments", err)
+			return
+		}
+		if count >= int64(MaxAttachmentsPerContact) {
+			sendAttachmentLimitReached(c)
+			return
+		}
+	}
+
+	upload, ok := readAttachmentUpload(c)
+	if !ok {
+		return
+	}
+
+	// The limits are checked and the file stored with the user locked, so concurrent uploads can't both pass
+	var attachment database.CreateContactAttachmentRow
+	var used int64
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		if _, err := qtx.LockUserByID(ctx, userID); err != nil {
+			return err
+		}
+
+		if MaxAttachmentsPerContact > 0 {
+			count, err := qtx.CountContactAttachmentsByContactID(ctx, contactID)
+			if err != nil {
+				return err
+			}
+			if count >= int64(MaxAttachmentsPerContact) {
+				return errAttachmentLimitReached
+			}
+		}
+
+		if AttachmentStorageQuota > 0 {
+			var err error
+			used, err = qtx.GetAttachmentStorageByUserID(ctx, userID)
+			if err != nil {
+				return err
+			}
+			if used+int64(len(upload.Data)) > AttachmentStorageQuota {
+				return errAttachmentQuotaExceeded
+			}
+		}
+
+		var err error
+		attachment, err = qtx.CreateContactAttachment(ctx, database.CreateContactAttachmentParams{
+			ContactID:   contactID,
+			Filename:    upload.Filename,
+			ContentType: upload.ContentType,
+			SizeBytes:   int32(len(upload.Data)),
+			Data:        upload.Data,
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, errAttachmentLimitReached):
+		sendAttachmentLimitReached(c)
+		return
+	case errors.Is(err, errAttachmentQuotaExceeded):
+		sendAttachmentQuotaExceeded(c, used)
+		return
+	}
+	if handleDatabaseError(c, err, "Attachment") {
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// DownloadContactAttachment handles GET /api/contacts/:id/attachments/:attachmentId
+// Returns the file itself as a download (verifies ownership through the contact)
+func (h *ContactHandler) DownloadContactAttachment(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	contactID, attachmentID, ok := parseContactAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.queries.GetContactAttachmentByIDAndUserID(c.Request.Context(), database.GetContactAttachmentByIDAndUserIDParams{
+		ID:        attachmentID,
+		ContactID: contactID,
+		UserID:    userID,
+	})
+	if handleDatabaseError(c, err, "Attachment") {
+		return
+	}
+
+	sendAttachmentFile(c, attachment.Filename, attachment.ContentType, attachment.Data)
+}
+
+// DeleteContactAttachment handles DELETE /api/contacts/:id/attachments/:attachmentId
+// Deletes a file attached to a contact (verifies ownership through the contact)
+func (h *ContactHandler) DeleteContactAttachment(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	contactID, attachmentID, ok := parseContactAttachmentPath(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.queries.DeleteContactAttachment(c.Request.Context(), database.DeleteContactAttachmentParams{
+		ID:        attachmentID,
+		ContactID: contactID,
+		UserID:    userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to delete attachment", err)
+		return
+	}
+	if deleted == 0 {
+		sendNotFound(c, "Attachment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+		"id":      attachmentID,
+	})
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is the start of a PNG file, enough for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// TestContactAttachments tests uploading, listing, downloading and deleting contact attachments
+func TestContactAttachments(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user and another user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contact-attachments@example.com")
+	defer cleanup()
+	otherUser, cleanupOther := createTestUser(t, queries, db, "test-contact-attachments-other@example.com")
+	defer cleanupOther()
+
+	contact, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+		Name:   "Card Holder",
+		UserID: testUser.ID,
+	})
+	require.NoError(t, err)
+	base := "/api/contacts/" + strconv.Itoa(int(contact.ID)) + "/attachments"
+
+	send := func(method, path, token string, body *bytes.Buffer, contentType string) *httptest.ResponseRecorder {
+		if body == nil {
+			body = &bytes.Buffer{}
+		}
+		req := httptest.NewRequest(method, path, body)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	upload := func(token, filename string, content []byte) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return send("POST", base, token, body, writer.FormDataContentType())
+	}
+
+	// Upload a PNG
+	w := upload(testUser.Token, "card.png", pngHeader)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created database.CreateContactAttachmentRow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "card.png", created.Filename)
+	assert.Equal(t, "image/png", created.ContentType)
+	assert.Equal(t, int32(len(pngHeader)), created.SizeBytes)
+	assert.NotContains(t, w.Body.String(), `"data"`)
+
+	// Rejected uploads
+	assert.Equal(t, http.StatusBadRequest, upload(testUser.Token, "notes.txt", []byte("plain text")).Code)
+	assert.Equal(t, http.StatusBadRequest, upload(testUser.Token, "empty.png", nil).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload(testUser.Token, "huge.png", append(pngHeader, make([]byte, MaxAttachmentSize)...)).Code)
+	assert.Equal(t, http.StatusBadRequest, send("POST", base, testUser.Token, bytes.NewBufferString(`{}`), "application/json").Code)
+	assert.Equal(t, http.StatusNotFound, upload(otherUser.Token, "card.png", pngHeader).Code)
+
+	// List without content
+	w = send("GET", base, testUser.Token, nil, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var attachments []database.GetContactAttachmentsByContactIDAndUserIDRow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &attachments))
+	require.Len(t, attachments, 1)
+	assert.Equal(t, created.ID, attachments[0].ID)
+	assert.Equal(t, http.StatusNotFound, send("GET", base, otherUser.Token, nil, "").Code)
+
+	// Download
+	attachmentPath := base + "/" + strconv.Itoa(int(created.ID))
+	w = send("GET", attachmentPath, testUser.Token, nil, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, pngHeader, w.Body.Bytes())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment;"))
+	assert.Equal(t, http.StatusNotFound, send("GET", attachmentPath, otherUser.Token, nil, "").Code)
+
+	// Delete
+	assert.Equal(t, http.StatusNotFound, send("DELETE", attachmentPath, otherUser.Token, nil, "").Code)
+	assert.Equal(t, http.StatusOK, send("DELETE", attachmentPath, testUser.Token, nil, "").Code)
+	assert.Equal(t, http.StatusNotFound, send("GET", attachmentPath, testUser.Token, nil, "").Code)
+}
+
+// TestCleanAttachmentFilename tests that uploaded filenames are reduced to a safe base name
+func TestCleanAttachmentFilename(t *testing.T) {
+	for name, expected := range map[string]string{
+		"card.png":               "card.png",
+		`C:\Users\me\card.pdf`:   "card.pdf",
+		"../../etc/passwd":       "passwd",
+		"  scan\x00\n.jpg ":      "scan.jpg",
+		"":                       "attachment",
+		"/":                      "attachment",
+		strings.Repeat("é", 200): strings.Repeat("é", 127),
+	} {
+		if got := cleanAttachmentFilename(name); got != expected {
+			t.Errorf("cleanAttachmentFilename(%q): expected %q, got %q", name, expected, got)
+		}
+	}
+}
+
+// TestContactAttachmentLimits tests the per-contact attachment limit and the per-user storage quota
+func TestContactAttachmentLimits(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	defer func(maxAttachments int, quota int64) {
+		MaxAttachmentsPerContact, AttachmentStorageQuota = maxAttachments, quota
+	}(MaxAttachmentsPerContact, AttachmentStorageQuota)
+	MaxAttachmentsPerContact = 1
+	AttachmentStorageQuota = int64(len(pngHeader)) * 2
+
+	testUser, cleanup := createTestUser(t, queries, db, "test-contact-attachment-limits@example.com")
+	defer cleanup()
+
+	uploadRequest := func(contactID int32) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "card.png")
+		require.NoError(t, err)
+		_, err = part.Write(pngHeader)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest("POST", "/api/contacts/"+strconv.Itoa(int(contactID))+"/attachments", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		return req
+	}
+	upload := func(contactID int32) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, uploadRequest(contactID))
+		return w
+	}
+
+	var contactIDs []int32
+	for _, name := range []string{"First Holder", "Second Holder", "Third Holder", "Fourth Holder"} {
+		contact, err := queries.CreateContact(context.Background(), database.CreateContactParams{Name: name, UserID: testUser.ID})
+		require.NoError(t, err)
+		contactIDs = append(contactIDs, contact.ID)
+	}
+
+	// One file per contact
+	require.Equal(t, http.StatusCreated, upload(contactIDs[0]).Code)
+	w := upload(contactIDs[0])
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	// Two files in total across the user's contacts
+	require.Equal(t, http.StatusCreated, upload(contactIDs[1]).Code)
+	w = upload(contactIDs[2])
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "Storage quota exceeded")
+
+	// Concurrent uploads can't get past the limit together
+	AttachmentStorageQuota = 0
+	requests := make([]*http.Request, 5)
+	for i := range requests {
+		requests[i] = uploadRequest(contactIDs[3])
+	}
+	codes := make(chan int, len(requests))
+	var wg sync.WaitGroup
+	for _, req := range requests {
+		wg.Add(1)
+		go func(req *http.Request) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			codes <- w.Code
+		}(req)
+	}
+	wg.Wait()
+	close(codes)
+	statuses := map[int]int{}
+	for code := range codes {
+		statuses[code]++
+	}
+	assert.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: len(requests) - 1}, statuses)
+}
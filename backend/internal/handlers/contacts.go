@@ -1,28 +1,51 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
+// emailDomainPattern matches a hostname such as "acme.com" or "mail.acme.co.uk"
+var emailDomainPattern = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// validContactRoles lists the allowed values for a contact's role
+// Keep in sync with the oneof tags on the create/update request structs
+var validContactRoles = map[string]bool{
+	"recruiter":      true,
+	"hiring_manager": true,
+	"interviewer":    true,
+	"referrer":       true,
+	"peer":           true,
+	"other":          true,
+}
+
 // ContactHandler handles HTTP requests for contacts
 type ContactHandler struct {
+	db      *sql.DB
 	queries *database.Queries
 }
 
 // NewContactHandler creates a new contact handler
-func NewContactHandler(queries *database.Queries) *ContactHandler {
+func NewContactHandler(db *sql.DB, queries *database.Queries) *ContactHandler {
 	return &ContactHandler{
+		db:      db,
 		queries: queries,
 	}
 }
 
 // GetAllContacts handles GET /api/contacts
-// Returns all contacts for the authenticated user
+// Returns all contacts or paginated contacts if page/limit query params are provided
+// Query params: ?page=1&limit=10 (optional, backward compatible); filters and sort apply to both
+// Supports ?sort=created_at:desc (fields: created_at, name); defaults to DEFAULT_SORT_CONTACTS
+// Supports ?email_domain=acme.com to keep only contacts with an email at that domain (subdomains don't match)
+// Supports ?role=recruiter to filter by role
 func (h *ContactHandler) GetAllContacts(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
@@ -30,15 +53,92 @@ func (h *ContactHandler) GetAllContacts(c *gin.Context) {
 		return
 	}
 
+	listSort, ok := resolveListSort(c, sortResourceContacts)
+	if !ok {
+		return
+	}
+
+	emailDomain, ok := parseEmailDomain(c)
+	if !ok {
+		return
+	}
+
+	role := c.Query("role")
+	if role != "" && !validContactRoles[role] {
+		sendBadRequest(c, "Invalid role", "role must be one of: recruiter, hiring_manager, interviewer, referrer, peer, other")
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	contacts, err := h.queries.GetContactsByUserID(ctx, userID)
+	filters := database.GetContactsByUserIDParams{
+		UserID:      userID,
+		EmailDomain: emailDomain,
+		Role:        sql.NullString{String: role, Valid: role != ""},
+		SortKey:     listSort.key(),
+	}
+
+	// If no pagination params, return all (backward compatible)
+	if c.Query("page") == "" && c.Query("limit") == "" {
+		contacts, err := h.queries.GetContactsByUserID(ctx, filters)
+		if err != nil {
+			sendInternalError(c, "Failed to fetch contacts", err)
+			return
+		}
+		c.JSON(http.StatusOK, contacts)
+		return
+	}
+
+	// Parse pagination parameters
+	params := ParsePaginationParams(c)
+	filters.RowLimit = sql.NullInt32{Int32: params.Limit, Valid: true}
+	filters.RowOffset = CalculateOffset(params.Page, params.Limit)
+
+	contacts, err := h.queries.GetContactsByUserID(ctx, filters)
 	if err != nil {
 		sendInternalError(c, "Failed to fetch contacts", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, contacts)
+	totalCount, err := h.queries.CountContactsByUserID(ctx, database.CountContactsByUserIDParams{
+		UserID:      filters.UserID,
+		EmailDomain: filters.EmailDomain,
+		Role:        filters.Role,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count contacts", err)
+		return
+	}
+
+	// Convert to interface{} for paginated response
+	data := make([]interface{}, len(contacts))
+	for i, contact := range contacts {
+		data[i] = contact
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
+// parseEmailDomain parses the optional ?email_domain= filter (e.g. acme.com)
+// Sends a 400 response and returns false if it is not a valid domain
+func parseEmailDomain(c *gin.Context) (sql.NullString, bool) {
+	domain := strings.TrimSpace(c.Query("email_domain"))
+	if domain == "" {
+		return sql.NullString{}, true
+	}
+	if len(domain) > 253 || !emailDomainPattern.MatchString(domain) {
+		sendBadRequest(c, "Invalid email_domain", "email_domain must be a domain name such as acme.com")
+		return sql.NullString{}, false
+	}
+	return sql.NullString{String: domain, Valid: true}, true
 }
 
 // GetContactByID handles GET /api/contacts/:id
@@ -71,15 +171,89 @@ func (h *ContactHandler) GetContactByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, contact.UpdatedAt)
 	c.JSON(http.StatusOK, contact)
 }
 
+// GetContactReferrals handles GET /api/contacts/:id/referrals
+// Returns the applications this contact referred the user for, most recently applied first (verifies ownership)
+func (h *ContactHandler) GetContactReferrals(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	contactID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid contact ID", "Contact ID must be a number")
+		return
+	}
+
+	// Verify the contact exists and belongs to the user (so an unknown contact is a 404, not [])
+	_, err = h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+		ID:     int32(contactID),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Contact") {
+		return
+	}
+
+	applications, err := h.queries.GetApplicationsReferredByContactIDAndUserID(ctx, database.GetApplicationsReferredByContactIDAndUserIDParams{
+		ReferredByContactID: sql.NullInt32{Int32: int32(contactID), Valid: true},
+		UserID:              userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch referrals", err)
+		return
+	}
+	if applications == nil {
+		applications = []database.Application{}
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
 // CreateContactRequest represents the JSON body for creating a contact
 type CreateContactRequest struct {
 	Name     string `json:"name" binding:"required,min=1,max=255"`
 	Email    string `json:"email" binding:"omitempty,email,max=255"`
 	Phone    string `json:"phone" binding:"omitempty,min=10,max=20"`
 	Linkedin string `json:"linkedin" binding:"omitempty,url,max=500"`
+	Role     string `json:"role" binding:"omitempty,oneof=recruiter hiring_manager interviewer referrer peer other"`
+}
+
+// getOrCreateContact returns the user's existing contact with the same email (case-insensitive),
+// or creates a new one. Contacts without an email are always created.
+// Returns the contact and whether it was newly created.
+func getOrCreateContact(ctx context.Context, queries *database.Queries, userID int32, req CreateContactRequest) (database.Contact, bool, error) {
+	if req.Email != "" {
+		existing, err := queries.GetContactByEmailAndUserID(ctx, database.GetContactByEmailAndUserIDParams{
+			Lower:  req.Email,
+			UserID: userID,
+		})
+		if err == nil {
+			return existing, false, nil
+		}
+		if err != sql.ErrNoRows {
+			return database.Contact{}, false, err
+		}
+	}
+
+	contact, err := queries.CreateContact(ctx, database.CreateContactParams{
+		Name:     req.Name,
+		Email:    sql.NullString{String: req.Email, Valid: req.Email != ""},
+		Phone:    sql.NullString{String: req.Phone, Valid: req.Phone != ""},
+		Linkedin: sql.NullString{String: req.Linkedin, Valid: req.Linkedin != ""},
+		UserID:   userID,
+		Role:     sql.NullString{String: req.Role, Valid: req.Role != ""},
+	})
+	if err != nil {
+		return database.Contact{}, false, err
+	}
+	return contact, true, nil
 }
 
 // CreateContact handles POST /api/contacts
@@ -94,7 +268,7 @@ func (h *ContactHandler) CreateContact(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	var req CreateContactRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
@@ -106,6 +280,7 @@ func (h *ContactHandler) CreateContact(c *gin.Context) {
 		Phone:    sql.NullString{String: req.Phone, Valid: req.Phone != ""},
 		Linkedin: sql.NullString{String: req.Linkedin, Valid: req.Linkedin != ""},
 		UserID:   userID,
+		Role:     sql.NullString{String: req.Role, Valid: req.Role != ""},
 	})
 	if err != nil {
 		handleDatabaseError(c, err, "Contact")
@@ -117,10 +292,11 @@ func (h *ContactHandler) CreateContact(c *gin.Context) {
 
 // UpdateContactRequest represents the JSON body for updating a contact
 type UpdateContactRequest struct {
-	Name     string `json:"name" binding:"required,min=1,max=255"`
-	Email    string `json:"email" binding:"omitempty,email,max=255"`
-	Phone    string `json:"phone" binding:"omitempty,min=10,max=20"`
-	Linkedin string `json:"linkedin" binding:"omitempty,url,max=500"`
+	Name     string         `json:"name" binding:"required,min=1,max=255"`
+	Email    string         `json:"email" binding:"omitempty,email,max=255"`
+	Phone    string         `json:"phone" binding:"omitempty,min=10,max=20"`
+	Linkedin string         `json:"linkedin" binding:"omitempty,url,max=500"`
+	Role     optionalString `json:"role" binding:"omitempty,oneof=recruiter hiring_manager interviewer referrer peer other"` // Omitted leaves it unchanged, null or "" clears it
 }
 
 // UpdateContact handles PUT /api/contacts/:id
@@ -141,25 +317,40 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 	}
 
 	var req UpdateContactRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
 
+	// Optional optimistic concurrency: If-Match carries the version the client last saw
+	expectedUpdatedAt, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Update contact (verifies ownership via user_id)
 	contact, err := h.queries.UpdateContact(ctx, database.UpdateContactParams{
-		ID:       int32(contactID),
-		Name:     req.Name,
-		Email:    sql.NullString{String: req.Email, Valid: req.Email != ""},
-		Phone:    sql.NullString{String: req.Phone, Valid: req.Phone != ""},
-		Linkedin: sql.NullString{String: req.Linkedin, Valid: req.Linkedin != ""},
-		UserID:   userID,
+		ID:                int32(contactID),
+		Name:              req.Name,
+		Email:             sql.NullString{String: req.Email, Valid: req.Email != ""},
+		Phone:             sql.NullString{String: req.Phone, Valid: req.Phone != ""},
+		Linkedin:          sql.NullString{String: req.Linkedin, Valid: req.Linkedin != ""},
+		KeepRole:          !req.Role.Set,
+		Role:              sql.NullString{String: req.Role.Value, Valid: req.Role.Value != ""},
+		UserID:            userID,
+		ExpectedUpdatedAt: expectedUpdatedAt,
 	})
-	if err != nil {
-		handleDatabaseError(c, err, "Contact")
+	if handleConditionalUpdateError(c, err, expectedUpdatedAt, "Contact", func() error {
+		_, err := h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+			ID:     int32(contactID),
+			UserID: userID,
+		})
+		return err
+	}) {
 		return
 	}
 
+	setETag(c, contact.UpdatedAt)
 	c.JSON(http.StatusOK, contact)
 }
 
@@ -180,25 +371,62 @@ func (h *ContactHandler) DeleteContact(c *gin.Context) {
 		return
 	}
 
-	// Check if contact exists and belongs to user
-	_, err = h.queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
-		ID:     int32(contactID),
-		UserID: userID,
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var contact database.Contact
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if contact exists and belongs to user
+		var err error
+		contact, err = qtx.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+			ID:     int32(contactID),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Delete contact (verifies ownership via user_id)
+		return qtx.DeleteContact(ctx, database.DeleteContactParams{
+			ID:     int32(contactID),
+			UserID: userID,
+		})
 	})
 	if handleDatabaseError(c, err, "Contact") {
 		return
 	}
 
-	// Delete contact (verifies ownership via user_id)
-	err = h.queries.DeleteContact(ctx, database.DeleteContactParams{
-		ID:     int32(contactID),
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Contact deleted successfully",
+		"id":      contactID,
+		"deleted": contact,
+	})
+}
+
+// filterOwnedContactIDs splits ids into the contacts the user owns and the rest (missing or another user's),
+// both in request order, with one ownership query
+// Bulk endpoints that attach contacts use it so a user can't link someone else's contact
+func filterOwnedContactIDs(ctx context.Context, queries *database.Queries, userID int32, ids []int32) (owned, rejected []int32, err error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return []int32{}, []int32{}, nil
+	}
+
+	ownedIDs, err := queries.GetOwnedContactIDsByUserID(ctx, database.GetOwnedContactIDsByUserIDParams{
+		Ids:    ids,
 		UserID: userID,
 	})
 	if err != nil {
-		handleDatabaseError(c, err, "Contact")
-		return
+		return nil, nil, err
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Contact deleted successfully"})
+	isOwned := make(map[int32]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		isOwned[id] = true
+	}
+	owned = []int32{}
+	for _, id := range ids {
+		if isOwned[id] {
+			owned = append(owned, id)
+		}
+	}
+	return owned, skippedIDs(ids, owned), nil
 }
-
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// contactExportBatchSize is how many contacts an export reads per query,
+// so memory stays bounded however many contacts the user has
+const contactExportBatchSize = 500
+
+// ContactExport is one contact in a JSON contacts export (a flat record, unlike the API's nullable fields)
+type ContactExport struct {
+	ID        int32      `json:"id"`
+	Name      string     `json:"name"`
+	Email     *string    `json:"email"`
+	Phone     *string    `json:"phone"`
+	Linkedin  *string    `json:"linkedin"`
+	Role      *string    `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// contactExportWriter writes a contacts export in one file format
+type contactExportWriter interface {
+	WriteContact(contact database.Contact) error
+	Flush() error // pushes buffered contacts to the underlying writer
+	Close() error // completes the file and flushes it
+}
+
+// csvContactWriter writes contacts as CSV rows under a header row
+type csvContactWriter struct {
+	w *csv.Writer
+}
+
+// newCSVContactWriter returns a CSV export writer; the header row is written (buffered) right away
+func newCSVContactWriter(w io.Writer) *csvContactWriter {
+	cw := &csvContactWriter{w: csv.NewWriter(w)}
+	_ = cw.w.Write([]string{"id", "name", "email", "phone", "linkedin", "role", "created_at"}) // errors surface on Flush
+	return cw
+}
+
+// csvFormulaPrefixes are the first characters that make spreadsheet apps read a cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvSafeText neutralizes a user-entered value that a spreadsheet would run as a formula (CSV injection)
+// by prefixing it with a single quote, which spreadsheets show as text
+func csvSafeText(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (cw *csvContactWriter) WriteContact(contact database.Contact) error {
+	createdAt := ""
+	if contact.CreatedAt.Valid {
+		createdAt = contact.CreatedAt.Time.Format(time.RFC3339)
+	}
+	return cw.w.Write([]string{
+		strconv.Itoa(int(contact.ID)),
+		csvSafeText(contact.Name),
+		csvSafeText(contact.Email.String),
+		csvSafeText(contact.Phone.String),
+		csvSafeText(contact.Linkedin.String),
+		csvSafeText(contact.Role.String),
+		createdAt,
+	})
+}
+
+func (cw *csvContactWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvContactWriter) Close() error {
+	return cw.Flush()
+}
+
+// jsonContactWriter writes contacts as one JSON array of ContactExport records
+type jsonContactWriter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	count int
+}
+
+// newJSONContactWriter returns a JSON export writer
+func newJSONContactWriter(w io.Writer) *jsonContactWriter {
+	return &jsonContactWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+func (jw *jsonContactWriter) WriteContact(contact database.Contact) error {
+	separator := ","
+	if jw.count == 0 {
+		separator = "["
+	}
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	jw.count++
+
+	export := ContactExport{
+		ID:       contact.ID,
+		Name:     contact.Name,
+		Email:    nullStringPtr(contact.Email),
+		Phone:    nullStringPtr(contact.Phone),
+		Linkedin: nullStringPtr(contact.Linkedin),
+		Role:     nullStringPtr(contact.Role),
+	}
+	if contact.CreatedAt.Valid {
+		export.CreatedAt = &contact.CreatedAt.Time
+	}
+	return jw.enc.Encode(export)
+}
+
+func (jw *jsonContactWriter) Flush() error {
+	return nil // the encoder writes through
+}
+
+func (jw *jsonContactWriter) Close() error {
+	end := "]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
+
+// ExportContacts handles GET /api/contacts/export
+// Streams all of the authenticated user's contacts as a file download, oldest first
+// Supports ?format=csv (default) or ?format=json
+// Contacts are read and sent in batches; if a later batch fails the download is cut short
+// (a JSON export then lacks its closing bracket) since the 200 status has already been sent
+func (h *ContactHandler) ExportContacts(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	var contentType string
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	case "json":
+		contentType = "application/json; charset=utf-8"
+	default:
+		sendBadRequest(c, "Invalid format", "format must be one of: csv, json")
+		return
+	}
+
+	ctx := c.Request.Context()
+	nextBatch := func(afterID int32) ([]database.Contact, error) {
+		return h.queries.GetContactsByUserIDAfterID(ctx, database.GetContactsByUserIDAfterIDParams{
+			UserID:    userID,
+			AfterID:   afterID,
+			BatchSize: contactExportBatchSize,
+		})
+	}
+
+	// Read the first batch before sending anything, so a failure can still be reported as a 500
+	batch, err := nextBatch(0)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch contacts", err)
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="contacts-`+time.Now().UTC().Format("2006-01-02")+"."+format+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	var out contactExportWriter
+	if format == "csv" {
+		out = newCSVContactWriter(c.Writer)
+	} else {
+		out = newJSONContactWriter(c.Writer)
+	}
+
+	for {
+		for _, contact := range batch {
+			if err := out.WriteContact(contact); err != nil {
+				log.Printf("ERROR contacts export: failed to write contact %d - %v", contact.ID, err)
+				return
+			}
+		}
+		if err := out.Flush(); err != nil {
+			log.Printf("ERROR contacts export: failed to write response - %v", err)
+			return
+		}
+		c.Writer.Flush()
+
+		if len(batch) < contactExportBatchSize {
+			break
+		}
+		batch, err = nextBatch(batch[len(batch)-1].ID)
+		if err != nil {
+			log.Printf("ERROR contacts export: failed to fetch contacts for user %d - %v", userID, err)
+			return
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		log.Printf("ERROR contacts export: failed to write response - %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestContactExportWriters tests the CSV and JSON export formats without a database
+func TestContactExportWriters(t *testing.T) {
+	contacts := []database.Contact{
+		{ID: 1, Name: "Ada, Recruiter", Email: sql.NullString{String: "ada@example.com", Valid: true}},
+		{ID: 2, Name: "Grace", Phone: sql.NullString{String: "+1 555 0100", Valid: true}},
+		{ID: 3, Name: `=HYPERLINK("http://evil.example","click")`, Role: sql.NullString{String: "@SUM(A1:A2)", Valid: true}},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newCSVContactWriter(&buf)
+		for _, contact := range contacts {
+			if err := w.WriteContact(contact); err != nil {
+				t.Fatalf("WriteContact failed: %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+		if len(records) != 4 {
+			t.Fatalf("Expected a header and 3 rows, got %d records", len(records))
+		}
+		if strings.Join(records[0], ",") != "id,name,email,phone,linkedin,role,created_at" {
+			t.Errorf("Unexpected header: %v", records[0])
+		}
+		if records[1][1] != "Ada, Recruiter" || records[1][2] != "ada@example.com" {
+			t.Errorf("Unexpected rows: %v", records[1:])
+		}
+		// Values a spreadsheet would run as formulas are quoted
+		if records[2][3] != "'+1 555 0100" || records[3][1] != `'=HYPERLINK("http://evil.example","click")` || records[3][5] != "'@SUM(A1:A2)" {
+			t.Errorf("Expected formula-like values to be prefixed with a quote, got %v", records[2:])
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newJSONContactWriter(&buf)
+		for _, contact := range contacts {
+			if err := w.WriteContact(contact); err != nil {
+				t.Fatalf("WriteContact failed: %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		var exported []ContactExport
+		if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+			t.Fatalf("Failed to parse JSON: %v (%s)", err, buf.String())
+		}
+		if len(exported) != 3 || exported[0].Email == nil || *exported[0].Email != "ada@example.com" || exported[1].Email != nil {
+			t.Errorf("Unexpected export: %+v", exported)
+		}
+	})
+
+	t.Run("Empty JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := newJSONContactWriter(&buf).Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if strings.TrimSpace(buf.String()) != "[]" {
+			t.Errorf("Expected an empty array, got %q", buf.String())
+		}
+	})
+}
+
+// TestExportContacts tests GET /api/contacts/export (only the current user's contacts are exported)
+func TestExportContacts(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-export@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-contacts-export-other@example.com")
+	defer otherCleanup()
+
+	for _, params := range []database.CreateContactParams{
+		{Name: "Export Contact", Email: sql.NullString{String: "export@example.com", Valid: true}, UserID: testUser.ID},
+		{Name: "Other Contact", UserID: otherUser.ID},
+	} {
+		if _, err := queries.CreateContact(ctx, params); err != nil {
+			t.Fatalf("Failed to create test contact: %v", err)
+		}
+	}
+
+	export := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/contacts/export"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		w := export("")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+			t.Errorf("Expected a CSV content type, got %q", w.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(w.Header().Get("Content-Disposition"), "attachment") {
+			t.Errorf("Expected an attachment, got %q", w.Header().Get("Content-Disposition"))
+		}
+		records, err := csv.NewReader(w.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+		if len(records) != 2 || records[1][1] != "Export Contact" {
+			t.Errorf("Expected a header and the user's contact, got %v", records)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		w := export("?format=json")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var exported []ContactExport
+		if err := json.Unmarshal(w.Body.Bytes(), &exported); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(exported) != 1 || exported[0].Name != "Export Contact" {
+			t.Errorf("Expected only the user's contact, got %+v", exported)
+		}
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		if w := export("?format=xml"); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
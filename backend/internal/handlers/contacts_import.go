@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/quotedprintable"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
+)
+
+const (
+	// MaxVCardImportSize is the largest .vcf file POST /api/contacts/import accepts (1 MB)
+	MaxVCardImportSize = 1 << 20
+	// vCardFormField is the multipart form field holding the .vcf file
+	vCardFormField = "file"
+)
+
+// Outcomes of one vCard in a contacts import
+const (
+	contactImportCreated  = "created"
+	contactImportExisting = "existing" // an existing contact has the same email
+	contactImportFailed   = "failed"
+)
+
+// vCard is the part of a vCard that becomes a contact
+type vCard struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+// errVCardUnterminated is returned for a file that ends inside a BEGIN:VCARD block
+var errVCardUnterminated = errors.New("vCard is missing END:VCARD")
+
+// vCardLines splits a vCard file into logical lines: folded lines (continued with a leading
+// space or tab) are joined, and so are quoted-printable soft line breaks (a trailing "=")
+func vCardLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxVCardImportSize)
+	softBreak := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case len(lines) > 0 && softBreak:
+			lines[len(lines)-1] = strings.TrimSuffix(lines[len(lines)-1], "=") + line
+		case len(lines) > 0 && line != "" && (line[0] == ' ' || line[0] == '\t'):
+			lines[len(lines)-1] += line[1:]
+		case strings.TrimSpace(line) == "":
+			continue
+		default:
+			lines = append(lines, line)
+		}
+		last := lines[len(lines)-1]
+		params, _, _ := strings.Cut(last, ":")
+		softBreak = strings.HasSuffix(last, "=") && strings.Contains(strings.ToUpper(params), "QUOTED-PRINTABLE")
+	}
+	return lines
+}
+
+// splitVCardValue splits a value on sep, ignoring backslash-escaped separators; parts stay escaped
+func splitVCardValue(value string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// vCardUnescaper undoes vCard text escaping (\n, \, and \;)
+var vCardUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";")
+
+// parseVCardLine splits a content line like "item1.TEL;TYPE=cell:+1 555 0100" into its
+// property name without group ("TEL"), its upper-cased parameters and its raw value
+func parseVCardLine(line string) (name, params, value string) {
+	// The value starts at the first colon outside a quoted parameter value
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				name, params, _ = strings.Cut(line[:i], ";")
+				if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+					name = name[dot+1:]
+				}
+				return strings.ToUpper(strings.TrimSpace(name)), strings.ToUpper(params), line[i+1:]
+			}
+		}
+	}
+	return "", "", ""
+}
+
+// decodeVCardValue decodes a quoted-printable value (vCard 2.1) and keeps only valid UTF-8
+func decodeVCardValue(params, value string) string {
+	if strings.Contains(params, "QUOTED-PRINTABLE") {
+		if decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(value))); err == nil {
+			value = string(decoded)
+		}
+	}
+	return strings.ToValidUTF8(value, "")
+}
+
+// parseVCards parses every top-level vCard of a .vcf file (versions 2.1, 3.0 and 4.0)
+// The name is FN, or the parts of N when FN is missing; the first EMAIL and TEL are kept
+func parseVCards(data []byte) ([]vCard, error) {
+	var cards []vCard
+	var card vCard
+	var structuredName string
+	depth := 0
+	for _, line := range vCardLines(data) {
+		name, params, value := parseVCardLine(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(strings.TrimSpace(value), "VCARD"):
+			depth++
+			if depth == 1 {
+				card, structuredName = vCard{}, ""
+			}
+			continue
+		case name == "END" && strings.EqualFold(strings.TrimSpace(value), "VCARD"):
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				if card.Name == "" {
+					card.Name = structuredName
+				}
+				cards = append(cards, card)
+			}
+			continue
+		case depth != 1:
+			continue // outside a vCard, or inside a nested one (e.g. an AGENT)
+		}
+
+		value = decodeVCardValue(params, value)
+		switch name {
+		case "FN":
+			card.Name = strings.TrimSpace(vCardUnescaper.Replace(value))
+		case "N":
+			// Family;Given;Additional;Prefix;Suffix, written as "Prefix Given Additional Family Suffix"
+			parts := splitVCardValue(value, ';')
+			for len(parts) < 5 {
+				parts = append(parts, "")
+			}
+			var words []string
+			for _, i := range []int{3, 1, 2, 0, 4} {
+				if part := strings.TrimSpace(vCardUnescaper.Replace(parts[i])); part != "" {
+					words = append(words, part)
+				}
+			}
+			structuredName = strings.Join(words, " ")
+		case "EMAIL":
+			if card.Email == "" {
+				card.Email = strings.TrimSpace(strings.TrimPrefix(vCardUnescaper.Replace(value), "mailto:"))
+			}
+		case "TEL":
+			if card.Phone == "" {
+				card.Phone = strings.TrimSpace(strings.TrimPrefix(vCardUnescaper.Replace(value), "tel:"))
+			}
+		}
+	}
+	if depth > 0 {
+		return nil, errVCardUnterminated
+	}
+	return cards, nil
+}
+
+// ContactImportResult is the outcome of one vCard in POST /api/contacts/import
+type ContactImportResult struct {
+	Index     int               `json:"index"` // position of the vCard in the file, from 0
+	Name      string            `json:"name"`
+	Status    string            `json:"status"`           // created, existing or failed
+	ContactID *int32            `json:"contact_id"`       // null when the vCard failed
+	Errors    map[string]string `json:"errors,omitempty"` // why the vCard failed, by field
+}
+
+// ContactImportResponse summarizes a contacts import
+type ContactImportResponse struct {
+	Created  int                   `json:"created"`
+	Existing int                   `json:"existing"`
+	Failed   int                   `json:"failed"`
+	Results  []ContactImportResult `json:"results"`
+}
+
+// readVCardUpload reads the .vcf file in the "file" form field of a multipart request
+// Sends a 400 (or 413 for an oversized file) response and returns false if the upload is invalid
+func readVCardUpload(c *gin.Context) ([]byte, bool) {
+	// Leave room for the multipart framing around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxVCardImportSize+1<<20)
+
+	fileHeader, err := c.FormFile(vCardFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sendVCardTooLarge(c)
+			return nil, false
+		}
+		sendBadRequest(c, "Invalid upload", "Send the .vcf file as multipart/form-data in the \""+vCardFormField+"\" field")
+		return nil, false
+	}
+	if fileHeader.Size > MaxVCardImportSize {
+		sendVCardTooLarge(c)
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		sendInternalError(c, "Failed to read upload", err)
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxVCardImportSize))
+	if err != nil {
+		sendInternalError(c, "Failed to read upload", err)
+		return nil, false
+	}
+	if !utf8.Valid(data) {
+		sendBadRequest(c, "Invalid vCard file", "The file must be encoded as UTF-8")
+		return nil, false
+	}
+	return data, true
+}
+
+// sendVCardTooLarge sends a 413 Request Entity Too Large error for an oversized .vcf file
+func sendVCardTooLarge(c *gin.Context) {
+	sendError(c, http.StatusRequestEntityTooLarge, "File too large", fmt.Sprintf("vCard files can be at most %d MB", MaxVCardImportSize>>20))
+}
+
+// ImportContacts handles POST /api/contacts/import
+// Creates a contact from every vCard in a .vcf file sent as multipart/form-data in the "file" field
+// Each vCard is validated like POST /api/contacts and saved in its own transaction, so one bad entry
+// doesn't stop the others; vCards whose email matches an existing contact reuse it (see getOrCreateContact)
+// With ?dry_run=true nothing is saved: the file is imported in one transaction that is rolled back, and
+// vCards that would be created have a null contact_id
+// Returns a summary with one result per vCard, in file order
+func (h *ContactHandler) ImportContacts(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	data, ok := readVCardUpload(c)
+	if !ok {
+		return
+	}
+
+	cards, err := parseVCards(data)
+	if err != nil {
+		sendBadRequest(c, "Invalid vCard file", err.Error())
+		return
+	}
+	if len(cards) == 0 {
+		sendBadRequest(c, "Invalid vCard file", "The file contains no BEGIN:VCARD ... END:VCARD entries")
+		return
+	}
+	if len(cards) > MaxImportRecords {
+		sendBadRequest(c, "Too many contacts", fmt.Sprintf("A vCard file can hold at most %d contacts", MaxImportRecords))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if c.Query("dry_run") != "true" {
+		response := importVCards(c, cards, func(req CreateContactRequest) (contact database.Contact, created bool, err error) {
+			err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+				var err error
+				contact, created, err = getOrCreateContact(ctx, qtx, userID, req)
+				return err
+			})
+			return contact, created, err
+		})
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// One transaction for the whole file, so vCards repeating an email match each other like they would on import
+	var response ContactImportResponse
+	err = withDryRunTx(ctx, h.db, h.queries, true, func(qtx *database.Queries) error {
+		response = importVCards(c, cards, func(req CreateContactRequest) (database.Contact, bool, error) {
+			return getOrCreateContact(ctx, qtx, userID, req)
+		})
+		return nil
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to preview import", err)
+		return
+	}
+	for i := range response.Results {
+		if response.Results[i].Status == contactImportCreated {
+			response.Results[i].ContactID = nil
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// importVCards validates each vCard like POST /api/contacts and passes the valid ones to save
+// Returns the import summary; save errors are logged and reported as failed vCards
+func importVCards(c *gin.Context, cards []vCard, save func(req CreateContactRequest) (database.Contact, bool, error)) ContactImportResponse {
+	locale := requestLocale(c)
+
+	response := ContactImportResponse{Results: make([]ContactImportResult, len(cards))}
+	for i, card := range cards {
+		result := ContactImportResult{Index: i, Name: card.Name, Status: contactImportFailed}
+		req := CreateContactRequest{Name: card.Name, Email: card.Email, Phone: card.Phone}
+
+		if err := binding.Validator.ValidateStruct(req); err != nil {
+			result.Errors = map[string]string{}
+			var validationErrors validator.ValidationErrors
+			if errors.As(err, &validationErrors) {
+				for _, fieldError := range validationErrors {
+					fieldName := strings.ToLower(fieldError.Field()[:1]) + fieldError.Field()[1:]
+					result.Errors[fieldName] = fieldErrorMessage(locale, fieldName, fieldError)
+				}
+			} else {
+				result.Errors["general"] = err.Error()
+			}
+		} else if contact, created, err := save(req); err != nil {
+			log.Printf("ERROR request_id=%s: failed to import vCard %d: %v", c.GetString(middleware.RequestIDKey), i, err)
+			result.Errors = map[string]string{"general": "Failed to save contact"}
+		} else {
+			result.ContactID = &contact.ID
+			result.Status = contactImportExisting
+			if created {
+				result.Status = contactImportCreated
+			}
+		}
+
+		switch result.Status {
+		case contactImportCreated:
+			response.Created++
+		case contactImportExisting:
+			response.Existing++
+		default:
+			response.Failed++
+		}
+		response.Results[i] = result
+	}
+	return response
+}
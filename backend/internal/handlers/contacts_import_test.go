@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseVCards tests parsing multi-contact .vcf files across vCard versions
+func TestParseVCards(t *testing.T) {
+	data := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Smith;Alice;;Dr.;\r\n" +
+		"FN:Alice Smith\\, PhD\r\n" +
+		"item1.EMAIL;TYPE=INTERNET,WORK:alice@acme.com\r\n" +
+		"EMAIL;TYPE=HOME:alice@home.example\r\n" +
+		"TEL;TYPE=\"cell,voice\":+1 555 01\r\n" +
+		" 00 0100\r\n" +
+		"END:VCARD\r\n" +
+		"\r\n" +
+		"BEGIN:VCARD\n" +
+		"VERSION:4.0\n" +
+		"N:Jones;Bob;;;\n" +
+		"TEL;VALUE=uri:tel:+44-20-7946-0958\n" +
+		"BEGIN:VCARD\n" +
+		"FN:Nested Agent\n" +
+		"END:VCARD\n" +
+		"END:VCARD\n" +
+		"BEGIN:VCARD\n" +
+		"VERSION:2.1\n" +
+		"FN;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:Ren=C3=A9e =\n" +
+		"Dupont\n" +
+		"END:VCARD\n"
+
+	cards, err := parseVCards([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []vCard{
+		{Name: "Alice Smith, PhD", Email: "alice@acme.com", Phone: "+1 555 0100 0100"},
+		{Name: "Bob Jones", Phone: "+44-20-7946-0958"},
+		{Name: "Renée Dupont"},
+	}, cards)
+
+	_, err = parseVCards([]byte("BEGIN:VCARD\nFN:Cut Off\n"))
+	assert.ErrorIs(t, err, errVCardUnterminated)
+
+	cards, err = parseVCards([]byte("not a vcard"))
+	require.NoError(t, err)
+	assert.Empty(t, cards)
+}
+
+// TestImportContacts tests POST /api/contacts/import
+func TestImportContacts(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-import@example.com")
+	defer cleanup()
+
+	existing, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+		Name:   "Already Here",
+		Email:  sql.NullString{String: "known@acme.com", Valid: true},
+		UserID: testUser.ID,
+	})
+	require.NoError(t, err)
+
+	upload := func(content string, query ...string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "contacts.vcf")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest("POST", "/api/contacts/import"+strings.Join(query, ""), body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	file := "BEGIN:VCARD\nFN:New Recruiter\nEMAIL:new@acme.com\nTEL:+1 555 010 0100\nEND:VCARD\n" +
+		"BEGIN:VCARD\nFN:Known Person\nEMAIL:KNOWN@acme.com\nEND:VCARD\n" +
+		"BEGIN:VCARD\nFN:Bad Email\nEMAIL:not-an-email\nEND:VCARD\n" +
+		"BEGIN:VCARD\nEMAIL:noname@acme.com\nEND:VCARD\n"
+
+	// A dry run reports the same outcomes without saving anything
+	w := upload(file, "?dry_run=true")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response ContactImportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.Existing)
+	assert.Equal(t, 2, response.Failed)
+	require.Len(t, response.Results, 4)
+	assert.Nil(t, response.Results[0].ContactID)
+	assert.Equal(t, &existing.ID, response.Results[1].ContactID)
+	_, err = queries.GetContactByEmailAndUserID(context.Background(), database.GetContactByEmailAndUserIDParams{
+		Lower:  "new@acme.com",
+		UserID: testUser.ID,
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	w = upload(file)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	response = ContactImportResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.Existing)
+	assert.Equal(t, 2, response.Failed)
+	require.Len(t, response.Results, 4)
+
+	assert.Equal(t, contactImportCreated, response.Results[0].Status)
+	require.NotNil(t, response.Results[0].ContactID)
+	created, err := queries.GetContactByIDAndUserID(context.Background(), database.GetContactByIDAndUserIDParams{
+		ID:     *response.Results[0].ContactID,
+		UserID: testUser.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "New Recruiter", created.Name)
+	assert.Equal(t, "+1 555 010 0100", created.Phone.String)
+
+	assert.Equal(t, contactImportExisting, response.Results[1].Status)
+	assert.Equal(t, &existing.ID, response.Results[1].ContactID)
+	assert.Contains(t, response.Results[2].Errors, "email")
+	assert.Nil(t, response.Results[2].ContactID)
+	assert.Contains(t, response.Results[3].Errors, "name")
+
+	// Files without vCards or with an unterminated vCard are rejected
+	assert.Equal(t, http.StatusBadRequest, upload("hello").Code)
+	assert.Equal(t, http.StatusBadRequest, upload("BEGIN:VCARD\nFN:Cut Off\n").Code)
+}
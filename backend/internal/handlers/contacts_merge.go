@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// MergeContactRequest represents the JSON body for POST /api/contacts/:id/merge
+type MergeContactRequest struct {
+	Into int32 `json:"into" binding:"required,gt=0"` // ID of the contact that survives the merge
+}
+
+// errMergedAttachmentLimit aborts a merge whose contacts hold more than MaxAttachmentsPerContact files together
+var errMergedAttachmentLimit = errors.New("merged contact would exceed the attachment limit")
+
+// MergeContact handles POST /api/contacts/:id/merge
+// Merges a duplicate contact into another: applications linked to the contact in the path (as contact or referrer)
+// and its attachments are moved to the "into" contact, then the duplicate is deleted, all in one transaction
+// Both contacts must belong to the user; responds with the surviving contact, or 409 if the attachments of
+// both contacts together exceed MaxAttachmentsPerContact
+func (h *ContactHandler) MergeContact(c *gin.Context) {
+	sourceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid contact ID", "Contact ID must be a number")
+		return
+	}
+
+	// Parse JSON body
+	var req MergeContactRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+	if req.Into == int32(sourceID) {
+		sendBadRequest(c, "Invalid merge target", "A contact can't be merged into itself")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var target database.Contact
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Both contacts must exist and belong to the user
+		if _, err := qtx.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+			ID:     int32(sourceID),
+			UserID: userID,
+		}); err != nil {
+			return err
+		}
+		var err error
+		target, err = qtx.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{
+			ID:     req.Into,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.ReassignApplicationContactByUserID(ctx, database.ReassignApplicationContactByUserIDParams{
+			FromContactID: int32(sourceID),
+			ToContactID:   req.Into,
+			UserID:        userID,
+		}); err != nil {
+			return err
+		}
+
+		// Lock the user like UploadContactAttachment, so an upload can't slip past the limit during the merge
+		if _, err := qtx.LockUserByID(ctx, userID); err != nil {
+			return err
+		}
+		if MaxAttachmentsPerContact > 0 {
+			sourceCount, err := qtx.CountContactAttachmentsByContactID(ctx, int32(sourceID))
+			if err != nil {
+				return err
+			}
+			targetCount, err := qtx.CountContactAttachmentsByContactID(ctx, req.Into)
+			if err != nil {
+				return err
+			}
+			if sourceCount+targetCount > int64(MaxAttachmentsPerContact) {
+				return errMergedAttachmentLimit
+			}
+		}
+		if _, err := qtx.ReassignContactAttachmentsByUserID(ctx, database.ReassignContactAttachmentsByUserIDParams{
+			ToContactID:   req.Into,
+			FromContactID: int32(sourceID),
+			UserID:        userID,
+		}); err != nil {
+			return err
+		}
+
+		return qtx.DeleteContact(ctx, database.DeleteContactParams{
+			ID:     int32(sourceID),
+			UserID: userID,
+		})
+	})
+	if errors.Is(err, errMergedAttachmentLimit) {
+		sendAttachmentLimitReached(c)
+		return
+	}
+	if handleDatabaseError(c, err, "Contact") {
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
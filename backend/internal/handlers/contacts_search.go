@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// maxContactSearchLength bounds ?q= on GET /api/contacts/search
+const maxContactSearchLength = 255
+
+// likeEscaper escapes the LIKE wildcards (and the escape character itself) so user text matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns an ILIKE pattern matching values that contain text
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+// SearchContacts handles GET /api/contacts/search?q=
+// Returns the user's contacts whose name, email, phone, LinkedIn or role contains q (case-insensitive),
+// ordered by name, as a PaginatedResponse (?page= and ?limit=, parsed strictly)
+// An empty q or an invalid page or limit is a 400
+func (h *ContactHandler) SearchContacts(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		sendBadRequest(c, "Invalid search", "q is required")
+		return
+	}
+	if len(q) > maxContactSearchLength {
+		sendBadRequest(c, "Invalid search", "q must be at most 255 characters")
+		return
+	}
+	pattern := containsPattern(q)
+
+	params, err := ParseStrictPaginationParams(c)
+	if err != nil {
+		sendBadRequest(c, "Invalid pagination", err.Error())
+		return
+	}
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	ctx := c.Request.Context()
+
+	contacts, err := h.queries.SearchContactsByUserID(ctx, database.SearchContactsByUserIDParams{
+		UserID:    userID,
+		Pattern:   pattern,
+		RowLimit:  params.Limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to search contacts", err)
+		return
+	}
+
+	totalCount, err := h.queries.CountContactsMatchingByUserID(ctx, database.CountContactsMatchingByUserIDParams{
+		UserID:  userID,
+		Pattern: pattern,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count contacts", err)
+		return
+	}
+
+	data := make([]interface{}, len(contacts))
+	for i, contact := range contacts {
+		data[i] = contact
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
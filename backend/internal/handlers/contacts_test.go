@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 	"github.com/stretchr/testify/assert"
@@ -249,6 +251,7 @@ func TestUpdateContact(t *testing.T) {
 		Name:   "John Doe",
 		Email:  sql.NullString{String: "john@example.com", Valid: true},
 		UserID: testUser.ID,
+		Role:   sql.NullString{String: "recruiter", Valid: true},
 	})
 	require.NoError(t, err)
 	defer queries.DeleteContact(ctx, database.DeleteContactParams{
@@ -282,6 +285,23 @@ func TestUpdateContact(t *testing.T) {
 				assert.Equal(t, "john.updated@example.com", result.Email.String)
 				assert.True(t, result.Phone.Valid)
 				assert.Equal(t, "+9876543210", result.Phone.String)
+				// role was omitted, so it is kept
+				assert.Equal(t, "recruiter", result.Role.String)
+			},
+		},
+		{
+			name:      "Clear role with null",
+			contactID: strconv.Itoa(int(contact.ID)),
+			body: map[string]interface{}{
+				"name": "John Updated",
+				"role": nil,
+			},
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var result database.Contact
+				err := json.Unmarshal(w.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.False(t, result.Role.Valid)
 			},
 		},
 		{
@@ -381,8 +401,418 @@ func TestDeleteContact(t *testing.T) {
 					UserID: testUser.ID,
 				})
 				assert.Error(t, err) // Should not exist
+
+				// Response includes the deleted contact for undo
+				var response struct {
+					ID      int32            `json:"id"`
+					Deleted database.Contact `json:"deleted"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, contactID, response.ID)
+				assert.Equal(t, contactID, response.Deleted.ID)
+				assert.Equal(t, "John Doe", response.Deleted.Name)
 			}
 		})
 	}
 }
 
+// TestMergeContact tests POST /api/contacts/:id/merge
+func TestMergeContact(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user and another user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-merge@example.com")
+	defer cleanup()
+	otherUser, cleanupOther := createTestUser(t, queries, db, "test-contacts-merge-other@example.com")
+	defer cleanupOther()
+	ctx := context.Background()
+
+	createContact := func(userID int32, name string) database.Contact {
+		contact, err := queries.CreateContact(ctx, database.CreateContactParams{
+			Name:   name,
+			UserID: userID,
+		})
+		require.NoError(t, err)
+		return contact
+	}
+	source := createContact(testUser.ID, "Jane Duplicate")
+	target := createContact(testUser.ID, "Jane Doe")
+	foreign := createContact(otherUser.ID, "Someone Else")
+
+	linked := func(id int32) sql.NullInt32 { return sql.NullInt32{Int32: id, Valid: true} }
+	asContact, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+		Status:      "applied",
+		AppliedDate: time.Now(),
+		ContactID:   linked(source.ID),
+		UserID:      testUser.ID,
+	})
+	require.NoError(t, err)
+	asReferrer, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+		Status:              "applied",
+		AppliedDate:         time.Now(),
+		ContactID:           linked(target.ID),
+		UserID:              testUser.ID,
+		ReferredByContactID: linked(source.ID),
+	})
+	require.NoError(t, err)
+
+	attach := func(contactID int32, filename string) database.CreateContactAttachmentRow {
+		attachment, err := queries.CreateContactAttachment(ctx, database.CreateContactAttachmentParams{
+			ContactID:   contactID,
+			Filename:    filename,
+			ContentType: "image/png",
+			SizeBytes:   4,
+			Data:        []byte("card"),
+		})
+		require.NoError(t, err)
+		return attachment
+	}
+	sourceAttachment := attach(source.ID, "card.png")
+	attach(target.ID, "badge.png")
+
+	merge := func(id int32, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/contacts/"+strconv.Itoa(int(id))+"/merge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Self-merge, foreign target and missing body are rejected
+	assert.Equal(t, http.StatusBadRequest, merge(source.ID, `{"into": `+strconv.Itoa(int(source.ID))+`}`).Code)
+	assert.Equal(t, http.StatusNotFound, merge(source.ID, `{"into": `+strconv.Itoa(int(foreign.ID))+`}`).Code)
+	assert.Equal(t, http.StatusNotFound, merge(foreign.ID, `{"into": `+strconv.Itoa(int(target.ID))+`}`).Code)
+	assert.Equal(t, http.StatusBadRequest, merge(source.ID, `{}`).Code)
+
+	// Merging fails while both contacts' attachments together exceed the per-contact limit
+	defer func(maxAttachments int) { MaxAttachmentsPerContact = maxAttachments }(MaxAttachmentsPerContact)
+	MaxAttachmentsPerContact = 1
+	assert.Equal(t, http.StatusConflict, merge(source.ID, `{"into": `+strconv.Itoa(int(target.ID))+`}`).Code)
+	MaxAttachmentsPerContact = 2
+
+	w := merge(source.ID, `{"into": `+strconv.Itoa(int(target.ID))+`}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var survivor database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &survivor))
+	assert.Equal(t, target.ID, survivor.ID)
+
+	// Links moved to the target and the duplicate is gone
+	app, err := queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{ID: asContact.ID, UserID: testUser.ID})
+	require.NoError(t, err)
+	assert.Equal(t, linked(target.ID), app.ContactID)
+	app, err = queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{ID: asReferrer.ID, UserID: testUser.ID})
+	require.NoError(t, err)
+	assert.Equal(t, linked(target.ID), app.ContactID)
+	assert.Equal(t, linked(target.ID), app.ReferredByContactID)
+	_, err = queries.GetContactByIDAndUserID(ctx, database.GetContactByIDAndUserIDParams{ID: source.ID, UserID: testUser.ID})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The duplicate's attachment now belongs to the target
+	attachments, err := queries.GetContactAttachmentsByContactIDAndUserID(ctx, database.GetContactAttachmentsByContactIDAndUserIDParams{
+		ContactID: target.ID,
+		UserID:    testUser.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, sourceAttachment.ID, attachments[0].ID)
+}
+
+// TestGetAllContacts_Sort tests GET /api/contacts?sort=
+func TestGetAllContacts_Sort(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-sort@example.com")
+	defer cleanup()
+
+	// Created in this order, so name order and newest-first order differ
+	for _, name := range []string{"Bob", "Alice", "Carol"} {
+		_, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+			Name:   name,
+			UserID: testUser.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test contact: %v", err)
+		}
+	}
+
+	getNames := func(query string) []string {
+		req := httptest.NewRequest("GET", "/api/contacts"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var contacts []database.Contact
+		if err := json.Unmarshal(w.Body.Bytes(), &contacts); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		names := make([]string, len(contacts))
+		for i, contact := range contacts {
+			names[i] = contact.Name
+		}
+		return names
+	}
+
+	// Test default order is by name
+	if names := getNames(""); len(names) != 3 || names[0] != "Alice" || names[2] != "Carol" {
+		t.Errorf("Expected name order, got %v", names)
+	}
+
+	// Test newest first
+	if names := getNames("?sort=created_at:desc"); len(names) != 3 || names[0] != "Carol" || names[2] != "Bob" {
+		t.Errorf("Expected newest first, got %v", names)
+	}
+
+	// Test invalid sort field
+	req := httptest.NewRequest("GET", "/api/contacts?sort=phone", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetAllContacts_EmailDomain(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-email-domain@example.com")
+	defer cleanup()
+
+	for name, email := range map[string]string{
+		"Alice": "alice@acme.com",
+		"Bob":   "BOB@ACME.COM",
+		"Carol": "carol@mail.acme.com",
+		"Dave":  "dave@other.com",
+		"Erin":  "",
+	} {
+		_, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+			Name:   name,
+			Email:  sql.NullString{String: email, Valid: email != ""},
+			UserID: testUser.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/contacts?email_domain=Acme.com", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var contacts []database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &contacts))
+	names := make([]string, len(contacts))
+	for i, contact := range contacts {
+		names[i] = contact.Name
+	}
+	assert.Equal(t, []string{"Alice", "Bob"}, names)
+
+	// Test invalid domains
+	for _, domain := range []string{"acme", "@acme.com", "acme..com", "-acme.com"} {
+		req := httptest.NewRequest("GET", "/api/contacts?email_domain="+domain, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, domain)
+	}
+}
+
+func TestGetAllContacts_Pagination(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-pagination@example.com")
+	defer cleanup()
+
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		_, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+			Name:   name,
+			UserID: testUser.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Paginated response when page/limit are given
+	w := get("/api/contacts?page=2&limit=2&sort=name:asc")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page struct {
+		Data []database.Contact `json:"data"`
+		Meta PaginationMeta     `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Carol", page.Data[0].Name)
+	assert.Equal(t, PaginationMeta{Page: 2, Limit: 2, TotalCount: 3, TotalPages: 2}, page.Meta)
+
+	// Bare array without page/limit (backward compatible)
+	w = get("/api/contacts")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var contacts []database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &contacts))
+	assert.Len(t, contacts, 3)
+}
+
+func TestFilterOwnedContactIDs(t *testing.T) {
+	_, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	owner, cleanupOwner := createTestUser(t, queries, db, "test-contacts-owned@example.com")
+	defer cleanupOwner()
+	other, cleanupOther := createTestUser(t, queries, db, "test-contacts-foreign@example.com")
+	defer cleanupOther()
+
+	createContact := func(userID int32, name string) int32 {
+		contact, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+			Name:   name,
+			UserID: userID,
+		})
+		require.NoError(t, err)
+		return contact.ID
+	}
+	mine1 := createContact(owner.ID, "Mine One")
+	mine2 := createContact(owner.ID, "Mine Two")
+	foreign := createContact(other.ID, "Not Mine")
+	missing := mine2 + 100000
+
+	// Mixed owned, foreign, missing and duplicate IDs keep request order
+	owned, rejected, err := filterOwnedContactIDs(context.Background(), queries, owner.ID, []int32{foreign, mine2, missing, mine1, mine2})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{mine2, mine1}, owned)
+	assert.Equal(t, []int32{foreign, missing}, rejected)
+
+	// Ownership is checked per user
+	owned, rejected, err = filterOwnedContactIDs(context.Background(), queries, other.ID, []int32{mine1, foreign})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{foreign}, owned)
+	assert.Equal(t, []int32{mine1}, rejected)
+
+	// No IDs
+	owned, rejected, err = filterOwnedContactIDs(context.Background(), queries, owner.ID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, owned)
+	assert.Empty(t, rejected)
+}
+
+func TestContactRole(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-role@example.com")
+	defer cleanup()
+
+	send := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Create with a role, and without one
+	w := send("POST", "/api/contacts", map[string]interface{}{"name": "Rita Recruiter", "role": "recruiter"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var recruiter database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &recruiter))
+	assert.Equal(t, "recruiter", recruiter.Role.String)
+
+	w = send("POST", "/api/contacts", map[string]interface{}{"name": "Pat Peer"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var peer database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &peer))
+	assert.False(t, peer.Role.Valid)
+
+	// Set the role on update
+	w = send("PUT", "/api/contacts/"+strconv.Itoa(int(peer.ID)), map[string]interface{}{"name": "Pat Peer", "role": "peer"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Filter by role
+	w = send("GET", "/api/contacts?role=recruiter", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var contacts []database.Contact
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &contacts))
+	require.Len(t, contacts, 1)
+	assert.Equal(t, recruiter.ID, contacts[0].ID)
+
+	// Unknown roles are rejected
+	assert.Equal(t, http.StatusBadRequest, send("POST", "/api/contacts", map[string]interface{}{"name": "X", "role": "ceo"}).Code)
+	assert.Equal(t, http.StatusBadRequest, send("GET", "/api/contacts?role=ceo", nil).Code)
+}
+
+func TestSearchContacts(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user and another user
+	testUser, cleanup := createTestUser(t, queries, db, "test-contacts-search@example.com")
+	defer cleanup()
+	otherUser, cleanupOther := createTestUser(t, queries, db, "test-contacts-search-other@example.com")
+	defer cleanupOther()
+
+	for _, params := range []database.CreateContactParams{
+		{Name: "Alice Smith", UserID: testUser.ID},
+		{Name: "Bob", Email: sql.NullString{String: "bob.SMITH@acme.com", Valid: true}, UserID: testUser.ID},
+		{Name: "Carol", Linkedin: sql.NullString{String: "https://linkedin.com/in/carolsmith", Valid: true}, UserID: testUser.ID},
+		{Name: "Dave", Phone: sql.NullString{String: "+1 555 0100", Valid: true}, UserID: testUser.ID},
+		{Name: "Erin 100%", UserID: testUser.ID},
+		{Name: "Other Smith", UserID: otherUser.ID},
+	} {
+		_, err := queries.CreateContact(context.Background(), params)
+		require.NoError(t, err)
+	}
+
+	search := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/contacts/search?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	names := func(w *httptest.ResponseRecorder) []string {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Data []database.Contact `json:"data"`
+			Meta PaginationMeta     `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		names := make([]string, len(response.Data))
+		for i, contact := range response.Data {
+			names[i] = contact.Name
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Alice Smith", "Bob", "Carol"}, names(search("q=smith")))
+	assert.Equal(t, []string{"Dave"}, names(search("q=555")))
+	assert.Equal(t, []string{"Bob"}, names(search("q=smith&page=2&limit=1")))
+
+	// Wildcards match literally
+	assert.Equal(t, []string{"Erin 100%"}, names(search("q=%25")))
+
+	assert.Equal(t, http.StatusBadRequest, search("q=").Code)
+	assert.Equal(t, http.StatusBadRequest, search("q=+++").Code)
+
+	// Invalid pagination is rejected instead of defaulting
+	assert.Equal(t, http.StatusBadRequest, search("q=x&limit=abc").Code)
+	assert.Equal(t, http.StatusBadRequest, search("q=x&page=0").Code)
+}
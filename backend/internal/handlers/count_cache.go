@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCountCacheTTL is how long a cached total count stays valid
+	DefaultCountCacheTTL = 15 * time.Second
+)
+
+// Resource names used as the first segment of count cache keys
+const (
+	countResourceApplications = "applications"
+	countResourceCompanies    = "companies"
+	countResourceJobs         = "jobs"
+)
+
+// countCacheEntry holds a cached count and when it stops being valid
+type countCacheEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// CountCache is a short-TTL in-memory cache for the total counts used in pagination meta.
+// Keys are scoped per resource, user and filter so one user's writes never affect another's counts.
+// A nil *CountCache (or a TTL <= 0) disables caching and always runs the live count.
+type CountCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]countCacheEntry
+	// generations counts Invalidate calls per resource and user, so a count fetched
+	// across an invalidation is not stored (keyed like countCacheKey with an empty filter)
+	generations map[string]uint64
+}
+
+// NewCountCache creates a count cache with the given TTL
+func NewCountCache(ttl time.Duration) *CountCache {
+	cc := &CountCache{
+		ttl:         ttl,
+		entries:     make(map[string]countCacheEntry),
+		generations: make(map[string]uint64),
+	}
+	if ttl > 0 {
+		cc.cleanup(time.Minute)
+	}
+	return cc
+}
+
+// countCacheKey builds the cache key for a resource, user and optional filter
+// Example: "applications:42:status=applied"
+func countCacheKey(resource string, userID int32, filter string) string {
+	return fmt.Sprintf("%s:%d:%s", resource, userID, filter)
+}
+
+// countCacheScope returns the resource and user part of a cache key (its key with an empty filter)
+func countCacheScope(key string) string {
+	resourceEnd := strings.IndexByte(key, ':')
+	if resourceEnd < 0 {
+		return key
+	}
+	userEnd := strings.IndexByte(key[resourceEnd+1:], ':')
+	if userEnd < 0 {
+		return key
+	}
+	return key[:resourceEnd+1+userEnd+1]
+}
+
+// Count returns the cached count for key, or calls fetch on a miss and caches the result
+// Errors from fetch are returned as-is and never cached
+func (cc *CountCache) Count(key string, fetch func() (int64, error)) (int64, error) {
+	if cc == nil || cc.ttl <= 0 {
+		return fetch()
+	}
+
+	scope := countCacheScope(key)
+
+	cc.mu.RLock()
+	entry, exists := cc.entries[key]
+	generation := cc.generations[scope]
+	cc.mu.RUnlock()
+
+	if exists && time.Now().Before(entry.expiresAt) {
+		return entry.count, nil
+	}
+
+	// Cache miss (or expired) - fall back to the live count
+	count, err := fetch()
+	if err != nil {
+		return 0, err
+	}
+
+	cc.mu.Lock()
+	// A write invalidated this resource while fetch ran, so the count may already be stale
+	if cc.generations[scope] == generation {
+		cc.entries[key] = countCacheEntry{
+			count:     count,
+			expiresAt: time.Now().Add(cc.ttl),
+		}
+	}
+	cc.mu.Unlock()
+
+	return count, nil
+}
+
+// Invalidate removes all cached counts of a resource for a user (every filter variant)
+// Called after create/delete so the next paginated request sees the live count
+func (cc *CountCache) Invalidate(resource string, userID int32) {
+	if cc == nil {
+		return
+	}
+
+	prefix := countCacheKey(resource, userID, "")
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.generations[prefix]++
+	for key := range cc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(cc.entries, key)
+		}
+	}
+}
+
+// cleanup removes expired entries periodically to prevent memory leaks
+func (cc *CountCache) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			now := time.Now()
+			cc.mu.Lock()
+			for key, entry := range cc.entries {
+				if now.After(entry.expiresAt) {
+					delete(cc.entries, key)
+				}
+			}
+			cc.mu.Unlock()
+		}
+	}()
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCountCache tests caching, per-user invalidation and error passthrough of CountCache
+func TestCountCache(t *testing.T) {
+	cache := NewCountCache(time.Minute)
+
+	calls := 0
+	fetch := func() (int64, error) {
+		calls++
+		return 5, nil
+	}
+
+	key := countCacheKey(countResourceApplications, 1, "status=applied")
+
+	// First call is a miss and hits the live count
+	count, err := cache.Count(key, fetch)
+	if err != nil || count != 5 {
+		t.Fatalf("Expected count 5, got %d (err: %v)", count, err)
+	}
+
+	// Second call is served from the cache
+	if _, err := cache.Count(key, fetch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 live count, got %d", calls)
+	}
+
+	// Invalidating another user must not affect this entry
+	cache.Invalidate(countResourceApplications, 2)
+	cache.Count(key, fetch)
+	if calls != 1 {
+		t.Errorf("Expected entry to survive another user's invalidation, got %d live counts", calls)
+	}
+
+	// Invalidating this user drops every filter variant
+	cache.Invalidate(countResourceApplications, 1)
+	cache.Count(key, fetch)
+	if calls != 2 {
+		t.Errorf("Expected live count after invalidation, got %d live counts", calls)
+	}
+
+	// Errors are returned and never cached
+	failing := func() (int64, error) { return 0, errors.New("db down") }
+	otherKey := countCacheKey(countResourceCompanies, 1, "")
+	if _, err := cache.Count(otherKey, failing); err == nil {
+		t.Error("Expected error from failing fetch")
+	}
+	if count, _ := cache.Count(otherKey, fetch); count != 5 {
+		t.Errorf("Expected failed fetch not to be cached, got %d", count)
+	}
+}
+
+// TestCountCache_Disabled tests that a nil cache or zero TTL always runs the live count
+func TestCountCache_Disabled(t *testing.T) {
+	calls := 0
+	fetch := func() (int64, error) {
+		calls++
+		return 3, nil
+	}
+
+	var nilCache *CountCache
+	nilCache.Count("k", fetch)
+	nilCache.Count("k", fetch)
+	nilCache.Invalidate(countResourceJobs, 1)
+
+	zeroTTL := NewCountCache(0)
+	zeroTTL.Count("k", fetch)
+	zeroTTL.Count("k", fetch)
+
+	if calls != 4 {
+		t.Errorf("Expected 4 live counts with caching disabled, got %d", calls)
+	}
+}
+
+// TestCountCache_InvalidateDuringFetch tests that a count fetched while the resource is invalidated is not cached
+func TestCountCache_InvalidateDuringFetch(t *testing.T) {
+	cache := NewCountCache(time.Minute)
+	key := countCacheKey(countResourceApplications, 1, "")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// This fetch reads the count before the concurrent write lands
+		cache.Count(key, func() (int64, error) {
+			close(started)
+			<-release
+			return 5, nil
+		})
+	}()
+
+	<-started
+	cache.Invalidate(countResourceApplications, 1)
+	close(release)
+	<-done
+
+	calls := 0
+	count, err := cache.Count(key, func() (int64, error) {
+		calls++
+		return 6, nil
+	})
+	if err != nil || count != 6 || calls != 1 {
+		t.Errorf("Expected a live count of 6 after the invalidation, got %d from %d live counts (err: %v)", count, calls, err)
+	}
+}
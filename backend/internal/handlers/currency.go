@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// baseCurrency is the currency offer stats convert salaries to (BASE_CURRENCY); empty disables conversion
+var baseCurrency string
+
+// currencyRates maps a currency code to how many units of baseCurrency one unit is worth (CURRENCY_RATES)
+var currencyRates = map[string]float64{}
+
+// SetCurrencyRates configures salary conversion for offer stats
+// base is an ISO 4217 code; rates is a comma-separated list of CODE=rate, where rate is
+// the value of one unit of CODE in base (e.g. base "USD", rates "EUR=1.08,GBP=1.27")
+// Stored salaries are never changed; they are only converted when stats are computed
+func SetCurrencyRates(base, rates string) error {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	if !isCurrencyCode(base) {
+		return fmt.Errorf("invalid base currency %q (expected a 3-letter code like USD)", base)
+	}
+
+	parsed := map[string]float64{base: 1}
+	for _, entry := range strings.Split(rates, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		code, rateStr, ok := strings.Cut(entry, "=")
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !ok || !isCurrencyCode(code) {
+			return fmt.Errorf("invalid currency rate %q (expected CODE=rate, e.g. EUR=1.08)", entry)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return fmt.Errorf("invalid rate for %s: %q must be a positive number", code, rateStr)
+		}
+		parsed[code] = rate
+	}
+
+	baseCurrency = base
+	currencyRates = parsed
+	return nil
+}
+
+// isCurrencyCode reports whether code looks like an upper-case ISO 4217 code
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// BaseCurrencyOfferStat is the offered salary range with every salary converted to the base currency
+type BaseCurrencyOfferStat struct {
+	Currency              string   `json:"currency"`
+	Offers                int64    `json:"offers"` // offers whose salary could be converted
+	MinSalary             int64    `json:"min_salary"`
+	MaxSalary             int64    `json:"max_salary"`
+	AvgSalary             float64  `json:"avg_salary"`
+	UnconvertedOffers     int64    `json:"unconverted_offers"`     // offers left out because their currency has no rate
+	UnconvertedCurrencies []string `json:"unconverted_currencies"` // currencies without a configured rate
+}
+
+// convertOfferStats combines per-currency salary stats into one range in the base currency
+// Returns nil when no base currency is configured; currencies without a rate are skipped and flagged
+func convertOfferStats(byCurrency []CurrencyOfferStat) *BaseCurrencyOfferStat {
+	if baseCurrency == "" {
+		return nil
+	}
+
+	stat := &BaseCurrencyOfferStat{Currency: baseCurrency, UnconvertedCurrencies: []string{}}
+	var total float64
+	for _, row := range byCurrency {
+		rate, ok := currencyRates[strings.ToUpper(row.Currency)]
+		if !ok {
+			stat.UnconvertedOffers += row.Offers
+			stat.UnconvertedCurrencies = append(stat.UnconvertedCurrencies, row.Currency)
+			continue
+		}
+		// Conversion is linear, so each currency's min/max/avg convert directly
+		minSalary := int64(math.Round(float64(row.MinSalary) * rate))
+		maxSalary := int64(math.Round(float64(row.MaxSalary) * rate))
+		if stat.Offers == 0 || minSalary < stat.MinSalary {
+			stat.MinSalary = minSalary
+		}
+		if maxSalary > stat.MaxSalary {
+			stat.MaxSalary = maxSalary
+		}
+		total += row.AvgSalary * rate * float64(row.Offers)
+		stat.Offers += row.Offers
+	}
+	if stat.Offers > 0 {
+		stat.AvgSalary = total / float64(stat.Offers)
+	}
+	sort.Strings(stat.UnconvertedCurrencies)
+	return stat
+}
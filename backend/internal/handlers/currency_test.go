@@ -0,0 +1,62 @@
+package handlers
+
+import "testing"
+
+// TestSetCurrencyRates tests parsing BASE_CURRENCY/CURRENCY_RATES
+func TestSetCurrencyRates(t *testing.T) {
+	defer func(base string, rates map[string]float64) { baseCurrency, currencyRates = base, rates }(baseCurrency, currencyRates)
+
+	if err := SetCurrencyRates("usd", " eur=1.08, GBP=1.27 "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if baseCurrency != "USD" || currencyRates["USD"] != 1 || currencyRates["EUR"] != 1.08 || currencyRates["GBP"] != 1.27 {
+		t.Errorf("Unexpected rates: base %q, %v", baseCurrency, currencyRates)
+	}
+
+	for _, tt := range []struct{ base, rates string }{
+		{"US", ""},
+		{"USD", "EUR"},
+		{"USD", "EUR=0"},
+		{"USD", "EUR=-1"},
+		{"USD", "EURO=1.08"},
+		{"USD", "EUR=abc"},
+	} {
+		if err := SetCurrencyRates(tt.base, tt.rates); err == nil {
+			t.Errorf("Expected an error for base %q, rates %q", tt.base, tt.rates)
+		}
+	}
+}
+
+// TestConvertOfferStats tests combining per-currency salary stats in the base currency
+func TestConvertOfferStats(t *testing.T) {
+	defer func(base string, rates map[string]float64) { baseCurrency, currencyRates = base, rates }(baseCurrency, currencyRates)
+
+	byCurrency := []CurrencyOfferStat{
+		{Currency: "USD", Offers: 2, MinSalary: 80000, MaxSalary: 100000, AvgSalary: 90000},
+		{Currency: "EUR", Offers: 1, MinSalary: 100000, MaxSalary: 100000, AvgSalary: 100000},
+		{Currency: "JPY", Offers: 3, MinSalary: 5000000, MaxSalary: 9000000, AvgSalary: 7000000},
+	}
+
+	// No base currency: nothing is converted
+	baseCurrency, currencyRates = "", map[string]float64{}
+	if stat := convertOfferStats(byCurrency); stat != nil {
+		t.Errorf("Expected no conversion without a base currency, got %+v", stat)
+	}
+
+	if err := SetCurrencyRates("USD", "EUR=1.1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	stat := convertOfferStats(byCurrency)
+	if stat == nil {
+		t.Fatal("Expected converted stats")
+	}
+	if stat.Currency != "USD" || stat.Offers != 3 || stat.MinSalary != 80000 || stat.MaxSalary != 110000 {
+		t.Errorf("Unexpected converted range: %+v", stat)
+	}
+	if want := (90000*2 + 110000) / 3.0; stat.AvgSalary != want {
+		t.Errorf("Expected average %v, got %v", want, stat.AvgSalary)
+	}
+	if stat.UnconvertedOffers != 3 || len(stat.UnconvertedCurrencies) != 1 || stat.UnconvertedCurrencies[0] != "JPY" {
+		t.Errorf("Expected JPY offers to be flagged as unconverted, got %+v", stat)
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// DashboardSectionSize is how many items the list sections of the dashboard hold
+const DashboardSectionSize = 5
+
+// DashboardHandler handles HTTP requests for the home screen dashboard
+type DashboardHandler struct {
+	queries *database.Queries
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(queries *database.Queries) *DashboardHandler {
+	return &DashboardHandler{
+		queries: queries,
+	}
+}
+
+// DashboardTotals holds the number of each resource the user has
+type DashboardTotals struct {
+	Applications int64 `json:"applications"`
+	Companies    int64 `json:"companies"`
+	Contacts     int64 `json:"contacts"`
+	Jobs         int64 `json:"jobs"`
+}
+
+// PeriodComparison compares a count in the current period with the previous one
+type PeriodComparison struct {
+	Current  int64 `json:"current"`
+	Previous int64 `json:"previous"`
+	Delta    int64 `json:"delta"` // current - previous (negative when activity dropped)
+}
+
+// newPeriodComparison builds a PeriodComparison from the two period counts
+func newPeriodComparison(current, previous int64) PeriodComparison {
+	return PeriodComparison{Current: current, Previous: previous, Delta: current - previous}
+}
+
+// DashboardMomentum compares this week's activity with last week's
+// Weeks are calendar weeks starting on Monday (UTC); the current week includes the days still to come
+type DashboardMomentum struct {
+	WeekStart    time.Time        `json:"week_start"`   // Monday of the current week
+	Applications PeriodComparison `json:"applications"` // by applied_date
+	Interviews   PeriodComparison `json:"interviews"`   // by scheduled_at
+}
+
+// DashboardResponse is the home screen payload
+// Every section is always present (empty lists/maps rather than null) so each can render on its own
+type DashboardResponse struct {
+	RecentApplications []database.Application `json:"recent_applications"` // most recently updated
+	Interviewing       []database.Application `json:"interviewing"`        // currently in the interview stage
+	DueActions         []Reminder             `json:"due_actions"`         // next actions due today or overdue
+	StatusCounts       map[string]int64       `json:"status_counts"`
+	Totals             DashboardTotals        `json:"totals"`
+	Momentum           DashboardMomentum      `json:"momentum"`
+}
+
+// GetDashboard handles GET /api/dashboard
+// Returns everything the home screen needs in a single response
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	today := todayUTC()
+
+	recent, err := h.queries.GetApplicationsByUserIDPaginated(ctx, database.GetApplicationsByUserIDPaginatedParams{
+		UserID: userID,
+		Limit:  DashboardSectionSize,
+		Offset: 0,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch recent applications", err)
+		return
+	}
+
+	interviewing, err := h.queries.GetApplicationsFilteredByUserID(ctx, database.GetApplicationsFilteredByUserIDParams{
+		UserID:   userID,
+		Status:   sql.NullString{String: "interview", Valid: true},
+		RowLimit: sql.NullInt32{Int32: DashboardSectionSize, Valid: true},
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch interviewing applications", err)
+		return
+	}
+
+	due, err := h.queries.GetApplicationsWithDueNextActionByUserID(ctx, database.GetApplicationsWithDueNextActionByUserIDParams{
+		UserID:        userID,
+		NextActionDue: sql.NullTime{Time: today, Valid: true},
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch due actions", err)
+		return
+	}
+
+	statusRows, err := h.queries.GetApplicationStatusCounts(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch status counts", err)
+		return
+	}
+	statusCounts := make(map[string]int64, len(statusRows))
+	for _, row := range statusRows {
+		statusCounts[row.Status] = row.Count
+	}
+
+	totals, err := h.queries.GetUserTotals(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch totals", err)
+		return
+	}
+
+	momentum, err := h.weeklyMomentum(ctx, userID, today)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch momentum", err)
+		return
+	}
+
+	// Ensure empty sections serialize as [] instead of null
+	if recent == nil {
+		recent = []database.Application{}
+	}
+	if interviewing == nil {
+		interviewing = []database.Application{}
+	}
+
+	c.JSON(http.StatusOK, DashboardResponse{
+		RecentApplications: recent,
+		Interviewing:       interviewing,
+		DueActions:         nextActionReminders(due, today),
+		StatusCounts:       statusCounts,
+		Totals: DashboardTotals{
+			Applications: totals.Applications,
+			Companies:    totals.Companies,
+			Contacts:     totals.Contacts,
+			Jobs:         totals.Jobs,
+		},
+		Momentum: momentum,
+	})
+}
+
+// startOfWeek returns the Monday (UTC midnight) of the week containing day
+func startOfWeek(day time.Time) time.Time {
+	day = truncateToDay(day)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// weeklyMomentum counts the user's applications and interviews in the week containing today and in the week before
+func (h *DashboardHandler) weeklyMomentum(ctx context.Context, userID int32, today time.Time) (DashboardMomentum, error) {
+	thisWeek := startOfWeek(today)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+	nextWeek := thisWeek.AddDate(0, 0, 7)
+
+	// count returns the applications and interviews in [from, to)
+	count := func(from, to time.Time) (applications, interviews int64, err error) {
+		applications, err = h.queries.CountApplicationsAppliedBetween(ctx, database.CountApplicationsAppliedBetweenParams{
+			UserID:   userID,
+			FromDate: from,
+			ToDate:   to,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		interviews, err = h.queries.CountInterviewsScheduledBetween(ctx, database.CountInterviewsScheduledBetweenParams{
+			UserID:   userID,
+			FromTime: from,
+			ToTime:   to,
+		})
+		return applications, interviews, err
+	}
+
+	currentApplications, currentInterviews, err := count(thisWeek, nextWeek)
+	if err != nil {
+		return DashboardMomentum{}, err
+	}
+	previousApplications, previousInterviews, err := count(lastWeek, thisWeek)
+	if err != nil {
+		return DashboardMomentum{}, err
+	}
+
+	return DashboardMomentum{
+		WeekStart:    thisWeek,
+		Applications: newPeriodComparison(currentApplications, previousApplications),
+		Interviews:   newPeriodComparison(currentInterviews, previousInterviews),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestGetDashboard tests GET /api/dashboard
+func TestGetDashboard(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-dashboard@example.com")
+	defer cleanup()
+
+	getDashboard := func() DashboardResponse {
+		req := httptest.NewRequest("GET", "/api/dashboard", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response DashboardResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	// Test empty dashboard: sections are present but empty
+	empty := getDashboard()
+	if empty.RecentApplications == nil || empty.Interviewing == nil || empty.DueActions == nil {
+		t.Errorf("Expected empty sections to be [], got %+v", empty)
+	}
+	if empty.Totals.Applications != 0 {
+		t.Errorf("Expected 0 applications, got %d", empty.Totals.Applications)
+	}
+
+	// Test dashboard with data
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+	createTestApplication(t, queries, testUser.ID, "interview", "")
+	createTestApplication(t, queries, testUser.ID, "interview", "")
+
+	dashboard := getDashboard()
+	if len(dashboard.RecentApplications) != 3 {
+		t.Errorf("Expected 3 recent applications, got %d", len(dashboard.RecentApplications))
+	}
+	if len(dashboard.Interviewing) != 2 {
+		t.Errorf("Expected 2 interviewing applications, got %d", len(dashboard.Interviewing))
+	}
+	if dashboard.StatusCounts["interview"] != 2 || dashboard.StatusCounts["applied"] != 1 {
+		t.Errorf("Unexpected status counts: %v", dashboard.StatusCounts)
+	}
+	if dashboard.Totals.Applications != 3 {
+		t.Errorf("Expected 3 applications in totals, got %d", dashboard.Totals.Applications)
+	}
+	if dashboard.Momentum.Applications != (PeriodComparison{Current: 3, Previous: 0, Delta: 3}) {
+		t.Errorf("Expected 3 applications this week and none last week, got %+v", dashboard.Momentum.Applications)
+	}
+
+	// Test momentum: an application applied and interviewed last week
+	lastWeek := startOfWeek(time.Now()).AddDate(0, 0, -3)
+	previous, err := queries.CreateApplication(context.Background(), database.CreateApplicationParams{
+		Status:      "interview",
+		AppliedDate: lastWeek,
+		UserID:      testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+	if _, err := queries.CreateInterview(context.Background(), database.CreateInterviewParams{
+		ApplicationID: previous.ID,
+		Title:         "Phone screen",
+		ScheduledAt:   sql.NullTime{Time: lastWeek.Add(10 * time.Hour), Valid: true},
+	}); err != nil {
+		t.Fatalf("Failed to create test interview: %v", err)
+	}
+
+	momentum := getDashboard().Momentum
+	if momentum.Applications != (PeriodComparison{Current: 3, Previous: 1, Delta: 2}) {
+		t.Errorf("Unexpected application momentum: %+v", momentum.Applications)
+	}
+	if momentum.Interviews != (PeriodComparison{Current: 0, Previous: 1, Delta: -1}) {
+		t.Errorf("Unexpected interview momentum: %+v", momentum.Interviews)
+	}
+	if !momentum.WeekStart.Equal(startOfWeek(time.Now())) {
+		t.Errorf("Expected week_start %s, got %s", startOfWeek(time.Now()), momentum.WeekStart)
+	}
+}
+
+// TestStartOfWeek tests that weeks start on Monday
+func TestStartOfWeek(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	for _, day := range []time.Time{
+		monday,
+		time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC), // Wednesday afternoon
+		time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), // Sunday night
+	} {
+		if got := startOfWeek(day); !got.Equal(monday) {
+			t.Errorf("startOfWeek(%s): expected %s, got %s", day, monday, got)
+		}
+	}
+	if got := startOfWeek(monday.AddDate(0, 0, 7)); !got.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("Expected the next Monday to start its own week, got %s", got)
+	}
+}
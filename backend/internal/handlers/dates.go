@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is the plain calendar date format used by DATE columns
+const dateLayout = "2006-01-02"
+
+// parseDate parses a date given either as YYYY-MM-DD or as an RFC 3339 timestamp
+// Timestamps are converted to UTC; a plain date is midnight UTC. DATE columns keep only the day,
+// so use truncateToDay before comparing a parsed value against other calendar dates
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(dateLayout, value); err == nil {
+		return date, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither YYYY-MM-DD nor RFC 3339", value)
+	}
+	return t.UTC(), nil
+}
+
+// truncateToDay returns midnight UTC of t's UTC calendar day
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sendDateError sends a 400 ValidationErrorResponse for a malformed date field
+// Uses the same shape as binding errors so clients can show it next to the field
+func sendDateError(c *gin.Context, field string) {
+	locale := requestLocale(c)
+	c.Header("Content-Language", locale)
+	c.JSON(http.StatusBadRequest, ValidationErrorResponse{
+		Error:   "Validation failed",
+		Message: localizedMessage(locale, "validation_failed", "", ""),
+		Fields:  map[string]string{field: localizedMessage(locale, "date", field, "")},
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestParseDate tests that parseDate accepts YYYY-MM-DD and RFC 3339 and normalizes to UTC
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectError bool
+	}{
+		{name: "Plain date", value: "2024-01-15", expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "RFC 3339 in UTC", value: "2024-01-15T14:00:00Z", expected: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)},
+		{name: "RFC 3339 with offset", value: "2024-01-15T23:30:00-02:00", expected: time.Date(2024, 1, 16, 1, 30, 0, 0, time.UTC)},
+		{name: "Day-first date", value: "15/01/2024", expectError: true},
+		{name: "Invalid day", value: "2024-02-30", expectError: true},
+		{name: "Empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := parseDate(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %v", tt.value, date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tt.value, err)
+			}
+			if !date.Equal(tt.expected) || date.Location() != time.UTC {
+				t.Errorf("Expected %v, got %v", tt.expected, date)
+			}
+		})
+	}
+
+	if got := truncateToDay(time.Date(2024, 1, 15, 23, 30, 0, 0, time.FixedZone("", -2*60*60))); !got.Equal(time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected truncateToDay to use the UTC day, got %v", got)
+	}
+}
+
+// TestSendDateError tests that a malformed date is reported as a localized field-level error
+func TestSendDateError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/", nil)
+	c.Request.Header.Set("Accept-Language", "es")
+
+	sendDateError(c, "applied_date")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	var response ValidationErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expected := "applied_date debe ser una fecha en formato AAAA-MM-DD o RFC 3339 (p. ej. 2024-01-15)"
+	if response.Fields["applied_date"] != expected {
+		t.Errorf("Expected field message %q, got %q", expected, response.Fields["applied_date"])
+	}
+	if w.Header().Get("Content-Language") != "es" {
+		t.Errorf("Expected Content-Language es, got %q", w.Header().Get("Content-Language"))
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RetryTransientDBErrors enables retrying a transaction once when it fails because the
+// database connection was lost (set from DB_RETRY_TRANSIENT in main.go)
+var RetryTransientDBErrors bool
+
+// transientRetryDelay is how long to wait before the single retry, giving the pool time to reconnect
+const transientRetryDelay = 100 * time.Millisecond
+
+// isConnectionError reports whether err means the database could not be reached or the
+// connection dropped mid-request, as opposed to an error in the query itself
+func isConnectionError(err error) bool {
+	// A cancelled request or statement timeout is not a lost connection (context errors also satisfy net.Error)
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Class 08 is "connection exception"; 57P01-57P03 are server shutdown / not accepting connections
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	return false
+}
+
+// shouldRetryTransient reports whether an operation that failed with err should be run once more
+// True only when RetryTransientDBErrors is set and err is a connection error; it waits
+// transientRetryDelay first and returns false if ctx is done meanwhile
+// The caller must only retry work that is safe to repeat (e.g. a transaction that was rolled back)
+func shouldRetryTransient(ctx context.Context, err error) bool {
+	if !RetryTransientDBErrors || !isConnectionError(err) {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(transientRetryDelay):
+		return true
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// TestIsConnectionError tests which database errors count as a lost connection
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Nil", nil, false},
+		{"No rows", sql.ErrNoRows, false},
+		{"Bad connection", driver.ErrBadConn, true},
+		{"Connection done", sql.ErrConnDone, true},
+		{"Wrapped connection refused", fmt.Errorf("query: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"Connection reset", syscall.ECONNRESET, true},
+		{"Connection exception class", &pq.Error{Code: "08006"}, true},
+		{"Admin shutdown", &pq.Error{Code: "57P01"}, true},
+		{"Unique violation", &pq.Error{Code: "23505"}, false},
+		{"Query cancelled", &pq.Error{Code: "57014"}, false},
+		{"Request timeout", context.DeadlineExceeded, false},
+		{"Request cancelled", context.Canceled, false},
+		{"Plain error", errors.New("something else"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConnectionError(tt.err); got != tt.expected {
+				t.Errorf("Expected %v for %v, got %v", tt.expected, tt.err, got)
+			}
+		})
+	}
+}
+
+// TestSendInternalError_ConnectionLost tests that connection errors become a sanitized 503
+func TestSendInternalError_ConnectionLost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	secret := "dial tcp 10.0.0.5:5432: connect: connection refused"
+	sendInternalError(c, "Failed to fetch applications", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New(secret)})
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "10.0.0.5") {
+		t.Errorf("Expected internal error text not to leak, got %s", w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Error != "Service temporarily unavailable" || response.Details != "" {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+}
+
+// TestShouldRetryTransient tests the retry-once option
+func TestShouldRetryTransient(t *testing.T) {
+	defer func(original bool) { RetryTransientDBErrors = original }(RetryTransientDBErrors)
+	ctx := context.Background()
+
+	RetryTransientDBErrors = false
+	if shouldRetryTransient(ctx, driver.ErrBadConn) {
+		t.Error("Expected no retry when the option is off")
+	}
+
+	RetryTransientDBErrors = true
+	if !shouldRetryTransient(ctx, driver.ErrBadConn) {
+		t.Error("Expected a retry for a connection error")
+	}
+	if shouldRetryTransient(ctx, &pq.Error{Code: "23505"}) {
+		t.Error("Expected no retry for a query error")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if shouldRetryTransient(cancelled, driver.ErrBadConn) {
+		t.Error("Expected no retry once the request is cancelled")
+	}
+}
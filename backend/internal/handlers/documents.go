@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// DocumentHandler handles HTTP requests for application document links
+type DocumentHandler struct {
+	db      *sql.DB
+	queries *database.Queries
+	counts  *CountCache
+}
+
+// NewDocumentHandler creates a new document handler
+func NewDocumentHandler(db *sql.DB, queries *database.Queries, counts *CountCache) *DocumentHandler {
+	return &DocumentHandler{
+		db:      db,
+		queries: queries,
+		counts:  counts,
+	}
+}
+
+// DocumentRequest represents the JSON body for creating or updating a document link
+type DocumentRequest struct {
+	Label string `json:"label" binding:"required,min=1,max=255"`
+	URL   string `json:"url" binding:"required,url,max=2048"`
+	Type  string `json:"type" binding:"omitempty,oneof=resume cover_letter portfolio other"` // defaults to "other"
+}
+
+// documentType returns the request's type, defaulting to "other"
+func (r DocumentRequest) documentType() string {
+	if r.Type == "" {
+		return "other"
+	}
+	return r.Type
+}
+
+// isWebURL reports whether rawURL is an absolute http(s) URL
+// The url validator also accepts schemes like javascript: which must never be rendered as links
+func isWebURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// parseApplicationChildPath parses :id (application) and, if present, the child resource ID param
+// (e.g. :documentId) from the URL; childName is used in error messages
+// Sends a 400 response and returns false if either is not a number
+func parseApplicationChildPath(c *gin.Context, childParam, childName string) (applicationID int32, childID int32, ok bool) {
+	appID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid application ID", "ID must be a number")
+		return 0, 0, false
+	}
+
+	if childIDStr := c.Param(childParam); childIDStr != "" {
+		id, err := strconv.Atoi(childIDStr)
+		if err != nil {
+			sendBadRequest(c, "Invalid "+strings.ToLower(childName)+" ID", childName+" ID must be a number")
+			return 0, 0, false
+		}
+		childID = int32(id)
+	}
+
+	return int32(appID), childID, true
+}
+
+// parseDocumentPath parses :id (application) and, if present, :documentId from the URL
+func parseDocumentPath(c *gin.Context) (applicationID int32, documentID int32, ok bool) {
+	return parseApplicationChildPath(c, "documentId", "Document")
+}
+
+// bindDocumentRequest binds and validates a document request body
+// Sends a 400 response and returns false if the body is invalid
+func bindDocumentRequest(c *gin.Context) (DocumentRequest, bool) {
+	var req DocumentRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return req, false
+	}
+	if !isWebURL(req.URL) {
+		sendBadRequest(c, "Invalid url", "url must be an http or https link")
+		return req, false
+	}
+	return req, true
+}
+
+// GetDocuments handles GET /api/applications/:id/documents
+// Returns all document links of an application (verifies ownership)
+func (h *DocumentHandler) GetDocuments(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseDocumentPath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user (so an unknown application is a 404, not [])
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	documents, err := h.queries.GetDocumentsByApplicationIDAndUserID(ctx, database.GetDocumentsByApplicationIDAndUserIDParams{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch documents", err)
+		return
+	}
+	if documents == nil {
+		documents = []database.Document{}
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// CreateDocument handles POST /api/applications/:id/documents
+// Adds a document link to an application (verifies ownership)
+func (h *DocumentHandler) CreateDocument(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseDocumentPath(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindDocumentRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	document, err := h.queries.CreateDocument(ctx, database.CreateDocumentParams{
+		ApplicationID: applicationID,
+		Label:         req.Label,
+		Url:           req.URL,
+		Type:          req.documentType(),
+	})
+	if handleDatabaseError(c, err, "Document") {
+		return
+	}
+	// Application counts filtered by missing_resume depend on which applications have a resume
+	h.counts.Invalidate(countResourceApplications, userID)
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// UpdateDocument handles PUT /api/applications/:id/documents/:documentId
+// Updates a document link (verifies ownership through the application)
+func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, documentID, ok := parseDocumentPath(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindDocumentRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	document, err := h.queries.UpdateDocument(ctx, database.UpdateDocumentParams{
+		ID:            documentID,
+		ApplicationID: applicationID,
+		Label:         req.Label,
+		Url:           req.URL,
+		Type:          req.documentType(),
+		UserID:        userID,
+	})
+	if handleDatabaseError(c, err, "Document") {
+		return
+	}
+	// The type may have changed to or from resume, which shifts the missing_resume counts
+	h.counts.Invalidate(countResourceApplications, userID)
+
+	c.JSON(http.StatusOK, document)
+}
+
+// DeleteDocument handles DELETE /api/applications/:id/documents/:documentId
+// Deletes a document link (verifies ownership through the application)
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, documentID, ok := parseDocumentPath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var document database.Document
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		var err error
+		document, err = qtx.GetDocumentByIDAndUserID(ctx, database.GetDocumentByIDAndUserIDParams{
+			ID:            documentID,
+			ApplicationID: applicationID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return qtx.DeleteDocument(ctx, database.DeleteDocumentParams{
+			ID:            documentID,
+			ApplicationID: applicationID,
+			UserID:        userID,
+		})
+	})
+	if handleDatabaseError(c, err, "Document") {
+		return
+	}
+	// Application counts filtered by missing_resume depend on which applications have a resume
+	h.counts.Invalidate(countResourceApplications, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document deleted successfully",
+		"id":      documentID,
+		"deleted": document,
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestDocumentsCRUD tests the /api/applications/:id/documents endpoints
+func TestDocumentsCRUD(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-documents@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-documents-other@example.com")
+	defer otherCleanup()
+
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+	basePath := "/api/applications/" + strconv.Itoa(int(application.ID)) + "/documents"
+
+	request := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			buf.Write(jsonBody)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test create
+	w := request("POST", basePath, testUser.Token, map[string]interface{}{
+		"label": "Resume (Google Doc)",
+		"url":   "https://docs.google.com/document/d/abc",
+		"type":  "resume",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var document database.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	documentPath := basePath + "/" + strconv.Itoa(int(document.ID))
+
+	// Test invalid URLs are rejected
+	for _, badURL := range []string{"not a url", "javascript:alert(1)", "ftp://example.com/file"} {
+		w = request("POST", basePath, testUser.Token, map[string]interface{}{"label": "Bad", "url": badURL})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for url %q, got %d", http.StatusBadRequest, badURL, w.Code)
+		}
+	}
+
+	// Test list
+	w = request("GET", basePath, testUser.Token, nil)
+	var documents []database.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &documents); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(documents) != 1 {
+		t.Errorf("Expected 1 document, got %d", len(documents))
+	}
+
+	// Test update (type defaults to other)
+	w = request("PUT", documentPath, testUser.Token, map[string]interface{}{
+		"label": "Portfolio",
+		"url":   "https://example.com/portfolio",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if document.Label != "Portfolio" || document.Type != "other" {
+		t.Errorf("Unexpected updated document: %+v", document)
+	}
+
+	// Test another user cannot see, update or delete the documents
+	if w = request("GET", basePath, otherUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's list, got %d", http.StatusNotFound, w.Code)
+	}
+	if w = request("PUT", documentPath, otherUser.Token, map[string]interface{}{"label": "Hijack", "url": "https://example.com"}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's update, got %d", http.StatusNotFound, w.Code)
+	}
+	if w = request("DELETE", documentPath, otherUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's delete, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Test delete
+	if w = request("DELETE", documentPath, testUser.Token, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w = request("DELETE", documentPath, testUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+// TestDocumentChangesInvalidateApplicationCounts tests that creating, retyping and deleting documents
+// refresh the cached missing_resume application counts
+func TestDocumentChangesInvalidateApplicationCounts(t *testing.T) {
+	_, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user (cleanup cascades to their applications and documents)
+	testUser, cleanup := createTestUser(t, queries, db, "test-documents-counts@example.com")
+	defer cleanup()
+
+	router := gin.New()
+	cfg := Config{DB: queries, DBConn: db, UseLegacyAuth: true, CountCache: NewCountCache(time.Minute)}
+	cfg.SetupRoutes(router)
+
+	application := createTestApplication(t, queries, testUser.ID, "applied", "")
+	documentsPath := "/api/applications/" + strconv.Itoa(int(application.ID)) + "/documents"
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	expectMissingResume := func(step string, expected int64) {
+		t.Helper()
+		w := request("GET", "/api/applications/count?missing_resume=true", nil)
+		var response struct {
+			Count int64 `json:"count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse count: %v (%s)", err, w.Body.String())
+		}
+		if response.Count != expected {
+			t.Errorf("%s: expected %d applications without a resume, got %d", step, expected, response.Count)
+		}
+	}
+
+	expectMissingResume("before", 1)
+
+	w := request("POST", documentsPath, map[string]interface{}{"label": "Resume", "url": "https://example.com/resume.pdf", "type": "resume"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var document database.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expectMissingResume("after create", 0)
+
+	documentPath := documentsPath + "/" + strconv.Itoa(int(document.ID))
+	if w := request("PUT", documentPath, map[string]interface{}{"label": "Portfolio", "url": "https://example.com", "type": "portfolio"}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	expectMissingResume("after retype", 1)
+
+	if w := request("PUT", documentPath, map[string]interface{}{"label": "Resume", "url": "https://example.com/resume.pdf", "type": "resume"}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	expectMissingResume("after retype back", 0)
+
+	if w := request("DELETE", documentPath, nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	expectMissingResume("after delete", 1)
+}
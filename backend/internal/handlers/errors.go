@@ -2,20 +2,27 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
 )
 
+// ExposeErrorDetails includes internal error text (e.g. SQL errors) in the details of error responses
+// Turned off in production (ENV=production, see main.go); the full error is always logged
+var ExposeErrorDetails = true
+
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Message string            `json:"message,omitempty"`
-	Details string            `json:"details,omitempty"`
-	Fields  map[string]string `json:"fields,omitempty"`
+	Error     string            `json:"error"`
+	Message   string            `json:"message,omitempty"`
+	Details   string            `json:"details,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"request_id,omitempty"` // set on 5xx responses so users can report them
 }
 
 // ValidationErrorResponse represents a validation error response with field-specific errors
@@ -26,6 +33,7 @@ type ValidationErrorResponse struct {
 }
 
 // sendError sends a standardized error response
+// 5xx responses carry the request ID; their details are logged and only sent when ExposeErrorDetails is on
 func sendError(c *gin.Context, statusCode int, errorMsg string, details ...string) {
 	response := ErrorResponse{
 		Error: errorMsg,
@@ -37,27 +45,56 @@ func sendError(c *gin.Context, statusCode int, errorMsg string, details ...strin
 
 	// Log error for debugging (except 4xx client errors)
 	if statusCode >= 500 {
-		log.Printf("ERROR [%d]: %s - %s", statusCode, errorMsg, response.Details)
+		response.RequestID = c.GetString(middleware.RequestIDKey)
+		log.Printf("ERROR [%d] request_id=%s: %s - %s", statusCode, response.RequestID, errorMsg, response.Details)
+
+		if !ExposeErrorDetails {
+			response.Details = ""
+			response.Message = "An unexpected error occurred. Please include the request_id when reporting this issue."
+		}
 	}
 
 	c.JSON(statusCode, response)
 }
 
+// internalDetails returns err's text for a client-facing details field, or "" when ExposeErrorDetails is off
+// Use it for 4xx responses whose details would otherwise echo database errors (constraint and column names)
+func internalDetails(err error) string {
+	if err == nil || !ExposeErrorDetails {
+		return ""
+	}
+	return err.Error()
+}
+
 // sendBadRequest sends a 400 Bad Request error
 func sendBadRequest(c *gin.Context, message string, details ...string) {
 	sendError(c, http.StatusBadRequest, message, details...)
 }
 
 // sendValidationError sends a 400 Bad Request error with field-specific validation errors
+// Field keys stay stable; messages are localized from the Accept-Language header
+// A missing body gets its own "Request body is required" error instead of field errors
 func sendValidationError(c *gin.Context, err error) {
+	if errors.Is(err, errEmptyBody) {
+		sendBadRequest(c, "Request body is required", "Send a JSON object in the request body")
+		return
+	}
+	if errors.Is(err, errInvalidJSONBody) {
+		sendBadRequest(c, "Invalid JSON body", "The request body must be well-formed JSON encoded as UTF-8")
+		return
+	}
+
 	var fields map[string]string
 	var message string
 	var errorTitle string
 
 	// Check if it's a validator.ValidationErrors
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		locale := requestLocale(c)
+		c.Header("Content-Language", locale)
+
 		fields = make(map[string]string)
-		message = "Validation failed"
+		message = localizedMessage(locale, "validation_failed", "", "")
 		errorTitle = "Validation failed"
 
 		for _, fieldError := range validationErrors {
@@ -68,27 +105,7 @@ func sendValidationError(c *gin.Context, err error) {
 			}
 
 			// Create user-friendly error message
-			var errorMsg string
-			switch fieldError.Tag() {
-			case "required":
-				errorMsg = fieldName + " is required"
-			case "email":
-				errorMsg = fieldName + " must be a valid email address"
-			case "url":
-				errorMsg = fieldName + " must be a valid URL"
-			case "min":
-				errorMsg = fieldName + " must be at least " + fieldError.Param() + " characters"
-			case "max":
-				errorMsg = fieldName + " must be at most " + fieldError.Param() + " characters"
-			case "oneof":
-				errorMsg = fieldName + " must be one of: " + strings.ReplaceAll(fieldError.Param(), " ", ", ")
-			case "datetime":
-				errorMsg = fieldName + " must be in format " + fieldError.Param()
-			default:
-				errorMsg = fieldName + " is invalid"
-			}
-
-			fields[fieldName] = errorMsg
+			fields[fieldName] = fieldErrorMessage(locale, fieldName, fieldError)
 		}
 	} else {
 		// Fallback for non-validator errors
@@ -113,8 +130,43 @@ func sendNotFound(c *gin.Context, resource string) {
 	sendError(c, http.StatusNotFound, resource+" not found")
 }
 
+// sendReferenceNotFound sends a 404 Not Found error for a body field that references a missing
+// (or another user's) resource; the field is named so clients can highlight the right input
+func sendReferenceNotFound(c *gin.Context, field, resource string) {
+	message := resource + " not found or not yours"
+	c.JSON(http.StatusNotFound, ErrorResponse{
+		Error:  message,
+		Fields: map[string]string{field: message},
+	})
+}
+
+// sendServiceUnavailable sends a 503 when the database can't be reached
+// The full error is logged but never sent to the client
+func sendServiceUnavailable(c *gin.Context, err error) {
+	requestID := c.GetString(middleware.RequestIDKey)
+	log.Printf("ERROR [%d] request_id=%s: database unavailable - %v", http.StatusServiceUnavailable, requestID, err)
+	c.Header("Retry-After", "5")
+	c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+		Error:     "Service temporarily unavailable",
+		Message:   "The database is temporarily unreachable. Please try again shortly.",
+		RequestID: requestID,
+	})
+}
+
 // sendInternalError sends a 500 Internal Server Error
+// Connection-level database errors are sent as a sanitized 503 instead
+// Nothing is sent if the request timed out; RequestTimeoutMiddleware sends the 503
 func sendInternalError(c *gin.Context, message string, err error) {
+	if middleware.RequestTimedOut(c) {
+		log.Printf("ERROR [%d] request_id=%s: request timed out - %s - %v", http.StatusServiceUnavailable, c.GetString(middleware.RequestIDKey), message, err)
+		return
+	}
+
+	if isConnectionError(err) {
+		sendServiceUnavailable(c, err)
+		return
+	}
+
 	details := ""
 	if err != nil {
 		details = err.Error()
@@ -133,15 +185,20 @@ func handleDatabaseError(c *gin.Context, err error, resource string) bool {
 		return true
 	}
 
+	if isConnectionError(err) || middleware.RequestTimedOut(c) {
+		sendInternalError(c, "Database operation failed", err)
+		return true
+	}
+
 	// Check for common database constraint errors
 	errStr := strings.ToLower(err.Error())
 	if strings.Contains(errStr, "duplicate") || strings.Contains(errStr, "unique") {
-		sendBadRequest(c, "Resource already exists", err.Error())
+		sendBadRequest(c, "Resource already exists", internalDetails(err))
 		return true
 	}
 
 	if strings.Contains(errStr, "foreign key") || strings.Contains(errStr, "constraint") {
-		sendBadRequest(c, "Invalid reference", err.Error())
+		sendBadRequest(c, "Invalid reference", internalDetails(err))
 		return true
 	}
 
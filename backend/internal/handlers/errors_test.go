@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
+)
+
+// TestSendInternalError_Details tests that internal error text only reaches clients outside production
+func TestSendInternalError_Details(t *testing.T) {
+	defer func(original bool) { ExposeErrorDetails = original }(ExposeErrorDetails)
+	gin.SetMode(gin.TestMode)
+
+	sqlErr := errors.New(`pq: column "secret_col" of relation "users" does not exist`)
+
+	tests := []struct {
+		name          string
+		expose        bool
+		expectDetails bool
+	}{
+		{"Development", true, true},
+		{"Production", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ExposeErrorDetails = tt.expose
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set(middleware.RequestIDKey, "req-123")
+
+			sendInternalError(c, "Failed to fetch applications", sqlErr)
+
+			if w.Code != http.StatusInternalServerError {
+				t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error != "Failed to fetch applications" || response.RequestID != "req-123" {
+				t.Errorf("Unexpected response: %+v", response)
+			}
+			if leaked := strings.Contains(w.Body.String(), "secret_col"); leaked != tt.expectDetails {
+				t.Errorf("Expected details in body: %v, got body %s", tt.expectDetails, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestHandleDatabaseError_HidesConstraintDetails tests that constraint errors don't echo SQL in production
+func TestHandleDatabaseError_HidesConstraintDetails(t *testing.T) {
+	defer func(original bool) { ExposeErrorDetails = original }(ExposeErrorDetails)
+	gin.SetMode(gin.TestMode)
+	ExposeErrorDetails = false
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	handleDatabaseError(c, errors.New(`pq: duplicate key value violates unique constraint "idx_tags_user_name"`), "Tag")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "idx_tags_user_name") {
+		t.Errorf("Expected constraint name not to leak, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// Expansion paths accepted by ?expand= on the application endpoints
+// Only these paths are joined, which caps how wide and deep a request can reach; other tokens are ignored
+const (
+	expandJob        = "job"
+	expandJobCompany = "job.company"
+	expandContact    = "contact"
+)
+
+// applicationExpansions records which related objects to embed in application responses
+type applicationExpansions struct {
+	Job        bool // embed the application's job as "job"
+	JobCompany bool // embed the job's company as "job.company" (implies Job)
+	Contact    bool // embed the application's contact as "contact"
+}
+
+// parseApplicationExpansions parses a comma-separated list of dotted paths, e.g. "job.company,contact"
+// Unknown tokens are ignored so clients can send expansions that newer servers may support
+func parseApplicationExpansions(raw string) applicationExpansions {
+	var e applicationExpansions
+	for _, token := range strings.Split(raw, ",") {
+		switch strings.ToLower(strings.TrimSpace(token)) {
+		case expandJob:
+			e.Job = true
+		case expandJobCompany:
+			e.Job = true
+			e.JobCompany = true
+		case expandContact:
+			e.Contact = true
+		}
+	}
+	return e
+}
+
+// any reports whether at least one expansion was requested
+func (e applicationExpansions) any() bool {
+	return e.Job || e.Contact
+}
+
+// expandedJob is a job with its company embedded (company is omitted unless job.company was requested)
+type expandedJob struct {
+	database.Job
+	Company *database.Company `json:"company,omitempty"`
+}
+
+// applicationKeys returns the ID and contact ID of an application row
+// Accepts the row types returned by the application list queries
+func applicationKeys(item interface{}) (id int32, contactID int32, hasContact bool) {
+	switch app := item.(type) {
+	case database.Application:
+		return app.ID, app.ContactID.Int32, app.ContactID.Valid
+	case database.GetApplicationsWithFlagsFilteredByUserIDRow:
+		return app.ID, app.ContactID.Int32, app.ContactID.Valid
+	}
+	return 0, 0, false
+}
+
+// expandApplications embeds the requested related objects into each application
+// Related objects are loaded with one query per expansion (not per application); each item
+// becomes a JSON object with the application's fields plus "job" and/or "contact" (null if none)
+func (h *ApplicationHandler) expandApplications(ctx context.Context, userID int32, items []interface{}, expand applicationExpansions) ([]interface{}, error) {
+	if !expand.any() || len(items) == 0 {
+		return items, nil
+	}
+
+	applicationIDs := make([]int32, 0, len(items))
+	contactIDs := make([]int32, 0, len(items))
+	for _, item := range items {
+		id, contactID, hasContact := applicationKeys(item)
+		applicationIDs = append(applicationIDs, id)
+		if hasContact {
+			contactIDs = append(contactIDs, contactID)
+		}
+	}
+
+	jobs := make(map[int32]*expandedJob)
+	if expand.Job {
+		rows, err := h.queries.GetJobsByApplicationIDsAndUserID(ctx, database.GetJobsByApplicationIDsAndUserIDParams{
+			ApplicationIds: applicationIDs,
+			UserID:         userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		companyIDs := make([]int32, 0, len(rows))
+		for _, job := range rows {
+			jobs[job.ApplicationID] = &expandedJob{Job: job}
+			companyIDs = append(companyIDs, job.CompanyID)
+		}
+
+		if expand.JobCompany && len(companyIDs) > 0 {
+			companies, err := h.queries.GetCompaniesByIDsAndUserID(ctx, database.GetCompaniesByIDsAndUserIDParams{
+				Ids:    companyIDs,
+				UserID: userID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[int32]*database.Company, len(companies))
+			for i := range companies {
+				byID[companies[i].ID] = &companies[i]
+			}
+			for _, job := range jobs {
+				job.Company = byID[job.CompanyID]
+			}
+		}
+	}
+
+	contacts := make(map[int32]*database.Contact)
+	if expand.Contact && len(contactIDs) > 0 {
+		rows, err := h.queries.GetContactsByIDsAndUserID(ctx, database.GetContactsByIDsAndUserIDParams{
+			Ids:    contactIDs,
+			UserID: userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			contacts[rows[i].ID] = &rows[i]
+		}
+	}
+
+	expanded := make([]interface{}, len(items))
+	for i, item := range items {
+		// Flatten the application into an object so related objects sit beside its own fields
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var own map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &own); err != nil {
+			return nil, err
+		}
+		fields := make(map[string]interface{}, len(own)+2)
+		for key, value := range own {
+			fields[key] = value
+		}
+
+		id, contactID, hasContact := applicationKeys(item)
+		if expand.Job {
+			fields["job"] = jobs[id] // nil (JSON null) when the application has no job
+		}
+		if expand.Contact {
+			var contact *database.Contact
+			if hasContact {
+				contact = contacts[contactID]
+			}
+			fields["contact"] = contact
+		}
+		expanded[i] = fields
+	}
+	return expanded, nil
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestParseApplicationExpansions tests expand token parsing
+func TestParseApplicationExpansions(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected applicationExpansions
+	}{
+		{"", applicationExpansions{}},
+		{"job", applicationExpansions{Job: true}},
+		{"job.company,contact", applicationExpansions{Job: true, JobCompany: true, Contact: true}},
+		{" Contact , job ", applicationExpansions{Job: true, Contact: true}},
+		{"job.company.jobs,notes,user", applicationExpansions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := parseApplicationExpansions(tt.raw); got != tt.expected {
+				t.Errorf("Expected %+v for %q, got %+v", tt.expected, tt.raw, got)
+			}
+		})
+	}
+}
+
+// TestGetApplications_Expand tests ?expand= on the application detail and list endpoints
+func TestGetApplications_Expand(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-expand@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Expand Corp", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	contact, err := queries.CreateContact(ctx, database.CreateContactParams{Name: "Recruiter", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+	application, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+		Status:      "applied",
+		AppliedDate: time.Now(),
+		ContactID:   sql.NullInt32{Int32: contact.ID, Valid: true},
+		UserID:      testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{
+		ApplicationID: application.ID,
+		CompanyID:     company.ID,
+		Title:         "Engineer",
+	}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	bare := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %s, got %d. Body: %s", http.StatusOK, path, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	type expandedApplication struct {
+		ID  int32 `json:"id"`
+		Job *struct {
+			Title   string            `json:"title"`
+			Company *database.Company `json:"company"`
+		} `json:"job"`
+		Contact *database.Contact `json:"contact"`
+	}
+
+	// Test detail with nested expansion
+	var detail expandedApplication
+	w := get("/api/applications/" + strconv.Itoa(int(application.ID)) + "?expand=job.company,contact,unknown")
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if detail.ID != application.ID || detail.Job == nil || detail.Job.Title != "Engineer" {
+		t.Fatalf("Expected expanded job, got %s", w.Body.String())
+	}
+	if detail.Job.Company == nil || detail.Job.Company.ID != company.ID {
+		t.Errorf("Expected job.company to be expanded, got %s", w.Body.String())
+	}
+	if detail.Contact == nil || detail.Contact.ID != contact.ID {
+		t.Errorf("Expected contact to be expanded, got %s", w.Body.String())
+	}
+
+	// Test job without job.company leaves the company out
+	var fields struct {
+		Job map[string]json.RawMessage `json:"job"`
+	}
+	w = get("/api/applications/" + strconv.Itoa(int(application.ID)) + "?expand=job")
+	if err := json.Unmarshal(w.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if _, ok := fields.Job["company"]; ok {
+		t.Errorf("Expected no company without job.company, got %s", w.Body.String())
+	}
+
+	// Test list: requested expansions are null when the application has none
+	var list []map[string]json.RawMessage
+	w = get("/api/applications?ids=" + strconv.Itoa(int(application.ID)) + "," + strconv.Itoa(int(bare.ID)) + "&expand=job,contact")
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(list) != 2 || string(list[1]["job"]) != "null" || string(list[1]["contact"]) != "null" {
+		t.Errorf("Expected null job and contact for the bare application, got %s", w.Body.String())
+	}
+
+	// Test paginated list expansion
+	var paginated struct {
+		Data []expandedApplication `json:"data"`
+	}
+	w = get("/api/applications?page=1&limit=10&expand=job.company")
+	if err := json.Unmarshal(w.Body.Bytes(), &paginated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expandedJobs := 0
+	for _, app := range paginated.Data {
+		if app.Job != nil && app.Job.Company != nil {
+			expandedJobs++
+		}
+	}
+	if expandedJobs != 1 {
+		t.Errorf("Expected 1 application with an expanded job.company, got %d", expandedJobs)
+	}
+}
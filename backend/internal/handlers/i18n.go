@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultLocale is used when Accept-Language names no supported locale
+const DefaultLocale = "en"
+
+// validationCatalog holds the human-readable validation messages per locale
+// Keys are validator tags (plus "invalid" for unknown tags, "date" for parseDate and "validation_failed" for the summary);
+// {field} and {param} are replaced with the field name and the tag parameter
+var validationCatalog = map[string]map[string]string{
+	"en": {
+		"validation_failed": "Validation failed",
+		"required":          "{field} is required",
+		"email":             "{field} must be a valid email address",
+		"url":               "{field} must be a valid URL",
+		"min":               "{field} must be at least {param} characters",
+		"max":               "{field} must be at most {param} characters",
+		"oneof":             "{field} must be one of: {param}",
+		"datetime":          "{field} must be in format {param}",
+		"iso4217":           "{field} must be a 3-letter ISO 4217 currency code (e.g. USD)",
+		"date":              "{field} must be a date in YYYY-MM-DD or RFC 3339 format (e.g. 2024-01-15)",
+		"invalid":           "{field} is invalid",
+	},
+	"es": {
+		"validation_failed": "La validación falló",
+		"required":          "{field} es obligatorio",
+		"email":             "{field} debe ser un correo electrónico válido",
+		"url":               "{field} debe ser una URL válida",
+		"min":               "{field} debe tener al menos {param} caracteres",
+		"max":               "{field} debe tener como máximo {param} caracteres",
+		"oneof":             "{field} debe ser uno de: {param}",
+		"datetime":          "{field} debe tener el formato {param}",
+		"iso4217":           "{field} debe ser un código de moneda ISO 4217 de 3 letras (p. ej. USD)",
+		"date":              "{field} debe ser una fecha en formato AAAA-MM-DD o RFC 3339 (p. ej. 2024-01-15)",
+		"invalid":           "{field} no es válido",
+	},
+}
+
+// requestLocale picks the best supported locale from the Accept-Language header
+// Honors q-values, matches region tags on their base language (es-MX -> es) and falls back to DefaultLocale
+func requestLocale(c *gin.Context) string {
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return DefaultLocale
+	}
+
+	type candidate struct {
+		locale string
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := validationCatalog[base]; ok && q > 0 {
+			candidates = append(candidates, candidate{locale: base, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+
+	// Stable so equal q-values keep header order
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+// localizedMessage renders a catalog message for the locale, falling back to English
+func localizedMessage(locale, key, field, param string) string {
+	template, ok := validationCatalog[locale][key]
+	if !ok {
+		template = validationCatalog[DefaultLocale][key]
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
+
+// fieldErrorMessage returns the localized message for a single validation error
+func fieldErrorMessage(locale, fieldName string, fieldError validator.FieldError) string {
+	switch tag := fieldError.Tag(); tag {
+	case "required", "email", "url", "min", "max", "datetime", "iso4217":
+		return localizedMessage(locale, tag, fieldName, fieldError.Param())
+	case "oneof":
+		return localizedMessage(locale, tag, fieldName, strings.ReplaceAll(fieldError.Param(), " ", ", "))
+	default:
+		return localizedMessage(locale, "invalid", fieldName, "")
+	}
+}
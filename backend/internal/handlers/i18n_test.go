@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// TestRequestLocale tests Accept-Language negotiation
+func TestRequestLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"No header", "", "en"},
+		{"Exact match", "es", "es"},
+		{"Region falls back to base language", "es-MX", "es"},
+		{"Unknown locale falls back to English", "fr-FR", "en"},
+		{"Highest q-value wins", "en;q=0.5, es;q=0.9", "es"},
+		{"Skips unsupported preferences", "de, es;q=0.8", "es"},
+		{"Zero q-value is excluded", "es;q=0", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newQueryContext("")
+			c.Request.Header.Set("Accept-Language", tt.header)
+			if got := requestLocale(c); got != tt.expected {
+				t.Errorf("Expected locale %q for %q, got %q", tt.expected, tt.header, got)
+			}
+		})
+	}
+}
+
+// TestSendValidationError_Localized tests that field messages are translated while keys stay stable
+func TestSendValidationError_Localized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	err := validator.New().Struct(struct {
+		Email string `validate:"required"`
+	}{})
+
+	tests := []struct {
+		language string
+		message  string
+		field    string
+	}{
+		{"en-US", "Validation failed", "email is required"},
+		{"es", "La validación falló", "email es obligatorio"},
+		{"ja", "Validation failed", "email is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", nil)
+			c.Request.Header.Set("Accept-Language", tt.language)
+
+			sendValidationError(c, err)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			var response ValidationErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Message != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, response.Message)
+			}
+			if response.Fields["email"] != tt.field {
+				t.Errorf("Expected field message %q, got %q", tt.field, response.Fields["email"])
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// ImportSchemaVersion is the only "version" accepted by POST /api/import/json
+const ImportSchemaVersion = 1
+
+// MaxImportRecords caps how many records each section of an import may hold
+// Keep in sync with the max tags on ImportRequest
+const MaxImportRecords = 1000
+
+// ImportCompany is a company in an import file
+// Companies are matched to the user's existing companies by normalized name, like POST /api/companies
+type ImportCompany struct {
+	ID int64 `json:"id" binding:"gte=0"` // id in the source file, referenced by jobs (0 is a valid id)
+	CreateCompanyRequest
+}
+
+// ImportContact is a contact in an import file
+// Contacts with an email are matched to the user's existing contacts by email (case-insensitive)
+type ImportContact struct {
+	ID int64 `json:"id" binding:"gte=0"` // id in the source file, referenced by applications (0 is a valid id)
+	CreateContactRequest
+}
+
+// ImportApplication is an application in an import file; fields match POST /api/applications
+// contact_id and referred_by_contact_id reference contacts in the same file
+type ImportApplication struct {
+	ID                  int64  `json:"id" binding:"gte=0"`
+	Status              string `json:"status" binding:"required,oneof=applied interview offer rejected withdrawn accepted"`
+	AppliedDate         string `json:"applied_date" binding:"required"` // YYYY-MM-DD or RFC 3339
+	ContactID           *int64 `json:"contact_id"`
+	ReferredByContactID *int64 `json:"referred_by_contact_id"`
+	Notes               string `json:"notes" binding:"omitempty,max=5000"`
+	Source              string `json:"source" binding:"omitempty,oneof=linkedin indeed referral company_site job_board recruiter other"`
+	NextAction          string `json:"next_action" binding:"omitempty,max=500"`
+	NextActionDue       string `json:"next_action_due"` // YYYY-MM-DD or RFC 3339
+	OfferDetails
+	ClosedReason string `json:"closed_reason" binding:"omitempty,max=500"`
+}
+
+// ImportJob is a job in an import file
+// application_id and company_id reference an application and a company in the same file
+type ImportJob struct {
+	ID            int64 `json:"id" binding:"gte=0"`
+	ApplicationID int64 `json:"application_id" binding:"gte=0"`
+	CompanyID     int64 `json:"company_id" binding:"gte=0"`
+	TrackJobRequest
+}
+
+// ImportRequest is the JSON body of POST /api/import/json
+//
+//	{
+//	  "version": 1,
+//	  "companies":    [{"id": 1, "name": "Acme", "website": "...", "industry": "...", "size": "51-200"}],
+//	  "contacts":     [{"id": 1, "name": "Rita", "email": "...", "phone": "...", "linkedin": "...", "role": "recruiter"}],
+//	  "applications": [{"id": 1, "status": "applied", "applied_date": "2024-01-15", "contact_id": 1, ...}],
+//	  "jobs":         [{"id": 1, "application_id": 1, "company_id": 1, "title": "Engineer", ...}]
+//	}
+//
+// ids are non-negative and only need to be unique within their section; they are replaced by new ids on import
+type ImportRequest struct {
+	Version      int                 `json:"version" binding:"required"`
+	Companies    []ImportCompany     `json:"companies" binding:"max=1000,dive"`
+	Contacts     []ImportContact     `json:"contacts" binding:"max=1000,dive"`
+	Applications []ImportApplication `json:"applications" binding:"max=1000,dive"`
+	Jobs         []ImportJob         `json:"jobs" binding:"max=1000,dive"`
+}
+
+// ImportResponse maps each section's ids in the import file to the ids of the imported records
+// JSON object keys are the old ids, e.g. {"companies": {"1": 57}}
+type ImportResponse struct {
+	Companies    map[int64]int32 `json:"companies"`
+	Contacts     map[int64]int32 `json:"contacts"`
+	Applications map[int64]int32 `json:"applications"`
+	Jobs         map[int64]int32 `json:"jobs"`
+}
+
+// ImportHandler handles HTTP requests for importing data
+type ImportHandler struct {
+	db       *sql.DB
+	queries  *database.Queries
+	counts   *CountCache
+	webhooks *WebhookDispatcher
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(db *sql.DB, queries *database.Queries, counts *CountCache, webhooks *WebhookDispatcher) *ImportHandler {
+	return &ImportHandler{
+		db:       db,
+		queries:  queries,
+		counts:   counts,
+		webhooks: webhooks,
+	}
+}
+
+// duplicateImportID returns the first id that appears twice in ids, if any
+func duplicateImportID(ids []int64) (int64, bool) {
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return id, true
+		}
+		seen[id] = true
+	}
+	return 0, false
+}
+
+// validateImportIDs checks that ids are unique within each section and that every reference
+// points at a record in the file; sends a 400 response and returns false otherwise
+func validateImportIDs(c *gin.Context, req ImportRequest) bool {
+	sections := map[string][]int64{}
+	companies := make(map[int64]bool, len(req.Companies))
+	for _, company := range req.Companies {
+		sections["companies"] = append(sections["companies"], company.ID)
+		companies[company.ID] = true
+	}
+	contacts := make(map[int64]bool, len(req.Contacts))
+	for _, contact := range req.Contacts {
+		sections["contacts"] = append(sections["contacts"], contact.ID)
+		contacts[contact.ID] = true
+	}
+	applications := make(map[int64]bool, len(req.Applications))
+	for _, application := range req.Applications {
+		sections["applications"] = append(sections["applications"], application.ID)
+		applications[application.ID] = true
+	}
+	for _, job := range req.Jobs {
+		sections["jobs"] = append(sections["jobs"], job.ID)
+	}
+	for section, ids := range sections {
+		if id, ok := duplicateImportID(ids); ok {
+			sendBadRequest(c, "Duplicate id", section+" id "+strconv.FormatInt(id, 10)+" is used more than once")
+			return false
+		}
+	}
+
+	for _, application := range req.Applications {
+		for _, ref := range []*int64{application.ContactID, application.ReferredByContactID} {
+			if ref != nil && !contacts[*ref] {
+				sendBadRequest(c, "Unknown reference", "application "+strconv.FormatInt(application.ID, 10)+" references contact "+strconv.FormatInt(*ref, 10)+", which is not in the file")
+				return false
+			}
+		}
+	}
+
+	jobApplications := make(map[int64]bool, len(req.Jobs))
+	for _, job := range req.Jobs {
+		jobID := strconv.FormatInt(job.ID, 10)
+		if !applications[job.ApplicationID] {
+			sendBadRequest(c, "Unknown reference", "job "+jobID+" references application "+strconv.FormatInt(job.ApplicationID, 10)+", which is not in the file")
+			return false
+		}
+		if !companies[job.CompanyID] {
+			sendBadRequest(c, "Unknown reference", "job "+jobID+" references company "+strconv.FormatInt(job.CompanyID, 10)+", which is not in the file")
+			return false
+		}
+		if jobApplications[job.ApplicationID] {
+			sendBadRequest(c, "Duplicate job", "application "+strconv.FormatInt(job.ApplicationID, 10)+" has more than one job (an application has at most one)")
+			return false
+		}
+		jobApplications[job.ApplicationID] = true
+	}
+	return true
+}
+
+// importApplicationParams converts an imported application to create params, leaving the contact links unset
+// Sends a 400 response and returns false if a field is invalid
+func importApplicationParams(c *gin.Context, userID int32, application ImportApplication) (database.CreateApplicationParams, bool) {
+	appliedDate, err := parseDate(application.AppliedDate)
+	if err != nil {
+		sendDateError(c, "applications["+strconv.FormatInt(application.ID, 10)+"].applied_date")
+		return database.CreateApplicationParams{}, false
+	}
+
+	nextAction, nextActionDue, ok := parseNextAction(c, application.NextAction, application.NextActionDue)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	offer, ok := parseOfferDetails(c, application.Status, application.OfferDetails)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	closedReason, ok := parseClosedReason(c, application.Status, application.ClosedReason)
+	if !ok {
+		return database.CreateApplicationParams{}, false
+	}
+
+	return database.CreateApplicationParams{
+		Status:            application.Status,
+		AppliedDate:       appliedDate,
+		Notes:             sql.NullString{String: application.Notes, Valid: application.Notes != ""},
+		UserID:            userID,
+		Source:            sql.NullString{String: application.Source, Valid: application.Source != ""},
+		NextAction:        nextAction,
+		NextActionDue:     nextActionDue,
+		OfferSalary:       offer.Salary,
+		OfferCurrency:     offer.Currency,
+		OfferReceivedDate: offer.ReceivedDate,
+		Decision:          offer.Decision,
+		ClosedReason:      closedReason,
+	}, true
+}
+
+// ImportJSON handles POST /api/import/json
+// Recreates the companies, contacts, applications and jobs of an import file (see ImportRequest)
+// with new ids and remapped references, in one transaction: nothing is imported if any record fails
+// Existing companies (same normalized name) and contacts (same email) are reused instead of duplicated
+// Returns the old→new id mapping of each section; an application.created webhook event is sent per application
+// With ?dry_run=true the import is validated and run in a transaction that is rolled back: the response
+// (200 instead of 201) maps reused companies and contacts to their ids and records that would be created to 0
+func (h *ImportHandler) ImportJSON(c *gin.Context) {
+	// Parse JSON body
+	var req ImportRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	if req.Version != ImportSchemaVersion {
+		sendBadRequest(c, "Unsupported import version", "version must be "+strconv.Itoa(ImportSchemaVersion))
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	if !validateImportIDs(c, req) {
+		return
+	}
+
+	applicationParams := make([]database.CreateApplicationParams, len(req.Applications))
+	for i, application := range req.Applications {
+		applicationParams[i], ok = importApplicationParams(c, userID, application)
+		if !ok {
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	dryRun := c.Query("dry_run") == "true"
+
+	var response ImportResponse
+	var createdCompanies, createdContacts map[int64]bool
+	var applications []database.Application
+	err := withDryRunTx(ctx, h.db, h.queries, dryRun, func(qtx *database.Queries) error {
+		// Reset in case withTx retries the transaction
+		response = ImportResponse{
+			Companies:    make(map[int64]int32, len(req.Companies)),
+			Contacts:     make(map[int64]int32, len(req.Contacts)),
+			Applications: make(map[int64]int32, len(req.Applications)),
+			Jobs:         make(map[int64]int32, len(req.Jobs)),
+		}
+		createdCompanies = make(map[int64]bool, len(req.Companies))
+		createdContacts = make(map[int64]bool, len(req.Contacts))
+		applications = make([]database.Application, 0, len(req.Applications))
+
+		for _, item := range req.Companies {
+			normalizedName := normalizeCompanyName(item.Name)
+			company, err := qtx.GetCompanyByNameAndUserID(ctx, database.GetCompanyByNameAndUserIDParams{
+				Btrim:  normalizedName,
+				UserID: userID,
+			})
+			if err == sql.ErrNoRows {
+				company, err = qtx.CreateCompany(ctx, database.CreateCompanyParams{
+					Name:     normalizedName,
+					Website:  sql.NullString{String: item.Website, Valid: item.Website != ""},
+					UserID:   userID,
+					Industry: sql.NullString{String: item.Industry, Valid: item.Industry != ""},
+					Size:     sql.NullString{String: item.Size, Valid: item.Size != ""},
+				})
+				createdCompanies[item.ID] = true
+			}
+			if err != nil {
+				return err
+			}
+			response.Companies[item.ID] = company.ID
+		}
+
+		for _, item := range req.Contacts {
+			contact, created, err := getOrCreateContact(ctx, qtx, userID, item.CreateContactRequest)
+			if err != nil {
+				return err
+			}
+			response.Contacts[item.ID] = contact.ID
+			createdContacts[item.ID] = created
+		}
+
+		for i, item := range req.Applications {
+			params := applicationParams[i]
+			if item.ContactID != nil {
+				params.ContactID = sql.NullInt32{Int32: response.Contacts[*item.ContactID], Valid: true}
+			}
+			if item.ReferredByContactID != nil {
+				params.ReferredByContactID = sql.NullInt32{Int32: response.Contacts[*item.ReferredByContactID], Valid: true}
+			}
+			application, err := qtx.CreateApplication(ctx, params)
+			if err != nil {
+				return err
+			}
+			response.Applications[item.ID] = application.ID
+			applications = append(applications, application)
+		}
+
+		for _, item := range req.Jobs {
+			job, err := qtx.CreateJob(ctx, database.CreateJobParams{
+				ApplicationID:  response.Applications[item.ApplicationID],
+				CompanyID:      response.Companies[item.CompanyID],
+				Title:          item.Title,
+				Description:    sql.NullString{String: item.Description, Valid: item.Description != ""},
+				Requirements:   sql.NullString{String: item.Requirements, Valid: item.Requirements != ""},
+				Location:       sql.NullString{String: item.Location, Valid: item.Location != ""},
+				EmploymentType: sql.NullString{String: item.EmploymentType, Valid: item.EmploymentType != ""},
+			})
+			if err != nil {
+				return err
+			}
+			response.Jobs[item.ID] = job.ID
+		}
+		return nil
+	})
+	if handleDatabaseError(c, err, "Import") {
+		return
+	}
+
+	if dryRun {
+		// The ids of rolled back records don't exist
+		for id := range createdCompanies {
+			response.Companies[id] = 0
+		}
+		for id, created := range createdContacts {
+			if created {
+				response.Contacts[id] = 0
+			}
+		}
+		for id := range response.Applications {
+			response.Applications[id] = 0
+		}
+		for id := range response.Jobs {
+			response.Jobs[id] = 0
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	h.counts.Invalidate(countResourceCompanies, userID)
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.counts.Invalidate(countResourceJobs, userID)
+	for _, application := range applications {
+		h.webhooks.Publish(userID, WebhookEventApplicationCreated, application)
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestImportJSON tests POST /api/import/json
+func TestImportJSON(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-import-json@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	importJSON := func(body map[string]interface{}, query ...string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/import/json"+strings.Join(query, ""), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	body := func() map[string]interface{} {
+		return map[string]interface{}{
+			"version":   1,
+			"companies": []map[string]interface{}{{"id": 10, "name": "Import Co", "industry": "Fintech"}},
+			"contacts":  []map[string]interface{}{{"id": 20, "name": "Rita", "email": "rita@import.example.com", "role": "recruiter"}},
+			"applications": []map[string]interface{}{
+				{"id": 30, "status": "interview", "applied_date": "2024-01-15", "contact_id": 20},
+				{"id": 31, "status": "applied", "applied_date": "2024-02-01", "referred_by_contact_id": 20},
+			},
+			"jobs": []map[string]interface{}{{"id": 40, "application_id": 30, "company_id": 10, "title": "Backend Engineer"}},
+		}
+	}
+
+	w := importJSON(body())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Companies) != 1 || len(response.Contacts) != 1 || len(response.Applications) != 2 || len(response.Jobs) != 1 {
+		t.Fatalf("Expected a mapping for every record, got %+v", response)
+	}
+
+	// References are remapped to the new ids
+	job, err := queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{ID: response.Jobs[40], UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to fetch imported job: %v", err)
+	}
+	if job.ApplicationID != response.Applications[30] || job.CompanyID != response.Companies[10] {
+		t.Errorf("Expected the job to link the imported application and company, got %+v", job)
+	}
+	application, err := queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{ID: response.Applications[31], UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to fetch imported application: %v", err)
+	}
+	if application.ReferredByContactID.Int32 != response.Contacts[20] {
+		t.Errorf("Expected referred_by_contact_id %d, got %+v", response.Contacts[20], application.ReferredByContactID)
+	}
+
+	t.Run("Reuses existing company and contact", func(t *testing.T) {
+		w := importJSON(body())
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var second ImportResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if second.Companies[10] != response.Companies[10] || second.Contacts[20] != response.Contacts[20] {
+			t.Errorf("Expected the company and contact to be reused, got %+v", second)
+		}
+		if second.Applications[30] == response.Applications[30] {
+			t.Error("Expected new applications on every import")
+		}
+	})
+
+	t.Run("Dry run writes nothing", func(t *testing.T) {
+		applicationsBefore, err := queries.GetApplicationsByUserID(ctx, testUser.ID)
+		if err != nil {
+			t.Fatalf("Failed to fetch applications: %v", err)
+		}
+		jobsBefore, err := queries.GetJobsByUserID(ctx, testUser.ID)
+		if err != nil {
+			t.Fatalf("Failed to fetch jobs: %v", err)
+		}
+
+		dryRun := body()
+		dryRun["companies"] = []map[string]interface{}{{"id": 10, "name": "Dry Run Co"}}
+		w := importJSON(dryRun, "?dry_run=true")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var preview ImportResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &preview); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(preview.Companies) != 1 || len(preview.Contacts) != 1 || len(preview.Applications) != 2 || len(preview.Jobs) != 1 {
+			t.Fatalf("Expected a mapping for every record, got %+v", preview)
+		}
+		if preview.Contacts[20] != response.Contacts[20] {
+			t.Errorf("Expected the existing contact %d to be reused, got %d", response.Contacts[20], preview.Contacts[20])
+		}
+		if preview.Companies[10] != 0 || preview.Applications[30] != 0 || preview.Jobs[40] != 0 {
+			t.Errorf("Expected records that would be created to map to 0, got %+v", preview)
+		}
+
+		applicationsAfter, err := queries.GetApplicationsByUserID(ctx, testUser.ID)
+		if err != nil {
+			t.Fatalf("Failed to fetch applications: %v", err)
+		}
+		jobsAfter, err := queries.GetJobsByUserID(ctx, testUser.ID)
+		if err != nil {
+			t.Fatalf("Failed to fetch jobs: %v", err)
+		}
+		if len(applicationsAfter) != len(applicationsBefore) || len(jobsAfter) != len(jobsBefore) {
+			t.Errorf("Expected no new applications or jobs, got %d -> %d applications and %d -> %d jobs",
+				len(applicationsBefore), len(applicationsAfter), len(jobsBefore), len(jobsAfter))
+		}
+		_, err = queries.GetCompanyByNameAndUserID(ctx, database.GetCompanyByNameAndUserIDParams{Btrim: "Dry Run Co", UserID: testUser.ID})
+		if err != sql.ErrNoRows {
+			t.Errorf("Expected the company not to be created, got %v", err)
+		}
+	})
+
+	t.Run("Invalid files", func(t *testing.T) {
+		unknownVersion := body()
+		unknownVersion["version"] = 2
+		danglingReference := body()
+		danglingReference["jobs"] = []map[string]interface{}{{"id": 40, "application_id": 99, "company_id": 10, "title": "Engineer"}}
+		duplicateID := body()
+		duplicateID["contacts"] = []map[string]interface{}{{"id": 20, "name": "A"}, {"id": 20, "name": "B"}}
+		invalidApplication := body()
+		invalidApplication["applications"] = []map[string]interface{}{{"id": 30, "status": "applied", "applied_date": "15/01/2024"}}
+
+		for name, invalid := range map[string]map[string]interface{}{
+			"unknown version":     unknownVersion,
+			"dangling reference":  danglingReference,
+			"duplicate id":        duplicateID,
+			"invalid application": invalidApplication,
+		} {
+			if w := importJSON(invalid); w.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected status %d, got %d. Body: %s", name, http.StatusBadRequest, w.Code, w.Body.String())
+			}
+		}
+	})
+}
+
+// TestImportJSON_ZeroIDsAndWebhooks tests that 0 is a valid file id and that imported applications
+// are announced with application.created webhook events (but not in a dry run)
+func TestImportJSON_ZeroIDsAndWebhooks(t *testing.T) {
+	_, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-import-json-webhooks@example.com")
+	defer cleanup()
+
+	// Not started, so published events stay queued for inspection
+	dispatcher := NewWebhookDispatcher(queries, 1)
+	router := gin.New()
+	cfg := Config{DB: queries, DBConn: db, UseLegacyAuth: true, Webhooks: dispatcher}
+	cfg.SetupRoutes(router)
+
+	importJSON := func(query string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"version":   1,
+			"companies": []map[string]interface{}{{"id": 0, "name": "Zero Co"}},
+			"contacts":  []map[string]interface{}{{"id": 0, "name": "Zed"}},
+			"applications": []map[string]interface{}{
+				{"id": 0, "status": "applied", "applied_date": "2024-03-01", "contact_id": 0},
+				{"id": 1, "status": "applied", "applied_date": "2024-03-02"},
+			},
+			"jobs": []map[string]interface{}{{"id": 0, "application_id": 0, "company_id": 0, "title": "Zero Engineer"}},
+		})
+		req := httptest.NewRequest("POST", "/api/import/json"+query, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	queuedEvents := func() []string {
+		var events []string
+		for len(dispatcher.jobs) > 0 {
+			if job := <-dispatcher.jobs; job.event != nil {
+				events = append(events, job.event.name)
+			}
+		}
+		return events
+	}
+
+	if w := importJSON("?dry_run=true"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if events := queuedEvents(); len(events) != 0 {
+		t.Errorf("Expected no webhook events for a dry run, got %v", events)
+	}
+
+	w := importJSON("")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Companies[0] == 0 || response.Contacts[0] == 0 || response.Applications[0] == 0 || response.Jobs[0] == 0 {
+		t.Errorf("Expected records with id 0 to be imported, got %+v", response)
+	}
+	events := queuedEvents()
+	if len(events) != 2 || events[0] != WebhookEventApplicationCreated || events[1] != WebhookEventApplicationCreated {
+		t.Errorf("Expected an application.created event per application, got %v", events)
+	}
+}
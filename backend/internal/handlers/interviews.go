@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// InterviewHandler handles HTTP requests for an application's interview rounds
+type InterviewHandler struct {
+	queries *database.Queries
+}
+
+// NewInterviewHandler creates a new interview handler
+func NewInterviewHandler(queries *database.Queries) *InterviewHandler {
+	return &InterviewHandler{
+		queries: queries,
+	}
+}
+
+// CreateInterviewRequest represents the JSON body for adding an interview
+type CreateInterviewRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=255"` // e.g. "Phone screen", "Onsite round 2"
+	ScheduledAt string `json:"scheduled_at"`                           // RFC 3339, e.g. 2024-01-15T14:00:00Z (optional)
+}
+
+// UpdateInterviewRequest represents the JSON body for PATCH /api/applications/:id/interviews/:interviewId
+// Omitted fields keep their current value
+type UpdateInterviewRequest struct {
+	Completed *bool   `json:"completed"`
+	Outcome   *string `json:"outcome" binding:"omitempty,oneof=passed failed pending"`
+	Feedback  *string `json:"feedback" binding:"omitempty,max=10000"`
+}
+
+// InterviewsResponse groups an application's interviews into upcoming and completed rounds
+type InterviewsResponse struct {
+	Upcoming  []database.Interview `json:"upcoming"`  // not completed yet, soonest first
+	Completed []database.Interview `json:"completed"` // most recent first, with outcome and feedback
+}
+
+// parseInterviewPath parses :id (application) and, if present, :interviewId from the URL
+func parseInterviewPath(c *gin.Context) (applicationID int32, interviewID int32, ok bool) {
+	return parseApplicationChildPath(c, "interviewId", "Interview")
+}
+
+// GetInterviews handles GET /api/applications/:id/interviews
+// Returns the application's upcoming and completed interviews (verifies ownership)
+func (h *InterviewHandler) GetInterviews(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseInterviewPath(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user (so an unknown application is a 404, not empty lists)
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	upcoming, err := h.queries.GetUpcomingInterviewsByApplicationIDAndUserID(ctx, database.GetUpcomingInterviewsByApplicationIDAndUserIDParams{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch interviews", err)
+		return
+	}
+	completed, err := h.queries.GetCompletedInterviewsByApplicationIDAndUserID(ctx, database.GetCompletedInterviewsByApplicationIDAndUserIDParams{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch interviews", err)
+		return
+	}
+
+	response := InterviewsResponse{Upcoming: upcoming, Completed: completed}
+	if response.Upcoming == nil {
+		response.Upcoming = []database.Interview{}
+	}
+	if response.Completed == nil {
+		response.Completed = []database.Interview{}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateInterview handles POST /api/applications/:id/interviews
+// Adds an upcoming interview to an application (verifies ownership)
+func (h *InterviewHandler) CreateInterview(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, _, ok := parseInterviewPath(c)
+	if !ok {
+		return
+	}
+
+	var req CreateInterviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	var scheduledAt sql.NullTime
+	if req.ScheduledAt != "" {
+		t, err := parseDate(req.ScheduledAt)
+		if err != nil {
+			sendDateError(c, "scheduled_at")
+			return
+		}
+		scheduledAt = sql.NullTime{Time: t, Valid: true}
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user
+	_, err := h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	interview, err := h.queries.CreateInterview(ctx, database.CreateInterviewParams{
+		ApplicationID: applicationID,
+		Title:         req.Title,
+		ScheduledAt:   scheduledAt,
+	})
+	if handleDatabaseError(c, err, "Interview") {
+		return
+	}
+
+	c.JSON(http.StatusCreated, interview)
+}
+
+// UpdateInterview handles PATCH /api/applications/:id/interviews/:interviewId
+// Records whether the interview is completed, its outcome (passed, failed or pending) and feedback notes
+// Setting a passed/failed outcome also marks the interview completed (combining it with "completed": false is a 400)
+func (h *InterviewHandler) UpdateInterview(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, interviewID, ok := parseInterviewPath(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateInterviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+	if req.Completed == nil && req.Outcome == nil && req.Feedback == nil {
+		sendBadRequest(c, "Nothing to update", "Provide at least one of: completed, outcome, feedback")
+		return
+	}
+
+	params := database.UpdateInterviewResultParams{
+		ID:            interviewID,
+		ApplicationID: applicationID,
+		UserID:        userID,
+	}
+	if req.Completed != nil {
+		params.Completed = sql.NullBool{Bool: *req.Completed, Valid: true}
+	}
+	if req.Outcome != nil {
+		params.Outcome = sql.NullString{String: *req.Outcome, Valid: true}
+		if *req.Outcome != "pending" {
+			if req.Completed != nil && !*req.Completed {
+				sendBadRequest(c, "Invalid outcome", "An interview with outcome "+*req.Outcome+" must be completed")
+				return
+			}
+			params.Completed = sql.NullBool{Bool: true, Valid: true}
+		}
+	}
+	if req.Feedback != nil {
+		params.Feedback = sql.NullString{String: *req.Feedback, Valid: true}
+	}
+
+	interview, err := h.queries.UpdateInterviewResult(c.Request.Context(), params)
+	if handleDatabaseError(c, err, "Interview") {
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestInterviews tests the /api/applications/:id/interviews endpoints
+func TestInterviews(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-interviews@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-interviews-other@example.com")
+	defer otherCleanup()
+
+	application := createTestApplication(t, queries, testUser.ID, "interview", "")
+	basePath := "/api/applications/" + strconv.Itoa(int(application.ID)) + "/interviews"
+
+	request := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			buf.Write(jsonBody)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	list := func() InterviewsResponse {
+		w := request("GET", basePath, testUser.Token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response InterviewsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	// Test create (two rounds)
+	var interview database.Interview
+	for _, body := range []map[string]interface{}{
+		{"title": "Phone screen", "scheduled_at": "2024-01-15T14:00:00Z"},
+		{"title": "Onsite"},
+	} {
+		w := request("POST", basePath, testUser.Token, body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+	}
+	if w := request("POST", basePath, testUser.Token, map[string]interface{}{"title": "Bad", "scheduled_at": "tomorrow"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid scheduled_at, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	if response := list(); len(response.Upcoming) != 2 || len(response.Completed) != 0 {
+		t.Fatalf("Expected 2 upcoming and 0 completed interviews, got %d and %d", len(response.Upcoming), len(response.Completed))
+	}
+
+	// Test recording an outcome completes the interview
+	interviewPath := basePath + "/" + strconv.Itoa(int(interview.ID))
+	w := request("PATCH", interviewPath, testUser.Token, map[string]interface{}{"outcome": "passed", "feedback": "Brush up on system design"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !interview.Completed || interview.Outcome != "passed" || interview.Feedback.String != "Brush up on system design" {
+		t.Errorf("Expected a completed, passed interview with feedback, got %+v", interview)
+	}
+
+	response := list()
+	if len(response.Upcoming) != 1 || len(response.Completed) != 1 {
+		t.Fatalf("Expected 1 upcoming and 1 completed interview, got %d and %d", len(response.Upcoming), len(response.Completed))
+	}
+	if response.Completed[0].ID != interview.ID {
+		t.Errorf("Expected completed interview %d, got %d", interview.ID, response.Completed[0].ID)
+	}
+
+	// Test invalid updates
+	if w := request("PATCH", interviewPath, testUser.Token, map[string]interface{}{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for empty update, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := request("PATCH", interviewPath, testUser.Token, map[string]interface{}{"outcome": "maybe"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid outcome, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := request("PATCH", interviewPath, testUser.Token, map[string]interface{}{"outcome": "failed", "completed": false}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an outcome on an uncompleted interview, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test another user cannot see or update the interviews
+	if w := request("GET", basePath, otherUser.Token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's application, got %d", http.StatusNotFound, w.Code)
+	}
+	if w := request("PATCH", interviewPath, otherUser.Token, map[string]interface{}{"completed": false}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's interview, got %d", http.StatusNotFound, w.Code)
+	}
+}
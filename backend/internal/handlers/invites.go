@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
+)
+
+// DefaultInviteExpiryDays is how long an invite code stays valid when the request doesn't say
+const DefaultInviteExpiryDays = 7
+
+// InviteHandler handles invite codes that let new users sign up while registration is disabled
+type InviteHandler struct {
+	queries *database.Queries
+}
+
+// NewInviteHandler creates a new invite handler
+func NewInviteHandler(queries *database.Queries) *InviteHandler {
+	return &InviteHandler{
+		queries: queries,
+	}
+}
+
+// InviteRequest represents the optional JSON body for creating an invite code
+type InviteRequest struct {
+	ExpiresInDays *int `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // defaults to DefaultInviteExpiryDays
+}
+
+// InviteResponse is returned when an invite code is created
+type InviteResponse struct {
+	ID        int32     `json:"id"`
+	Code      string    `json:"code"`
+	Header    string    `json:"header"` // request header the new user sends the code in
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// newInviteCode returns a random 32-character hex invite code
+func newInviteCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CreateInvite handles POST /api/admin/invites (admins only)
+// Generates a single-use invite code; the body is optional: {"expires_in_days": 7}
+// The new user redeems it by sending the code in the X-Invite-Code header on their first request
+func (h *InviteHandler) CreateInvite(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req InviteRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		sendValidationError(c, err)
+		return
+	}
+	expiresInDays := DefaultInviteExpiryDays
+	if req.ExpiresInDays != nil {
+		expiresInDays = *req.ExpiresInDays
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		sendInternalError(c, "Failed to generate invite code", err)
+		return
+	}
+
+	invite, err := h.queries.CreateInvite(c.Request.Context(), database.CreateInviteParams{
+		Code:      code,
+		CreatedBy: sql.NullInt32{Int32: userID, Valid: true},
+		ExpiresAt: sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, expiresInDays), Valid: true},
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to create invite", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, InviteResponse{
+		ID:        invite.ID,
+		Code:      invite.Code,
+		Header:    middleware.InviteCodeHeader,
+		ExpiresAt: invite.ExpiresAt.Time,
+	})
+}
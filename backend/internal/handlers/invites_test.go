@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateInvite tests that only admins can create invite codes and that the codes can be redeemed once
+func TestCreateInvite(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	adminUser, cleanupAdmin := createTestUser(t, queries, db, "test-invites-admin@example.com")
+	defer cleanupAdmin()
+	regularUser, cleanupRegular := createTestUser(t, queries, db, "test-invites-user@example.com")
+	defer cleanupRegular()
+	_, err := db.Exec("UPDATE users SET role = 'admin' WHERE id = $1", adminUser.ID)
+	require.NoError(t, err)
+	defer db.Exec("DELETE FROM invites WHERE created_by = $1", adminUser.ID)
+
+	createInvite := func(token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/admin/invites", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Non-admins can't create invites
+	assert.Equal(t, http.StatusForbidden, createInvite(regularUser.Token, "").Code)
+	assert.Equal(t, http.StatusBadRequest, createInvite(adminUser.Token, `{"expires_in_days": 0}`).Code)
+
+	// Without a body the code expires after DefaultInviteExpiryDays
+	w := createInvite(adminUser.Token, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var invite InviteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invite))
+	assert.Len(t, invite.Code, 32)
+	assert.Equal(t, middleware.InviteCodeHeader, invite.Header)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, DefaultInviteExpiryDays), invite.ExpiresAt, time.Minute)
+
+	newUserParams := func(email string) database.CreateUserWithClerkIDParams {
+		return database.CreateUserWithClerkIDParams{
+			ClerkUserID: sql.NullString{String: "clerk-" + email, Valid: true},
+			Email:       email,
+		}
+	}
+	defer db.Exec("DELETE FROM users WHERE email LIKE 'test-invites-new-%@example.com'")
+
+	// Unknown codes are rejected
+	_, err = middleware.CreateUserWithInvite(ctx, db, queries, "not-a-code", newUserParams("test-invites-new-1@example.com"))
+	assert.ErrorIs(t, err, middleware.ErrInviteInvalid)
+
+	// The code can be redeemed once
+	newUser, err := middleware.CreateUserWithInvite(ctx, db, queries, invite.Code, newUserParams("test-invites-new-1@example.com"))
+	require.NoError(t, err)
+	_, err = middleware.CreateUserWithInvite(ctx, db, queries, invite.Code, newUserParams("test-invites-new-2@example.com"))
+	assert.ErrorIs(t, err, middleware.ErrInviteUsed)
+	var usedBy sql.NullInt32
+	require.NoError(t, db.QueryRow("SELECT used_by FROM invites WHERE id = $1", invite.ID).Scan(&usedBy))
+	assert.Equal(t, newUser.ID, usedBy.Int32)
+
+	// Expired codes are rejected and don't create a user
+	w = createInvite(adminUser.Token, `{"expires_in_days": 1}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invite))
+	_, err = db.Exec("UPDATE invites SET expires_at = $1 WHERE id = $2", time.Now().UTC().Add(-time.Hour), invite.ID)
+	require.NoError(t, err)
+	_, err = middleware.CreateUserWithInvite(ctx, db, queries, invite.Code, newUserParams("test-invites-new-3@example.com"))
+	assert.ErrorIs(t, err, middleware.ErrInviteExpired)
+	_, err = queries.GetUserByEmail(ctx, "test-invites-new-3@example.com")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
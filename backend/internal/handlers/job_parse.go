@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/html"
+)
+
+const (
+	// jobParseTimeout bounds fetching a job page, so a slow site can't hold the request
+	jobParseTimeout = 10 * time.Second
+	// jobParseMaxBody caps how much of a job page is read (metadata lives in the head)
+	jobParseMaxBody = 2 << 20
+	// jobParseMaxRedirects caps redirects followed when fetching a job page
+	jobParseMaxRedirects = 5
+)
+
+// errNonPublicAddress is returned when a job URL resolves to a loopback, private or link-local address
+var errNonPublicAddress = errors.New("address is not publicly routable")
+
+// jobPageClient fetches job pages; it refuses to connect to non-public addresses (no SSRF into the private network)
+var jobPageClient = newJobPageClient()
+
+// newJobPageClient creates the HTTP client used by ParseJobURL
+// The address check runs on every connection, so redirects and DNS answers are covered too
+func newJobPageClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: jobParseTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errNonPublicAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: jobParseTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: jobParseTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= jobParseMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", jobParseMaxRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// isPublicIP reports whether ip is a globally routable unicast address
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
+
+// ParseJobURLRequest represents the JSON body for POST /api/jobs/parse
+type ParseJobURLRequest struct {
+	URL string `json:"url" binding:"required,url,max=2000"`
+}
+
+// JobDraft is a job prefilled from its posting page; it is not saved
+// Partial is true when the page couldn't be fetched or read, or the title or company wasn't found;
+// Warning then says why (fields that were found are still filled in)
+type JobDraft struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	CompanyName string `json:"company_name"`
+	Source      string `json:"source,omitempty"` // application source guessed from the host (linkedin, indeed)
+	Partial     bool   `json:"partial"`
+	Warning     string `json:"warning,omitempty"`
+}
+
+// ParseJobURL handles POST /api/jobs/parse
+// Fetches a job posting URL (e.g. LinkedIn or Indeed) and returns a JobDraft with the title and company,
+// read from JSON-LD JobPosting data, OpenGraph tags or the page title (best effort)
+// Fetch failures, timeouts and non-HTML responses still return 200 with a partial draft
+func (h *JobHandler) ParseJobURL(c *gin.Context) {
+	// Parse JSON body
+	var req ParseJobURLRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	if _, ok := requireAuth(c); !ok {
+		return
+	}
+
+	pageURL, err := url.Parse(req.URL)
+	if err != nil || (pageURL.Scheme != "http" && pageURL.Scheme != "https") || pageURL.Hostname() == "" {
+		sendBadRequest(c, "Invalid url", "url must be an http or https URL")
+		return
+	}
+
+	draft := JobDraft{URL: pageURL.String(), Source: jobSourceForHost(pageURL.Hostname())}
+	draft.Warning = fetchJobDraft(c.Request.Context(), pageURL.String(), &draft)
+	if draft.Warning == "" && (draft.Title == "" || draft.CompanyName == "") {
+		draft.Warning = "Could not find the job title and company on the page"
+	}
+	draft.Partial = draft.Warning != ""
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// jobSourceForHost maps a job board host to an application source ("" if unknown)
+func jobSourceForHost(host string) string {
+	host = strings.ToLower(host)
+	switch {
+	case host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com"):
+		return "linkedin"
+	case strings.HasPrefix(host, "indeed.") || strings.Contains(host, ".indeed."):
+		return "indeed"
+	}
+	return ""
+}
+
+// fetchJobDraft fetches pageURL and fills draft from its HTML
+// Returns a user-facing warning when the page can't be fetched or isn't HTML, "" otherwise
+func fetchJobDraft(ctx context.Context, pageURL string, draft *JobDraft) string {
+	ctx, cancel := context.WithTimeout(ctx, jobParseTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "Could not fetch the page"
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "ResumeControl/1.0 (+job quick add)")
+
+	resp, err := jobPageClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+			return "Timed out fetching the page"
+		}
+		return "Could not fetch the page"
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Sprintf("The page returned HTTP %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "The page is not HTML"
+	}
+
+	meta := readJobPageMetadata(io.LimitReader(resp.Body, jobParseMaxBody))
+	draft.Title, draft.CompanyName = meta.jobTitleAndCompany()
+	return ""
+}
+
+// isTimeout reports whether err is a network timeout
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// jobPageMetadata holds the parts of a job page used to find its title and company
+type jobPageMetadata struct {
+	title      string   // <title>
+	ogTitle    string   // og:title (or twitter:title)
+	ogSiteName string   // og:site_name
+	jsonLD     []string // contents of application/ld+json scripts
+}
+
+// readJobPageMetadata tokenizes an HTML page and collects its metadata, stopping at the end of the input
+// Malformed HTML is read as far as possible
+func readJobPageMetadata(r io.Reader) jobPageMetadata {
+	var meta jobPageMetadata
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "title":
+				if meta.title == "" && tokenizer.Next() == html.TextToken {
+					meta.title = cleanJobText(string(tokenizer.Text()))
+				}
+			case "meta":
+				key, content := "", ""
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = cleanJobText(attr.Val)
+					}
+				}
+				switch key {
+				case "og:title":
+					meta.ogTitle = content
+				case "twitter:title":
+					if meta.ogTitle == "" {
+						meta.ogTitle = content
+					}
+				case "og:site_name":
+					meta.ogSiteName = content
+				}
+			case "script":
+				for _, attr := range token.Attr {
+					if attr.Key == "type" && strings.EqualFold(attr.Val, "application/ld+json") {
+						if tokenizer.Next() == html.TextToken {
+							meta.jsonLD = append(meta.jsonLD, string(tokenizer.Text()))
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// cleanJobText collapses whitespace in page text
+func cleanJobText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// jobBoardSiteNames are og:site_name values of job boards, which name the board rather than the employer
+var jobBoardSiteNames = map[string]bool{
+	"linkedin": true, "indeed": true, "indeed.com": true, "glassdoor": true,
+	"monster": true, "ziprecruiter": true, "wellfound": true,
+}
+
+var (
+	// linkedInTitlePattern matches LinkedIn titles like "Acme hiring Software Engineer in Berlin | LinkedIn"
+	linkedInTitlePattern = regexp.MustCompile(`^(.+?) hiring (.+?)(?: in .+?)?(?: \| LinkedIn)?$`)
+	// indeedTitlePattern matches Indeed titles like "Software Engineer - Acme - Berlin | Indeed.com"
+	indeedTitlePattern = regexp.MustCompile(`^(.+?) - (.+?)(?: - .+?)? \| Indeed(?:\.[a-z.]+)?$`)
+)
+
+// jobTitleAndCompany picks the job title and company from the metadata, most reliable source first
+func (m jobPageMetadata) jobTitleAndCompany() (title, company string) {
+	for _, data := range m.jsonLD {
+		if title, company = jobPostingFromJSONLD(data); title != "" {
+			return title, company
+		}
+	}
+
+	for _, pageTitle := range []string{m.ogTitle, m.title} {
+		if match := linkedInTitlePattern.FindStringSubmatch(pageTitle); match != nil {
+			return match[2], match[1]
+		}
+		if match := indeedTitlePattern.FindStringSubmatch(pageTitle); match != nil {
+			return match[1], match[2]
+		}
+	}
+
+	title = m.ogTitle
+	if title == "" {
+		title = m.title
+	}
+	if !jobBoardSiteNames[strings.ToLower(m.ogSiteName)] {
+		company = m.ogSiteName
+	}
+	return title, company
+}
+
+// jobPostingFromJSONLD returns the title and hiring organization of a schema.org JobPosting
+// data may hold one object, an array of objects or an @graph; returns "" when there is no JobPosting
+func jobPostingFromJSONLD(data string) (title, company string) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(data), &parsed); err != nil {
+		return "", ""
+	}
+
+	var nodes []interface{}
+	switch value := parsed.(type) {
+	case []interface{}:
+		nodes = value
+	case map[string]interface{}:
+		nodes = []interface{}{value}
+		if graph, ok := value["@graph"].([]interface{}); ok {
+			nodes = append(nodes, graph...)
+		}
+	}
+
+	for _, node := range nodes {
+		object, ok := node.(map[string]interface{})
+		if !ok || !isJSONLDType(object["@type"], "JobPosting") {
+			continue
+		}
+		title, _ = object["title"].(string)
+		if organization, ok := object["hiringOrganization"].(map[string]interface{}); ok {
+			company, _ = organization["name"].(string)
+		}
+		return cleanJobText(html.UnescapeString(title)), cleanJobText(html.UnescapeString(company))
+	}
+	return "", ""
+}
+
+// isJSONLDType reports whether a JSON-LD @type value (a string or an array of strings) includes name
+func isJSONLDType(value interface{}, name string) bool {
+	switch typ := value.(type) {
+	case string:
+		return typ == name
+	case []interface{}:
+		for _, item := range typ {
+			if item == name {
+				return true
+			}
+		}
+	}
+	return false
+}
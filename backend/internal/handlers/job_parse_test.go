@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestJobTitleAndCompany tests extracting the job title and company from job page HTML
+func TestJobTitleAndCompany(t *testing.T) {
+	tests := []struct {
+		name            string
+		page            string
+		expectedTitle   string
+		expectedCompany string
+	}{
+		{
+			name: "JSON-LD JobPosting",
+			page: `<html><head><title>Jobs</title><script type="application/ld+json">
+				{"@context": "https://schema.org", "@type": "JobPosting", "title": "Backend Engineer",
+				 "hiringOrganization": {"@type": "Organization", "name": "Acme &amp; Co"}}
+				</script></head></html>`,
+			expectedTitle:   "Backend Engineer",
+			expectedCompany: "Acme & Co",
+		},
+		{
+			name: "JSON-LD graph",
+			page: `<script type="application/ld+json">{"@graph": [{"@type": "WebPage"},
+				{"@type": ["JobPosting"], "title": "Data Analyst", "hiringOrganization": {"name": "Globex"}}]}</script>`,
+			expectedTitle:   "Data Analyst",
+			expectedCompany: "Globex",
+		},
+		{
+			name:            "LinkedIn OpenGraph title",
+			page:            `<meta property="og:title" content="Acme hiring Software Engineer in Berlin, Germany | LinkedIn"><meta property="og:site_name" content="LinkedIn">`,
+			expectedTitle:   "Software Engineer",
+			expectedCompany: "Acme",
+		},
+		{
+			name:            "Indeed page title",
+			page:            `<title>Frontend Developer - Initech - Remote | Indeed.com</title>`,
+			expectedTitle:   "Frontend Developer",
+			expectedCompany: "Initech",
+		},
+		{
+			name:            "Company careers page",
+			page:            `<meta property="og:site_name" content="Umbrella"><meta name="twitter:title" content="  Product   Manager ">`,
+			expectedTitle:   "Product Manager",
+			expectedCompany: "Umbrella",
+		},
+		{
+			name:          "Job board site name is not the company",
+			page:          `<meta property="og:site_name" content="Glassdoor"><title>QA Engineer</title>`,
+			expectedTitle: "QA Engineer",
+		},
+		{
+			name: "Nothing found",
+			page: `<p>Hello</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, company := readJobPageMetadata(strings.NewReader(tt.page)).jobTitleAndCompany()
+			if title != tt.expectedTitle {
+				t.Errorf("Expected title %q, got %q", tt.expectedTitle, title)
+			}
+			if company != tt.expectedCompany {
+				t.Errorf("Expected company %q, got %q", tt.expectedCompany, company)
+			}
+		})
+	}
+}
+
+// TestIsPublicIP tests that job pages can't be fetched from loopback, private or link-local addresses
+func TestIsPublicIP(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"192.168.0.10":    false,
+		"169.254.169.254": false,
+		"::1":             false,
+		"fd00::1":         false,
+		"0.0.0.0":         false,
+	} {
+		if got := isPublicIP(net.ParseIP(address)); got != expected {
+			t.Errorf("isPublicIP(%s): expected %v, got %v", address, expected, got)
+		}
+	}
+}
+
+// TestParseJobURL tests POST /api/jobs/parse against a local job page server
+func TestParseJobURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head><meta property="og:title" content="Acme hiring Go Developer | LinkedIn"></head></html>`))
+		case "/pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("user_id", int32(1)) })
+	router.POST("/api/jobs/parse", NewJobHandler(nil, nil, nil).ParseJobURL)
+
+	parse := func(pageURL string) (int, JobDraft) {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"url": pageURL})
+		req := httptest.NewRequest("POST", "/api/jobs/parse", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var draft JobDraft
+		json.Unmarshal(w.Body.Bytes(), &draft)
+		return w.Code, draft
+	}
+
+	// The default client refuses to connect to the loopback test server
+	code, draft := parse(server.URL + "/job")
+	if code != http.StatusOK || !draft.Partial || draft.Warning != "Could not fetch the page" {
+		t.Errorf("Expected a partial draft for a loopback URL, got %d %+v", code, draft)
+	}
+
+	defaultClient := jobPageClient
+	defer func() { jobPageClient = defaultClient }()
+	jobPageClient = &http.Client{Timeout: 100 * time.Millisecond}
+
+	code, draft = parse(server.URL + "/job")
+	if code != http.StatusOK || draft.Partial {
+		t.Fatalf("Expected a complete draft, got %d %+v", code, draft)
+	}
+	if draft.Title != "Go Developer" || draft.CompanyName != "Acme" {
+		t.Errorf("Expected Go Developer at Acme, got %q at %q", draft.Title, draft.CompanyName)
+	}
+
+	// Failures still return a partial draft
+	for path, warning := range map[string]string{
+		"/pdf":     "The page is not HTML",
+		"/slow":    "Timed out fetching the page",
+		"/missing": "The page returned HTTP 404",
+	} {
+		code, draft = parse(server.URL + path)
+		if code != http.StatusOK || !draft.Partial || draft.Warning != warning {
+			t.Errorf("%s: expected a partial draft with warning %q, got %d %+v", path, warning, code, draft)
+		}
+	}
+
+	// Only http(s) URLs are fetched
+	if code, _ = parse("ftp://example.com/job"); code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an ftp URL, got %d", http.StatusBadRequest, code)
+	}
+}
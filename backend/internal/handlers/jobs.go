@@ -2,26 +2,44 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
 type JobHandler struct {
+	db      *sql.DB
 	queries *database.Queries
+	counts  *CountCache
 }
 
-func NewJobHandler(queries *database.Queries) *JobHandler {
+func NewJobHandler(db *sql.DB, queries *database.Queries, counts *CountCache) *JobHandler {
 	return &JobHandler{
+		db:      db,
 		queries: queries,
+		counts:  counts,
 	}
 }
 
+// validJobEmploymentTypes lists the allowed values for a job's employment type
+// Keep in sync with the oneof tags on the create/update request structs
+var validJobEmploymentTypes = map[string]bool{
+	"full_time":  true,
+	"part_time":  true,
+	"contract":   true,
+	"internship": true,
+	"temporary":  true,
+}
+
 // GetAllJobs handles GET /api/jobs
 // Returns all jobs or paginated jobs if page/limit query params are provided
 // Query params: ?page=1&limit=10 (optional, backward compatible)
+// Supports ?employment_type=contract to filter by employment type
+// Supports ?sort=title:asc (fields: created_at, title); defaults to DEFAULT_SORT_JOBS
 func (h *JobHandler) GetAllJobs(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
@@ -31,6 +49,24 @@ func (h *JobHandler) GetAllJobs(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
+	listSort, ok := resolveListSort(c, sortResourceJobs)
+	if !ok {
+		return
+	}
+
+	// Employment type filter and non-default sorts use the combined filtered query
+	if employmentType := c.Query("employment_type"); employmentType != "" || !listSort.isBuiltin(sortResourceJobs) {
+		if employmentType != "" && !validJobEmploymentTypes[employmentType] {
+			sendBadRequest(c, "Invalid employment_type", "employment_type must be one of: full_time, part_time, contract, internship, temporary")
+			return
+		}
+		h.getFilteredJobs(c, userID, jobListFilters{
+			EmploymentType: employmentType,
+			Sort:           listSort,
+		})
+		return
+	}
+
 	// Check if pagination parameters are provided
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
@@ -61,8 +97,10 @@ func (h *JobHandler) GetAllJobs(c *gin.Context) {
 		return
 	}
 
-	// Fetch total count
-	totalCount, err := h.queries.CountJobsByUserID(ctx, userID)
+	// Fetch total count (cached per user)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceJobs, userID, ""), func() (int64, error) {
+		return h.queries.CountJobsByUserID(ctx, userID)
+	})
 	if err != nil {
 		sendInternalError(c, "Failed to count jobs", err)
 		return
@@ -86,6 +124,116 @@ func (h *JobHandler) GetAllJobs(c *gin.Context) {
 	})
 }
 
+// CountJobs handles GET /api/jobs/count
+// Returns {"count": n} for the user's jobs, honoring the ?employment_type= list filter
+// Shares the pagination count cache with the list endpoint
+func (h *JobHandler) CountJobs(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	filters := jobListFilters{EmploymentType: c.Query("employment_type")}
+	if filters.EmploymentType != "" && !validJobEmploymentTypes[filters.EmploymentType] {
+		sendBadRequest(c, "Invalid employment_type", "employment_type must be one of: full_time, part_time, contract, internship, temporary")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	count, err := h.counts.Count(countCacheKey(countResourceJobs, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountJobsFilteredByUserID(ctx, database.CountJobsFilteredByUserIDParams{
+			UserID:         userID,
+			EmploymentType: sql.NullString{String: filters.EmploymentType, Valid: filters.EmploymentType != ""},
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count jobs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// jobListFilters holds the optional filters for the filtered jobs list
+// Empty fields are not applied
+type jobListFilters struct {
+	EmploymentType string
+	Sort           ListSort // order of the results; not part of cacheKey since it doesn't change counts
+}
+
+// cacheKey returns the count cache filter segment for these filters
+func (f jobListFilters) cacheKey() string {
+	return "employment_type=" + f.EmploymentType
+}
+
+// getFilteredJobs responds with the user's jobs matching filters
+// Returns a bare array without page/limit (backward compatible), otherwise a PaginatedResponse
+func (h *JobHandler) getFilteredJobs(c *gin.Context, userID int32, filters jobListFilters) {
+	ctx := c.Request.Context()
+
+	employmentType := sql.NullString{String: filters.EmploymentType, Valid: filters.EmploymentType != ""}
+
+	// No pagination params: return all matching jobs
+	if c.Query("page") == "" && c.Query("limit") == "" {
+		jobs, err := h.queries.GetJobsFilteredByUserID(ctx, database.GetJobsFilteredByUserIDParams{
+			UserID:         userID,
+			EmploymentType: employmentType,
+			SortKey:        filters.Sort.key(),
+		})
+		if err != nil {
+			sendInternalError(c, "Failed to fetch jobs", err)
+			return
+		}
+		c.JSON(http.StatusOK, jobs)
+		return
+	}
+
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	jobs, err := h.queries.GetJobsFilteredByUserID(ctx, database.GetJobsFilteredByUserIDParams{
+		UserID:         userID,
+		EmploymentType: employmentType,
+		SortKey:        filters.Sort.key(),
+		RowLimit:       sql.NullInt32{Int32: params.Limit, Valid: true},
+		RowOffset:      offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch jobs", err)
+		return
+	}
+
+	// Fetch total count (cached per user+filters)
+	totalCount, err := h.counts.Count(countCacheKey(countResourceJobs, userID, filters.cacheKey()), func() (int64, error) {
+		return h.queries.CountJobsFilteredByUserID(ctx, database.CountJobsFilteredByUserIDParams{
+			UserID:         userID,
+			EmploymentType: employmentType,
+		})
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count jobs", err)
+		return
+	}
+
+	// Convert to interface{} for paginated response
+	data := make([]interface{}, len(jobs))
+	for i, job := range jobs {
+		data[i] = job
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
 // GetJobByID handles GET /api/jobs/:id
 // Returns a single job by ID (verifies ownership through application)
 func (h *JobHandler) GetJobByID(c *gin.Context) {
@@ -113,6 +261,7 @@ func (h *JobHandler) GetJobByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, job.UpdatedAt)
 	c.JSON(http.StatusOK, job)
 }
 
@@ -150,12 +299,13 @@ func (h *JobHandler) GetJobsByCompanyID(c *gin.Context) {
 // CreateJobRequest represents the JSON body for creating a job
 // Jobs now belong to applications (application_id is required)
 type CreateJobRequest struct {
-	ApplicationID int32  `json:"application_id" binding:"required"`
-	CompanyID     int32  `json:"company_id" binding:"required"`
-	Title         string `json:"title" binding:"required,min=1,max=255"`
-	Description   string `json:"description" binding:"omitempty,max=10000"`
-	Requirements  string `json:"requirements" binding:"omitempty,max=10000"`
-	Location      string `json:"location" binding:"omitempty,max=255"`
+	ApplicationID  int32  `json:"application_id" binding:"required"`
+	CompanyID      int32  `json:"company_id" binding:"required"`
+	Title          string `json:"title" binding:"required,min=1,max=255"`
+	Description    string `json:"description" binding:"omitempty,max=10000"`
+	Requirements   string `json:"requirements" binding:"omitempty,max=10000"`
+	Location       string `json:"location" binding:"omitempty,max=255"`
+	EmploymentType string `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
 }
 
 // CreateJob handles POST /api/jobs
@@ -163,7 +313,7 @@ type CreateJobRequest struct {
 func (h *JobHandler) CreateJob(c *gin.Context) {
 	// Parse JSON body
 	var req CreateJobRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
@@ -182,6 +332,10 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		ID:     req.ApplicationID,
 		UserID: userID,
 	})
+	if err == sql.ErrNoRows {
+		sendReferenceNotFound(c, "application_id", "Application")
+		return
+	}
 	if handleDatabaseError(c, err, "Application") {
 		return
 	}
@@ -191,32 +345,41 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		ID:     req.CompanyID,
 		UserID: userID,
 	})
+	if err == sql.ErrNoRows {
+		sendReferenceNotFound(c, "company_id", "Company")
+		return
+	}
 	if handleDatabaseError(c, err, "Company") {
 		return
 	}
 
 	// Create job (now requires application_id)
 	job, err := h.queries.CreateJob(ctx, database.CreateJobParams{
-		ApplicationID: req.ApplicationID,
-		CompanyID:     req.CompanyID,
-		Title:         req.Title,
-		Description:   sql.NullString{String: req.Description, Valid: req.Description != ""},
-		Requirements:  sql.NullString{String: req.Requirements, Valid: req.Requirements != ""},
-		Location:      sql.NullString{String: req.Location, Valid: req.Location != ""},
+		ApplicationID:  req.ApplicationID,
+		CompanyID:      req.CompanyID,
+		Title:          req.Title,
+		Description:    sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Requirements:   sql.NullString{String: req.Requirements, Valid: req.Requirements != ""},
+		Location:       sql.NullString{String: req.Location, Valid: req.Location != ""},
+		EmploymentType: sql.NullString{String: req.EmploymentType, Valid: req.EmploymentType != ""},
 	})
 	if handleDatabaseError(c, err, "Job") {
 		return
 	}
+	h.counts.Invalidate(countResourceJobs, userID)
+	// Application counts filtered by missing_job depend on which applications have a job
+	h.counts.Invalidate(countResourceApplications, userID)
 
 	c.JSON(http.StatusCreated, job)
 }
 
 // UpdateJobRequest represents the JSON body for updating a job
 type UpdateJobRequest struct {
-	Title        string `json:"title" binding:"required,min=1,max=255"`
-	Description  string `json:"description" binding:"omitempty,max=10000"`
-	Requirements string `json:"requirements" binding:"omitempty,max=10000"`
-	Location     string `json:"location" binding:"omitempty,max=255"`
+	Title          string         `json:"title" binding:"required,min=1,max=255"`
+	Description    string         `json:"description" binding:"omitempty,max=10000"`
+	Requirements   string         `json:"requirements" binding:"omitempty,max=10000"`
+	Location       string         `json:"location" binding:"omitempty,max=255"`
+	EmploymentType optionalString `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"` // Omitted leaves it unchanged, null or "" clears it
 }
 
 // UpdateJob handles PUT /api/jobs/:id
@@ -232,11 +395,17 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 
 	// Parse JSON body
 	var req UpdateJobRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
 
+	// Optional optimistic concurrency: If-Match carries the version the client last saw
+	expectedUpdatedAt, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Get user_id from context (set by AuthMiddleware)
 	userID, ok := requireAuth(c)
 	if !ok {
@@ -246,22 +415,285 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 	// Get request context
 	ctx := c.Request.Context()
 
+	// Check if job exists and belongs to user (through application)
+	// Without this, another user's job id would surface as a confusing update failure
+	_, err = h.queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Job") {
+		return
+	}
+
 	// Update job (verifies ownership through application's user_id)
 	job, err := h.queries.UpdateJob(ctx, database.UpdateJobParams{
-		ID:           int32(id),
-		Title:        req.Title,
-		Description:  sql.NullString{String: req.Description, Valid: req.Description != ""},
-		Requirements: sql.NullString{String: req.Requirements, Valid: req.Requirements != ""},
-		Location:     sql.NullString{String: req.Location, Valid: req.Location != ""},
-		UserID:       userID,
+		ID:                 int32(id),
+		Title:              req.Title,
+		Description:        sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Requirements:       sql.NullString{String: req.Requirements, Valid: req.Requirements != ""},
+		Location:           sql.NullString{String: req.Location, Valid: req.Location != ""},
+		KeepEmploymentType: !req.EmploymentType.Set,
+		EmploymentType:     sql.NullString{String: req.EmploymentType.Value, Valid: req.EmploymentType.Value != ""},
+		UserID:             userID,
+		ExpectedUpdatedAt:  expectedUpdatedAt,
 	})
-	if handleDatabaseError(c, err, "Job") {
+	if handleConditionalUpdateError(c, err, expectedUpdatedAt, "Job", func() error {
+		_, err := h.queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		return err
+	}) {
+		return
+	}
+
+	// Employment type may have changed, which shifts the filtered counts
+	h.counts.Invalidate(countResourceJobs, userID)
+
+	setETag(c, job.UpdatedAt)
+	c.JSON(http.StatusOK, job)
+}
+
+// MoveJobRequest represents the JSON body for PATCH /api/jobs/:id
+type MoveJobRequest struct {
+	ApplicationID int32 `json:"application_id" binding:"required"`
+}
+
+// errTargetApplicationNotFound and errApplicationHasJob abort a job move transaction
+var (
+	errTargetApplicationNotFound = errors.New("target application not found")
+	errApplicationHasJob         = errors.New("target application already has a job")
+)
+
+// MoveJob handles PATCH /api/jobs/:id
+// Moves a job to another of the user's applications (fixes a job linked to the wrong application)
+// Returns 409 if the target application already has a job (one job per application)
+// Honors If-Match like UpdateJob: 412 if the job changed since the client's ETag
+func (h *JobHandler) MoveJob(c *gin.Context) {
+	// Get ID from URL parameter
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid job ID", "ID must be a number")
+		return
+	}
+
+	// Parse JSON body
+	var req MoveJobRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Optional optimistic concurrency: If-Match carries the version the client last saw
+	expectedUpdatedAt, ok := requireIfMatch(c)
+	if !ok {
 		return
 	}
 
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	// Get request context
+	ctx := c.Request.Context()
+
+	var job database.Job
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if job exists and belongs to user (through application)
+		var err error
+		job, err = qtx.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		if job.ApplicationID == req.ApplicationID {
+			if expectedUpdatedAt.Valid && !job.UpdatedAt.Time.Equal(expectedUpdatedAt.Time) {
+				return sql.ErrNoRows // stale, like a conditional update that matched no row
+			}
+			return nil // already linked, nothing to move
+		}
+
+		// Validate target application exists and belongs to this user
+		_, err = qtx.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+			ID:     req.ApplicationID,
+			UserID: userID,
+		})
+		if err == sql.ErrNoRows {
+			return errTargetApplicationNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := qtx.GetJobsByApplicationIDAndUserID(ctx, database.GetJobsByApplicationIDAndUserIDParams{
+			ApplicationID: req.ApplicationID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return errApplicationHasJob
+		}
+
+		job, err = qtx.MoveJobToApplication(ctx, database.MoveJobToApplicationParams{
+			ApplicationID:     req.ApplicationID,
+			ID:                int32(id),
+			UserID:            userID,
+			ExpectedUpdatedAt: expectedUpdatedAt,
+		})
+		return err
+	})
+	if errors.Is(err, errTargetApplicationNotFound) {
+		sendReferenceNotFound(c, "application_id", "Application")
+		return
+	}
+	if errors.Is(err, errApplicationHasJob) || (err != nil && strings.Contains(strings.ToLower(err.Error()), "unique")) {
+		// The unique check covers a job created for the target between our check and the update
+		sendError(c, http.StatusConflict, "Application already has a job", "Move or delete the target application's job first")
+		return
+	}
+	if handleConditionalUpdateError(c, err, expectedUpdatedAt, "Job", func() error {
+		_, err := h.queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		return err
+	}) {
+		return
+	}
+	// The job left one application and joined another, which changes the missing_job counts
+	h.counts.Invalidate(countResourceApplications, userID)
+
+	setETag(c, job.UpdatedAt)
 	c.JSON(http.StatusOK, job)
 }
 
+// CloneJobRequest represents the JSON body for POST /api/jobs/:id/clone
+type CloneJobRequest struct {
+	ApplicationID int32  `json:"application_id" binding:"required"`
+	CompanyID     *int32 `json:"company_id"` // Optional; defaults to the source job's company
+}
+
+// errTargetCompanyNotFound aborts a job clone transaction
+var errTargetCompanyNotFound = errors.New("target company not found")
+
+// CloneJob handles POST /api/jobs/:id/clone
+// Copies a job (title, description, requirements, location, employment type) to another of the user's applications,
+// optionally at a different company; saves re-typing descriptions shared by similar roles
+// Returns 409 if the target application already has a job (one job per application)
+func (h *JobHandler) CloneJob(c *gin.Context) {
+	// Get ID from URL parameter
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid job ID", "ID must be a number")
+		return
+	}
+
+	// Parse JSON body
+	var req CloneJobRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	// Get request context
+	ctx := c.Request.Context()
+
+	var job database.Job
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if the source job exists and belongs to user (through application)
+		source, err := qtx.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Validate target application exists and belongs to this user
+		_, err = qtx.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+			ID:     req.ApplicationID,
+			UserID: userID,
+		})
+		if err == sql.ErrNoRows {
+			return errTargetApplicationNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		companyID := source.CompanyID
+		if req.CompanyID != nil {
+			// Validate the override company exists and belongs to this user
+			_, err = qtx.GetCompanyByIDAndUserID(ctx, database.GetCompanyByIDAndUserIDParams{
+				ID:     *req.CompanyID,
+				UserID: userID,
+			})
+			if err == sql.ErrNoRows {
+				return errTargetCompanyNotFound
+			}
+			if err != nil {
+				return err
+			}
+			companyID = *req.CompanyID
+		}
+
+		existing, err := qtx.GetJobsByApplicationIDAndUserID(ctx, database.GetJobsByApplicationIDAndUserIDParams{
+			ApplicationID: req.ApplicationID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return errApplicationHasJob
+		}
+
+		job, err = qtx.CreateJob(ctx, database.CreateJobParams{
+			ApplicationID:  req.ApplicationID,
+			CompanyID:      companyID,
+			Title:          source.Title,
+			Description:    source.Description,
+			Requirements:   source.Requirements,
+			Location:       source.Location,
+			EmploymentType: source.EmploymentType,
+		})
+		return err
+	})
+	if errors.Is(err, errTargetApplicationNotFound) {
+		sendReferenceNotFound(c, "application_id", "Application")
+		return
+	}
+	if errors.Is(err, errTargetCompanyNotFound) {
+		sendReferenceNotFound(c, "company_id", "Company")
+		return
+	}
+	if errors.Is(err, errApplicationHasJob) || (err != nil && strings.Contains(strings.ToLower(err.Error()), "unique")) {
+		// The unique check covers a job created for the target between our check and the insert
+		sendError(c, http.StatusConflict, "Application already has a job", "Move or delete the target application's job first")
+		return
+	}
+	if handleDatabaseError(c, err, "Job") {
+		return
+	}
+	h.counts.Invalidate(countResourceJobs, userID)
+	// Application counts filtered by missing_job depend on which applications have a job
+	h.counts.Invalidate(countResourceApplications, userID)
+
+	c.JSON(http.StatusCreated, job)
+}
+
 // DeleteJob handles DELETE /api/jobs/:id
 // Deletes a job by ID
 func (h *JobHandler) DeleteJob(c *gin.Context) {
@@ -282,26 +714,35 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 	// Get request context
 	ctx := c.Request.Context()
 
-	// Check if job exists and belongs to user (through application)
-	_, err = h.queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
-		ID:     int32(id),
-		UserID: userID,
-	})
-	if handleDatabaseError(c, err, "Job") {
-		return
-	}
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var job database.Job
+	err = withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Check if job exists and belongs to user (through application)
+		var err error
+		job, err = qtx.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
 
-	// Delete job (verifies ownership through application's user_id)
-	err = h.queries.DeleteJob(ctx, database.DeleteJobParams{
-		ID:     int32(id),
-		UserID: userID,
+		// Delete job (verifies ownership through application's user_id)
+		return qtx.DeleteJob(ctx, database.DeleteJobParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
 	})
 	if handleDatabaseError(c, err, "Job") {
 		return
 	}
+	h.counts.Invalidate(countResourceJobs, userID)
+	// Application counts filtered by missing_job depend on which applications have a job
+	h.counts.Invalidate(countResourceApplications, userID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Job deleted successfully",
 		"id": id,
+		"deleted": job,
 	})
 }
\ No newline at end of file
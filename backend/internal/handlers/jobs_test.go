@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
@@ -102,6 +103,79 @@ func TestGetAllJobs(t *testing.T) {
 	}
 }
 
+// TestGetAllJobs_WithEmploymentTypeFilter tests GET /api/jobs?employment_type=
+func TestGetAllJobs_WithEmploymentTypeFilter(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-jobs-employment-type@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Employment Type Corp",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	for _, employmentType := range []string{"contract", "full_time", ""} {
+		application := createTestApplication(t, queries, testUser.ID, "applied", "")
+		_, err := queries.CreateJob(ctx, database.CreateJobParams{
+			ApplicationID:  application.ID,
+			CompanyID:      company.ID,
+			Title:          "Job " + employmentType,
+			EmploymentType: sql.NullString{String: employmentType, Valid: employmentType != ""},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test job: %v", err)
+		}
+	}
+
+	// Test filtering returns only contract jobs
+	req := httptest.NewRequest("GET", "/api/jobs?employment_type=contract", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var jobs []database.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &jobs); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].EmploymentType.String != "contract" {
+		t.Errorf("Expected 1 contract job, got %+v", jobs)
+	}
+
+	// Test invalid employment type in filter and body
+	req = httptest.NewRequest("GET", "/api/jobs?employment_type=freelance", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid filter, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"application_id":  createTestApplication(t, queries, testUser.ID, "applied", "").ID,
+		"company_id":      company.ID,
+		"title":           "Freelance gig",
+		"employment_type": "freelance",
+	})
+	req = httptest.NewRequest("POST", "/api/jobs", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid employment_type, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 // TestGetJobByID tests GET /api/jobs/:id
 func TestGetJobByID(t *testing.T) {
 	router, queries, db := setupTestRouter(t)
@@ -422,6 +496,11 @@ func TestCreateJob(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
 	}
+	var errResp ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &errResp)
+	if errResp.Error != "Application not found or not yours" || errResp.Fields["application_id"] == "" {
+		t.Errorf("Expected application_id reference error, got %+v", errResp)
+	}
 
 	// Test company not found
 	invalidBody = map[string]interface{}{
@@ -440,6 +519,11 @@ func TestCreateJob(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
 	}
+	errResp = ErrorResponse{}
+	json.Unmarshal(w.Body.Bytes(), &errResp)
+	if errResp.Error != "Company not found or not yours" || errResp.Fields["company_id"] == "" {
+		t.Errorf("Expected company_id reference error, got %+v", errResp)
+	}
 }
 
 // TestUpdateJob tests PUT /api/jobs/:id
@@ -481,9 +565,10 @@ func TestUpdateJob(t *testing.T) {
 
 	// Create a test job with application_id
 	job, err := queries.CreateJob(ctx, database.CreateJobParams{
-		ApplicationID: application.ID,
-		CompanyID:     company.ID,
-		Title:         "Original Job Title",
+		ApplicationID:  application.ID,
+		CompanyID:      company.ID,
+		Title:          "Original Job Title",
+		EmploymentType: sql.NullString{String: "contract", Valid: true},
 	})
 	if err != nil {
 		t.Fatalf("Failed to create test job: %v", err)
@@ -519,6 +604,10 @@ func TestUpdateJob(t *testing.T) {
 	if updated.Title != "Updated Job Title" {
 		t.Errorf("Expected title 'Updated Job Title', got %s", updated.Title)
 	}
+	// employment_type was omitted, so it is kept
+	if updated.EmploymentType.String != "contract" {
+		t.Errorf("Expected employment_type 'contract' to be kept, got %+v", updated.EmploymentType)
+	}
 
 	// Test not found
 	req = httptest.NewRequest("PUT", "/api/jobs/99999", bytes.NewBuffer(jsonBody))
@@ -530,6 +619,34 @@ func TestUpdateJob(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
+
+	// Test updating another user's job returns 404 and leaves the job untouched
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-jobs-update-other@example.com")
+	defer otherCleanup()
+
+	hijackBody, _ := json.Marshal(map[string]interface{}{
+		"title": "Hijacked Title",
+	})
+	req = httptest.NewRequest("PUT", "/api/jobs/"+strconv.Itoa(int(job.ID)), bytes.NewBuffer(hijackBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+otherUser.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's job, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+
+	unchanged, err := queries.GetJobByIDAndUserID(ctx, database.GetJobByIDAndUserIDParams{
+		ID:     job.ID,
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to fetch job: %v", err)
+	}
+	if unchanged.Title != "Updated Job Title" {
+		t.Errorf("Expected job title to be unchanged, got %s", unchanged.Title)
+	}
 }
 
 // TestDeleteJob tests DELETE /api/jobs/:id
@@ -807,3 +924,260 @@ func TestGetAllJobs_PaginationEdgeCases(t *testing.T) {
 	}
 }
 
+
+// TestMoveJob tests PATCH /api/jobs/:id (moving a job to another application)
+func TestMoveJob(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user (cleanup cascades to their applications and jobs)
+	testUser, cleanup := createTestUser(t, queries, db, "test-jobs-move@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-jobs-move-other@example.com")
+	defer otherCleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Test Company for MoveJob",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	wrong := createTestApplication(t, queries, testUser.ID, "applied", "")
+	right := createTestApplication(t, queries, testUser.ID, "applied", "")
+	taken := createTestApplication(t, queries, testUser.ID, "applied", "")
+	foreign := createTestApplication(t, queries, otherUser.ID, "applied", "")
+
+	job, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: wrong.ID, CompanyID: company.ID, Title: "Mislinked Job"})
+	if err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+	if _, err := queries.CreateJob(ctx, database.CreateJobParams{ApplicationID: taken.ID, CompanyID: company.ID, Title: "Other Job"}); err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+
+	move := func(applicationID int32, ifMatch ...string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"application_id": applicationID})
+		req := httptest.NewRequest("PATCH", "/api/jobs/"+strconv.Itoa(int(job.ID)), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		if len(ifMatch) > 0 {
+			req.Header.Set("If-Match", ifMatch[0])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := move(right.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var moved database.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &moved); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if moved.ApplicationID != right.ID || moved.Title != "Mislinked Job" {
+		t.Errorf("Expected the job to move to application %d, got %+v", right.ID, moved)
+	}
+
+	// Target application already has a job
+	if w := move(taken.ID); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// Target application belongs to another user
+	if w := move(foreign.ID); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+
+	// Moving to the current application is a no-op
+	if w := move(right.ID); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// If-Match with the ETag from before the first move is stale, even for a no-op
+	staleETag := resourceETag(job.UpdatedAt)
+	if w := move(wrong.ID, staleETag); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusPreconditionFailed, w.Code, w.Body.String())
+	}
+	if w := move(right.ID, staleETag); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusPreconditionFailed, w.Code, w.Body.String())
+	}
+
+	// The current ETag lets the move through
+	if w := move(wrong.ID, resourceETag(moved.UpdatedAt)); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+// TestCloneJob tests POST /api/jobs/:id/clone
+func TestCloneJob(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user (cleanup cascades to their applications and jobs)
+	testUser, cleanup := createTestUser(t, queries, db, "test-jobs-clone@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-jobs-clone-other@example.com")
+	defer otherCleanup()
+	ctx := context.Background()
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Test Company for CloneJob", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	otherCompany, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Second Company for CloneJob", UserID: testUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	foreignCompany, err := queries.CreateCompany(ctx, database.CreateCompanyParams{Name: "Foreign Company for CloneJob", UserID: otherUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+
+	original := createTestApplication(t, queries, testUser.ID, "applied", "")
+	target := createTestApplication(t, queries, testUser.ID, "applied", "")
+	second := createTestApplication(t, queries, testUser.ID, "applied", "")
+	foreign := createTestApplication(t, queries, otherUser.ID, "applied", "")
+
+	job, err := queries.CreateJob(ctx, database.CreateJobParams{
+		ApplicationID: original.ID,
+		CompanyID:     company.ID,
+		Title:         "Backend Engineer",
+		Description:   sql.NullString{String: "A long description", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test job: %v", err)
+	}
+
+	clone := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/jobs/"+strconv.Itoa(int(job.ID))+"/clone", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := clone(map[string]interface{}{"application_id": target.ID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var cloned database.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &cloned); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if cloned.ID == job.ID || cloned.ApplicationID != target.ID || cloned.CompanyID != company.ID || cloned.Title != job.Title || cloned.Description != job.Description {
+		t.Errorf("Expected a copy of job %d linked to application %d, got %+v", job.ID, target.ID, cloned)
+	}
+
+	// Company override
+	w = clone(map[string]interface{}{"application_id": second.ID, "company_id": otherCompany.ID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &cloned); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if cloned.CompanyID != otherCompany.ID {
+		t.Errorf("Expected company %d, got %d", otherCompany.ID, cloned.CompanyID)
+	}
+
+	// Target application already has a job
+	if w := clone(map[string]interface{}{"application_id": target.ID}); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// Target application or company belongs to another user
+	if w := clone(map[string]interface{}{"application_id": foreign.ID}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+	empty := createTestApplication(t, queries, testUser.ID, "applied", "")
+	if w := clone(map[string]interface{}{"application_id": empty.ID, "company_id": foreignCompany.ID}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
+// TestJobChangesInvalidateApplicationCounts tests that creating, moving, cloning and deleting jobs
+// refresh the cached missing_job application counts
+func TestJobChangesInvalidateApplicationCounts(t *testing.T) {
+	_, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user (cleanup cascades to their applications and jobs)
+	testUser, cleanup := createTestUser(t, queries, db, "test-jobs-counts@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	router := gin.New()
+	cfg := Config{DB: queries, DBConn: db, UseLegacyAuth: true, CountCache: NewCountCache(time.Minute)}
+	cfg.SetupRoutes(router)
+
+	company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+		Name:   "Test Company for job counts",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test company: %v", err)
+	}
+	first := createTestApplication(t, queries, testUser.ID, "applied", "")
+	second := createTestApplication(t, queries, testUser.ID, "applied", "")
+	third := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	missingJob := func() int64 {
+		w := request("GET", "/api/applications/count?missing_job=true", nil)
+		var response struct {
+			Count int64 `json:"count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse count: %v (%s)", err, w.Body.String())
+		}
+		return response.Count
+	}
+	expectMissingJob := func(step string, expected int64) {
+		t.Helper()
+		if got := missingJob(); got != expected {
+			t.Errorf("%s: expected %d applications without a job, got %d", step, expected, got)
+		}
+	}
+
+	expectMissingJob("before", 3)
+
+	w := request("POST", "/api/jobs", map[string]interface{}{"application_id": first.ID, "company_id": company.ID, "title": "Counted Job"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var job database.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	expectMissingJob("after create", 2)
+
+	if w := request("POST", "/api/jobs/"+strconv.Itoa(int(job.ID))+"/clone", map[string]interface{}{"application_id": second.ID}); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	expectMissingJob("after clone", 1)
+
+	if w := request("PATCH", "/api/jobs/"+strconv.Itoa(int(job.ID)), map[string]interface{}{"application_id": third.ID}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	expectMissingJob("after move", 1)
+
+	if w := request("DELETE", "/api/jobs/"+strconv.Itoa(int(job.ID)), nil); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	expectMissingJob("after delete", 2)
+}
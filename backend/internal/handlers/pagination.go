@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 
@@ -14,8 +15,19 @@ const (
 	MaxPageSize = 100
 	// DefaultPage is the default page number
 	DefaultPage = 1
+	// DefaultMaxPaginationOffset is the default deepest row offset reachable with page/limit
+	DefaultMaxPaginationOffset = 10000
 )
 
+// MaxPaginationOffset caps (page-1)*limit so huge page numbers can't force expensive OFFSET scans
+// Configurable via MAX_PAGINATION_OFFSET; lenient parsing clamps the page, strict parsing returns an error
+var MaxPaginationOffset int32 = DefaultMaxPaginationOffset
+
+// maxPageFor returns the highest page whose offset stays within MaxPaginationOffset
+func maxPageFor(limit int) int {
+	return int(MaxPaginationOffset)/limit + 1
+}
+
 // PaginationParams holds pagination query parameters
 type PaginationParams struct {
 	Page  int32
@@ -38,6 +50,7 @@ type PaginatedResponse struct {
 
 // ParsePaginationParams parses page and limit from query parameters
 // Returns default values if not provided or invalid
+// Pages beyond MaxPaginationOffset are clamped to the deepest allowed page
 func ParsePaginationParams(c *gin.Context) PaginationParams {
 	page := DefaultPage
 	limit := DefaultPageSize
@@ -60,18 +73,65 @@ func ParsePaginationParams(c *gin.Context) PaginationParams {
 		}
 	}
 
+	// Clamp deep pages (also keeps the offset from overflowing int32)
+	if maxPage := maxPageFor(limit); page > maxPage {
+		page = maxPage
+	}
+
 	return PaginationParams{
 		Page:  int32(page),
 		Limit: int32(limit),
 	}
 }
 
-// CalculateOffset calculates the offset for SQL queries
+// ParseStrictPaginationParams parses page and limit like ParsePaginationParams,
+// but returns an error for values that aren't positive integers instead of silently defaulting.
+// Used by search endpoints, where ignoring a bad limit on an expensive query is confusing.
+// limit is still capped at MaxPageSize; a page beyond MaxPaginationOffset is an error.
+func ParseStrictPaginationParams(c *gin.Context) (PaginationParams, error) {
+	page := DefaultPage
+	limit := DefaultPageSize
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		p, err := strconv.Atoi(pageStr)
+		if err != nil || p < 1 {
+			return PaginationParams{}, fmt.Errorf("page must be a positive integer")
+		}
+		page = p
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return PaginationParams{}, fmt.Errorf("limit must be a positive integer (max %d)", MaxPageSize)
+		}
+		limit = l
+		// Enforce maximum limit
+		if limit > MaxPageSize {
+			limit = MaxPageSize
+		}
+	}
+
+	if maxPage := maxPageFor(limit); page > maxPage {
+		return PaginationParams{}, fmt.Errorf("page must be at most %d for limit %d (narrow the filters to reach older results)", maxPage, limit)
+	}
+
+	return PaginationParams{
+		Page:  int32(page),
+		Limit: int32(limit),
+	}, nil
+}
+
+// CalculateOffset calculates the offset for SQL queries, capped at MaxPaginationOffset
 func CalculateOffset(page, limit int32) int32 {
 	if page < 1 {
 		page = 1
 	}
-	return (page - 1) * limit
+	offset := int64(page-1) * int64(limit)
+	if offset > int64(MaxPaginationOffset) {
+		return MaxPaginationOffset
+	}
+	return int32(offset)
 }
 
 // CalculateTotalPages calculates the total number of pages
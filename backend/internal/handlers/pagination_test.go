@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newQueryContext creates a Gin context for a GET request with the given raw query
+func newQueryContext(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?"+rawQuery, nil)
+	return c
+}
+
+// TestParseStrictPaginationParams tests the strict parser used by search endpoints
+func TestParseStrictPaginationParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedPage  int32
+		expectedLimit int32
+		expectError   bool
+	}{
+		{name: "Defaults", query: "", expectedPage: DefaultPage, expectedLimit: DefaultPageSize},
+		{name: "Valid values", query: "page=3&limit=25", expectedPage: 3, expectedLimit: 25},
+		{name: "Limit capped at max", query: "limit=5000", expectedPage: DefaultPage, expectedLimit: MaxPageSize},
+		{name: "Non-numeric limit", query: "limit=abc", expectError: true},
+		{name: "Zero limit", query: "limit=0", expectError: true},
+		{name: "Negative page", query: "page=-1", expectError: true},
+		{name: "Non-numeric page", query: "page=two", expectError: true},
+		{name: "Deepest allowed page", query: "page=1001&limit=10", expectedPage: 1001, expectedLimit: 10},
+		{name: "Page beyond offset cap", query: "page=1002&limit=10", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseStrictPaginationParams(newQueryContext(tt.query))
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %+v", tt.query, params)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tt.query, err)
+			}
+			if params.Page != tt.expectedPage || params.Limit != tt.expectedLimit {
+				t.Errorf("Expected page=%d limit=%d, got page=%d limit=%d", tt.expectedPage, tt.expectedLimit, params.Page, params.Limit)
+			}
+		})
+	}
+
+	// List endpoints stay lenient: a bad limit falls back to the default
+	lenient := ParsePaginationParams(newQueryContext("limit=abc"))
+	if lenient.Limit != DefaultPageSize {
+		t.Errorf("Expected lenient parser to default limit, got %d", lenient.Limit)
+	}
+}
+
+// TestPaginationOffsetCap tests that deep pages are clamped and offsets never exceed MaxPaginationOffset
+func TestPaginationOffsetCap(t *testing.T) {
+	original := MaxPaginationOffset
+	MaxPaginationOffset = 100
+	defer func() { MaxPaginationOffset = original }()
+
+	// Lenient parser clamps to the deepest page
+	params := ParsePaginationParams(newQueryContext("page=50&limit=10"))
+	if params.Page != 11 {
+		t.Errorf("Expected page clamped to 11, got %d", params.Page)
+	}
+	if offset := CalculateOffset(params.Page, params.Limit); offset != 100 {
+		t.Errorf("Expected offset 100, got %d", offset)
+	}
+
+	// A page that would overflow int32 is clamped rather than wrapping around
+	params = ParsePaginationParams(newQueryContext("page=2147483647&limit=100"))
+	if params.Page != 2 {
+		t.Errorf("Expected page clamped to 2, got %d", params.Page)
+	}
+	if offset := CalculateOffset(2147483647, 100); offset != 100 {
+		t.Errorf("Expected CalculateOffset to cap at 100, got %d", offset)
+	}
+
+	// Strict parser rejects it
+	if _, err := ParseStrictPaginationParams(newQueryContext("page=12&limit=10")); err == nil {
+		t.Error("Expected error for page beyond offset cap")
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// MaxReminderDays caps how far ahead ?days= may look
+const MaxReminderDays = 365
+
+// DefaultStaleDays is how long an application may sit in "applied" before it is reported as stale
+const DefaultStaleDays = 21
+
+// Reminder types
+const (
+	reminderTypeNextAction = "next_action"
+)
+
+// ReminderHandler handles HTTP requests for due reminders
+type ReminderHandler struct {
+	queries *database.Queries
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(queries *database.Queries) *ReminderHandler {
+	return &ReminderHandler{
+		queries: queries,
+	}
+}
+
+// Reminder is a single due item for the user
+type Reminder struct {
+	Type          string `json:"type"` // what is due, e.g. "next_action"
+	ApplicationID int32  `json:"application_id"`
+	Action        string `json:"action"`
+	DueDate       string `json:"due_date"` // YYYY-MM-DD
+	Overdue       bool   `json:"overdue"`
+}
+
+// GetReminders handles GET /api/reminders
+// Returns items due today or earlier; ?days=N also includes the next N days
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	days := 0
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 0 || parsed > MaxReminderDays {
+			sendBadRequest(c, "Invalid days parameter", "days must be a number between 0 and "+strconv.Itoa(MaxReminderDays))
+			return
+		}
+		days = parsed
+	}
+
+	today := todayUTC()
+	until := today.AddDate(0, 0, days)
+
+	ctx := c.Request.Context()
+
+	applications, err := h.queries.GetApplicationsWithDueNextActionByUserID(ctx, database.GetApplicationsWithDueNextActionByUserIDParams{
+		UserID:        userID,
+		NextActionDue: sql.NullTime{Time: until, Valid: true},
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch reminders", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nextActionReminders(applications, today))
+}
+
+// GetStaleApplications handles GET /api/applications/stale
+// Returns applications still in "applied" whose applied_date is more than ?days=N days ago (default 21), oldest first
+// An application still in "applied" is aged by its applied_date (the status history is not consulted)
+func (h *ReminderHandler) GetStaleApplications(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	days := DefaultStaleDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > MaxReminderDays {
+			sendBadRequest(c, "Invalid days parameter", "days must be a number between 1 and "+strconv.Itoa(MaxReminderDays))
+			return
+		}
+		days = parsed
+	}
+
+	applications, err := h.queries.GetStaleApplicationsByUserID(c.Request.Context(), database.GetStaleApplicationsByUserIDParams{
+		UserID:      userID,
+		AppliedDate: todayUTC().AddDate(0, 0, -days),
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch stale applications", err)
+		return
+	}
+	if applications == nil {
+		applications = []database.Application{}
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// todayUTC returns midnight UTC of the current day (DATE columns compare against this)
+func todayUTC() time.Time {
+	return truncateToDay(time.Now())
+}
+
+// nextActionReminders converts applications with a due next action into reminders
+func nextActionReminders(applications []database.Application, today time.Time) []Reminder {
+	reminders := make([]Reminder, 0, len(applications))
+	for _, application := range applications {
+		reminders = append(reminders, Reminder{
+			Type:          reminderTypeNextAction,
+			ApplicationID: application.ID,
+			Action:        application.NextAction.String,
+			DueDate:       application.NextActionDue.Time.Format("2006-01-02"),
+			Overdue:       application.NextActionDue.Time.Before(today),
+		})
+	}
+	return reminders
+}
+
+// SnoozeRequest represents the JSON body for POST /api/applications/:id/snooze
+type SnoozeRequest struct {
+	Until string `json:"until" binding:"required"` // YYYY-MM-DD or RFC 3339, must be after today (validated manually)
+}
+
+// SnoozeApplication handles POST /api/applications/:id/snooze
+// Moves the application's next action due date to until, so it leaves GET /api/reminders until then
+// Responds 409 if the application has no next action to snooze
+func (h *ApplicationHandler) SnoozeApplication(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid application ID", "ID must be a number")
+		return
+	}
+
+	// Parse JSON body
+	var req SnoozeRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	until, err := parseDate(req.Until)
+	if err != nil {
+		sendDateError(c, "until")
+		return
+	}
+	until = truncateToDay(until)
+	if !until.After(todayUTC()) {
+		sendBadRequest(c, "Invalid until date", "until must be in the future")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	application, err := h.queries.SnoozeNextActionByIDAndUserID(ctx, database.SnoozeNextActionByIDAndUserIDParams{
+		NextActionDue: sql.NullTime{Time: until, Valid: true},
+		ID:            int32(id),
+		UserID:        userID,
+	})
+	if err == sql.ErrNoRows {
+		// No row updated: tell a missing application apart from one without a next action
+		_, err = h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+			ID:     int32(id),
+			UserID: userID,
+		})
+		if err == nil {
+			sendError(c, http.StatusConflict, "Application has no next action", "Set a next action before snoozing it")
+			return
+		}
+	}
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+	h.webhooks.Publish(userID, WebhookEventApplicationUpdated, application)
+
+	setETag(c, application.UpdatedAt)
+	c.JSON(http.StatusOK, application)
+}
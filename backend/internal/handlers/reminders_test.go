@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestGetReminders tests GET /api/reminders with next actions
+func TestGetReminders(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-reminders@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	today := time.Now().UTC()
+	dueDates := map[string]time.Time{
+		"Overdue action": today.AddDate(0, 0, -3),
+		"Today action":   today,
+		"Future action":  today.AddDate(0, 0, 10),
+	}
+	for action, due := range dueDates {
+		_, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+			Status:        "applied",
+			AppliedDate:   time.Now(),
+			UserID:        testUser.ID,
+			NextAction:    sql.NullString{String: action, Valid: true},
+			NextActionDue: sql.NullTime{Time: due, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test application: %v", err)
+		}
+	}
+
+	getReminders := func(query string) []Reminder {
+		req := httptest.NewRequest("GET", "/api/reminders"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var reminders []Reminder
+		if err := json.Unmarshal(w.Body.Bytes(), &reminders); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return reminders
+	}
+
+	// Test default: due today or overdue, overdue first
+	reminders := getReminders("")
+	if len(reminders) != 2 {
+		t.Fatalf("Expected 2 reminders, got %d", len(reminders))
+	}
+	if reminders[0].Action != "Overdue action" || !reminders[0].Overdue {
+		t.Errorf("Expected overdue action first, got %+v", reminders[0])
+	}
+	if reminders[1].Action != "Today action" || reminders[1].Overdue {
+		t.Errorf("Expected today's action second (not overdue), got %+v", reminders[1])
+	}
+
+	// Test looking ahead includes upcoming actions
+	reminders = getReminders("?days=30")
+	if len(reminders) != 3 {
+		t.Errorf("Expected 3 reminders with days=30, got %d", len(reminders))
+	}
+
+	// Test invalid days
+	req := httptest.NewRequest("GET", "/api/reminders?days=-1", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// TestCreateApplication_NextActionValidation tests next_action/next_action_due validation
+func TestCreateApplication_NextActionValidation(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-applications-next-action@example.com")
+	defer cleanup()
+
+	tests := []struct {
+		name           string
+		nextAction     string
+		nextActionDue  string
+		expectedStatus int
+	}{
+		{"Action with due date", "Send thank-you note", "2024-01-15", http.StatusCreated},
+		{"Action without due date", "Prepare for system design round", "", http.StatusCreated},
+		{"Due date without action", "", "2024-01-15", http.StatusBadRequest},
+		{"Invalid due date", "Follow up", "15/01/2024", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBody, _ := json.Marshal(map[string]interface{}{
+				"status":          "applied",
+				"applied_date":    time.Now().Format("2006-01-02"),
+				"next_action":     tt.nextAction,
+				"next_action_due": tt.nextActionDue,
+			})
+			req := httptest.NewRequest("POST", "/api/applications", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestGetStaleApplications tests GET /api/applications/stale
+func TestGetStaleApplications(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stale-applications@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	today := time.Now().UTC()
+	applications := []struct {
+		status string
+		age    int
+	}{
+		{"applied", 40},
+		{"applied", 25},
+		{"applied", 5},
+		{"interview", 40},
+	}
+	for _, a := range applications {
+		_, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+			Status:      a.status,
+			AppliedDate: today.AddDate(0, 0, -a.age),
+			UserID:      testUser.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test application: %v", err)
+		}
+	}
+
+	getStale := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/applications/stale"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"", 2},
+		{"?days=30", 1},
+		{"?days=1", 3},
+		{"?days=60", 0},
+	}
+	for _, tt := range tests {
+		w := getStale(tt.query)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %q, got %d. Body: %s", http.StatusOK, tt.query, w.Code, w.Body.String())
+		}
+		var stale []database.Application
+		if err := json.Unmarshal(w.Body.Bytes(), &stale); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(stale) != tt.expected {
+			t.Errorf("Expected %d stale applications for %q, got %d", tt.expected, tt.query, len(stale))
+		}
+		for i := 1; i < len(stale); i++ {
+			if stale[i].AppliedDate.Before(stale[i-1].AppliedDate) {
+				t.Errorf("Expected oldest first for %q, got %v before %v", tt.query, stale[i-1].AppliedDate, stale[i].AppliedDate)
+			}
+		}
+	}
+
+	// Test invalid days
+	for _, query := range []string{"?days=0", "?days=abc", "?days=366"} {
+		if w := getStale(query); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+// TestSnoozeApplication tests POST /api/applications/:id/snooze
+func TestSnoozeApplication(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-reminders-snooze@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	today := time.Now().UTC()
+	application, err := queries.CreateApplication(ctx, database.CreateApplicationParams{
+		Status:        "applied",
+		AppliedDate:   time.Now(),
+		UserID:        testUser.ID,
+		NextAction:    sql.NullString{String: "Follow up", Valid: true},
+		NextActionDue: sql.NullTime{Time: today.AddDate(0, 0, -1), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+	withoutAction := createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	snooze := func(id int32, until string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]string{"until": until})
+		req := httptest.NewRequest("POST", "/api/applications/"+strconv.Itoa(int(id))+"/snooze", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	until := today.AddDate(0, 0, 7).Format("2006-01-02")
+
+	w := snooze(application.ID, until)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var snoozed database.Application
+	if err := json.Unmarshal(w.Body.Bytes(), &snoozed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if snoozed.NextActionDue.Time.Format("2006-01-02") != until || snoozed.NextAction.String != "Follow up" {
+		t.Errorf("Expected the next action to be due %s, got %+v", until, snoozed)
+	}
+
+	// The snoozed action is no longer due
+	req := httptest.NewRequest("GET", "/api/reminders", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+	var reminders []Reminder
+	if err := json.Unmarshal(rw.Body.Bytes(), &reminders); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(reminders) != 0 {
+		t.Errorf("Expected no reminders after snoozing, got %+v", reminders)
+	}
+
+	tests := []struct {
+		name     string
+		id       int32
+		until    string
+		expected int
+	}{
+		{"Today", application.ID, today.Format("2006-01-02"), http.StatusBadRequest},
+		{"Past date", application.ID, "2020-01-01", http.StatusBadRequest},
+		{"Invalid date", application.ID, "next week", http.StatusBadRequest},
+		{"No next action", withoutAction.ID, until, http.StatusConflict},
+		{"Unknown application", 999999999, until, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := snooze(tt.id, tt.until); w.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
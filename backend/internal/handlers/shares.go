@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// ShareHandler handles share links: read-only public views of a single application
+type ShareHandler struct {
+	queries *database.Queries
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(queries *database.Queries) *ShareHandler {
+	return &ShareHandler{
+		queries: queries,
+	}
+}
+
+// ShareRequest represents the optional JSON body for creating a share link
+type ShareRequest struct {
+	ExpiresInDays *int `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // omit for a link that never expires
+	IncludeNotes  bool `json:"include_notes"`                                     // notes are hidden from the public view unless set
+}
+
+// ShareResponse is returned when a share link is created
+// The token is only shown here; the database keeps just its hash
+type ShareResponse struct {
+	ID           int32      `json:"id"`
+	Token        string     `json:"token"`
+	URL          string     `json:"url"`
+	IncludeNotes bool       `json:"include_notes"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
+
+// SharedJob is the job part of a shared application
+type SharedJob struct {
+	Title          string  `json:"title"`
+	Description    *string `json:"description"`
+	Location       *string `json:"location"`
+	EmploymentType *string `json:"employment_type"`
+}
+
+// SharedCompany is the company part of a shared application
+type SharedCompany struct {
+	Name     string  `json:"name"`
+	Website  *string `json:"website"`
+	Industry *string `json:"industry"`
+}
+
+// SharedApplication is the public, read-only view of a shared application
+// It leaves out IDs, contacts, offer details and (unless the link allows it) notes
+type SharedApplication struct {
+	Status      string         `json:"status"`
+	AppliedDate string         `json:"applied_date"`
+	Job         *SharedJob     `json:"job"`     // null when the application has no job
+	Company     *SharedCompany `json:"company"` // null when the application has no job
+	Notes       *string        `json:"notes,omitempty"`
+	ExpiresAt   *time.Time     `json:"expires_at"`
+}
+
+// hashShareToken returns the hex SHA-256 of a share token, as stored in application_shares.token_hash
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newShareToken returns a random 64-character hex share token
+func newShareToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// nullStringPtr returns a pointer to s's value, or nil when s is NULL
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// shareURL builds the absolute URL of the public view for a token from the incoming request
+func shareURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/api/shared/" + token
+}
+
+// requireOwnedApplicationID parses the :id param and verifies the application belongs to the user
+// Sends a 400/404 response and returns false otherwise
+func (h *ShareHandler) requireOwnedApplicationID(c *gin.Context, userID int32) (int32, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid application ID", "ID must be a number")
+		return 0, false
+	}
+
+	_, err = h.queries.GetApplicationByIDAndUserID(c.Request.Context(), database.GetApplicationByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// CreateShare handles POST /api/applications/:id/share
+// Creates a read-only share link for the application (verifies ownership)
+// The body is optional: {"expires_in_days": 7, "include_notes": true}
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req ShareRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		sendValidationError(c, err)
+		return
+	}
+
+	applicationID, ok := h.requireOwnedApplicationID(c, userID)
+	if !ok {
+		return
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		sendInternalError(c, "Failed to generate share token", err)
+		return
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresInDays != nil {
+		expiresAt = sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, *req.ExpiresInDays), Valid: true}
+	}
+
+	share, err := h.queries.CreateApplicationShare(c.Request.Context(), database.CreateApplicationShareParams{
+		ApplicationID: applicationID,
+		TokenHash:     hashShareToken(token),
+		IncludeNotes:  req.IncludeNotes,
+		ExpiresAt:     expiresAt,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	response := ShareResponse{
+		ID:           share.ID,
+		Token:        token,
+		URL:          shareURL(c, token),
+		IncludeNotes: share.IncludeNotes,
+	}
+	if share.ExpiresAt.Valid {
+		response.ExpiresAt = &share.ExpiresAt.Time
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeShares handles DELETE /api/applications/:id/share
+// Revokes every share link of the application (verifies ownership)
+func (h *ShareHandler) RevokeShares(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := h.requireOwnedApplicationID(c, userID)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.queries.DeleteApplicationSharesByApplicationID(c.Request.Context(), applicationID)
+	if err != nil {
+		sendInternalError(c, "Failed to revoke share links", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Share links revoked successfully",
+		"id":      applicationID,
+		"revoked": revoked,
+	})
+}
+
+// GetSharedApplication handles GET /api/shared/:token (public - no authentication)
+// Returns the read-only view of a shared application; unknown, revoked and expired tokens are all 404
+func (h *ShareHandler) GetSharedApplication(c *gin.Context) {
+	row, err := h.queries.GetSharedApplicationByTokenHash(c.Request.Context(), hashShareToken(c.Param("token")))
+	if handleDatabaseError(c, err, "Shared application") {
+		return
+	}
+
+	view := SharedApplication{
+		Status:      row.Status,
+		AppliedDate: row.AppliedDate.Format("2006-01-02"),
+	}
+	if row.JobTitle.Valid {
+		view.Job = &SharedJob{
+			Title:          row.JobTitle.String,
+			Description:    nullStringPtr(row.JobDescription),
+			Location:       nullStringPtr(row.JobLocation),
+			EmploymentType: nullStringPtr(row.JobEmploymentType),
+		}
+	}
+	if row.CompanyName.Valid {
+		view.Company = &SharedCompany{
+			Name:     row.CompanyName.String,
+			Website:  nullStringPtr(row.CompanyWebsite),
+			Industry: nullStringPtr(row.CompanyIndustry),
+		}
+	}
+	if row.IncludeNotes {
+		view.Notes = nullStringPtr(row.Notes)
+	}
+	if row.ExpiresAt.Valid {
+		view.ExpiresAt = &row.ExpiresAt.Time
+	}
+
+	// Shared views may be revoked at any time, so they must not be cached
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, view)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestApplicationShares tests creating, viewing and revoking share links
+func TestApplicationShares(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-application-shares@example.com")
+	defer cleanup()
+
+	application, err := queries.CreateApplication(context.Background(), database.CreateApplicationParams{
+		Status:      "interview",
+		AppliedDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Notes:       sql.NullString{String: "Private notes", Valid: true},
+		UserID:      testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+	sharePath := "/api/applications/" + strconv.Itoa(int(application.ID)) + "/share"
+
+	request := func(method, path string, body interface{}, authenticated bool) *httptest.ResponseRecorder {
+		var reader *bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(jsonBody)
+		} else {
+			reader = bytes.NewBuffer(nil)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authenticated {
+			req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	share := func(body interface{}) ShareResponse {
+		w := request("POST", sharePath, body, true)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response ShareResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response
+	}
+
+	// Test a link without a body hides notes and never expires
+	plain := share(nil)
+	if plain.Token == "" || !strings.HasSuffix(plain.URL, "/api/shared/"+plain.Token) || plain.ExpiresAt != nil {
+		t.Errorf("Unexpected share response: %+v", plain)
+	}
+
+	w := request("GET", "/api/shared/"+plain.Token, nil, false)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var view SharedApplication
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if view.Status != "interview" || view.AppliedDate != "2024-01-15" || view.Notes != nil || view.Job != nil {
+		t.Errorf("Unexpected shared view: %+v", view)
+	}
+	if strings.Contains(w.Body.String(), "user_id") {
+		t.Errorf("Expected the shared view to leave out owner details, got %s", w.Body.String())
+	}
+
+	// Test include_notes exposes notes
+	withNotes := share(map[string]interface{}{"include_notes": true, "expires_in_days": 7})
+	if withNotes.ExpiresAt == nil {
+		t.Errorf("Expected an expiry, got %+v", withNotes)
+	}
+	w = request("GET", "/api/shared/"+withNotes.Token, nil, false)
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if view.Notes == nil || *view.Notes != "Private notes" {
+		t.Errorf("Expected notes in the shared view, got %+v", view)
+	}
+
+	// Test invalid expiry
+	w = request("POST", sharePath, map[string]interface{}{"expires_in_days": 0}, true)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	// Test unknown token
+	w = request("GET", "/api/shared/not-a-token", nil, false)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+
+	// Test revoking removes every link
+	w = request("DELETE", sharePath, nil, true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	w = request("GET", "/api/shared/"+plain.Token, nil, false)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after revoke, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sortable list resources
+const (
+	sortResourceApplications = "applications"
+	sortResourceCompanies    = "companies"
+	sortResourceContacts     = "contacts"
+	sortResourceJobs         = "jobs"
+)
+
+// ListSort is the order of a list endpoint, e.g. created_at descending
+type ListSort struct {
+	Field string
+	Desc  bool
+}
+
+// key returns the sort_key the list queries switch on, e.g. "created_at_desc"
+func (s ListSort) key() string {
+	if s.Desc {
+		return s.Field + "_desc"
+	}
+	return s.Field + "_asc"
+}
+
+// String returns the sort in the "field:direction" form accepted by ?sort= and the env vars
+func (s ListSort) String() string {
+	if s.Desc {
+		return s.Field + ":desc"
+	}
+	return s.Field + ":asc"
+}
+
+// isBuiltin reports whether the sort matches the resource's historical order,
+// which the plain (unfiltered) list queries already produce
+func (s ListSort) isBuiltin(resource string) bool {
+	return s == builtinListSorts[resource]
+}
+
+// sortableFields lists the fields each resource can be sorted by
+// Keep in sync with the sort_key CASE branches in the list queries
+var sortableFields = map[string][]string{
+	sortResourceApplications: {"applied_date", "created_at", "last_activity", "updated_at"},
+	sortResourceCompanies:    {"created_at", "name"},
+	sortResourceContacts:     {"created_at", "name"},
+	sortResourceJobs:         {"created_at", "title"},
+}
+
+// builtinListSorts is each resource's historical order, used unless configured otherwise
+var builtinListSorts = map[string]ListSort{
+	sortResourceApplications: {Field: "updated_at", Desc: true},
+	sortResourceCompanies:    {Field: "name"},
+	sortResourceContacts:     {Field: "name"},
+	sortResourceJobs:         {Field: "created_at", Desc: true},
+}
+
+// defaultListSorts holds the configured default order per resource (see SetDefaultListSort)
+// Written only at startup, before the server handles requests
+var defaultListSorts = map[string]ListSort{}
+
+// SortableResources returns the resources whose default order can be configured
+func SortableResources() []string {
+	return []string{sortResourceApplications, sortResourceCompanies, sortResourceContacts, sortResourceJobs}
+}
+
+// SetDefaultListSort sets the default order of a resource's list endpoint
+// value is "field", "field:asc", "field:desc" or "-field" (descending)
+func SetDefaultListSort(resource, value string) error {
+	sort, err := parseListSort(resource, value)
+	if err != nil {
+		return err
+	}
+	defaultListSorts[resource] = sort
+	return nil
+}
+
+// defaultListSort returns the configured default order of a resource, or its built-in order
+func defaultListSort(resource string) ListSort {
+	if sort, ok := defaultListSorts[resource]; ok {
+		return sort
+	}
+	return builtinListSorts[resource]
+}
+
+// parseListSort parses a sort value for a resource, rejecting fields it can't be sorted by
+func parseListSort(resource, value string) (ListSort, error) {
+	value = strings.TrimSpace(value)
+	var sort ListSort
+	if field, ok := strings.CutPrefix(value, "-"); ok {
+		sort = ListSort{Field: field, Desc: true}
+	} else {
+		field, direction, _ := strings.Cut(value, ":")
+		switch strings.ToLower(direction) {
+		case "", "asc":
+			sort = ListSort{Field: field}
+		case "desc":
+			sort = ListSort{Field: field, Desc: true}
+		default:
+			return ListSort{}, fmt.Errorf("direction must be asc or desc")
+		}
+	}
+
+	for _, field := range sortableFields[resource] {
+		if sort.Field == field {
+			return sort, nil
+		}
+	}
+	return ListSort{}, fmt.Errorf("sort field must be one of: %s", strings.Join(sortableFields[resource], ", "))
+}
+
+// resolveListSort returns the order for a list request: ?sort= if given, otherwise the resource default
+// Sends a 400 response and returns false if ?sort= is invalid
+func resolveListSort(c *gin.Context, resource string) (ListSort, bool) {
+	value := c.Query("sort")
+	if value == "" {
+		return defaultListSort(resource), true
+	}
+	sort, err := parseListSort(resource, value)
+	if err != nil {
+		sendBadRequest(c, "Invalid sort parameter", err.Error())
+		return ListSort{}, false
+	}
+	return sort, true
+}
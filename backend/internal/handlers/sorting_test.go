@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestParseListSort tests parsing of ?sort= / DEFAULT_SORT_* values
+func TestParseListSort(t *testing.T) {
+	tests := []struct {
+		name        string
+		resource    string
+		value       string
+		expected    ListSort
+		expectError bool
+	}{
+		{name: "Bare field is ascending", resource: sortResourceCompanies, value: "name", expected: ListSort{Field: "name"}},
+		{name: "Explicit descending", resource: sortResourceCompanies, value: "created_at:desc", expected: ListSort{Field: "created_at", Desc: true}},
+		{name: "Dash prefix is descending", resource: sortResourceJobs, value: "-title", expected: ListSort{Field: "title", Desc: true}},
+		{name: "Direction is case-insensitive", resource: sortResourceApplications, value: "applied_date:ASC", expected: ListSort{Field: "applied_date"}},
+		{name: "Last activity for applications", resource: sortResourceApplications, value: "-last_activity", expected: ListSort{Field: "last_activity", Desc: true}},
+		{name: "Field not sortable for resource", resource: sortResourceContacts, value: "title", expectError: true},
+		{name: "Invalid direction", resource: sortResourceCompanies, value: "name:up", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := parseListSort(tt.resource, tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %+v", tt.value, sort)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tt.value, err)
+			}
+			if sort != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, sort)
+			}
+		})
+	}
+}
+
+// TestResolveListSort tests that ?sort= overrides the configured default
+func TestResolveListSort(t *testing.T) {
+	defer delete(defaultListSorts, sortResourceCompanies)
+
+	// Built-in default
+	sort, ok := resolveListSort(newQueryContext(""), sortResourceCompanies)
+	if !ok || !sort.isBuiltin(sortResourceCompanies) {
+		t.Errorf("Expected built-in sort, got %+v", sort)
+	}
+
+	// Configured default
+	if err := SetDefaultListSort(sortResourceCompanies, "created_at:desc"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sort, _ = resolveListSort(newQueryContext(""), sortResourceCompanies)
+	if sort.key() != "created_at_desc" {
+		t.Errorf("Expected configured default created_at_desc, got %s", sort.key())
+	}
+
+	// Query parameter overrides it
+	sort, _ = resolveListSort(newQueryContext("sort=name"), sortResourceCompanies)
+	if sort.key() != "name_asc" {
+		t.Errorf("Expected ?sort= to override the default, got %s", sort.key())
+	}
+
+	// Invalid query parameter is a 400
+	c := newQueryContext("sort=website")
+	if _, ok := resolveListSort(c, sortResourceCompanies); ok {
+		t.Error("Expected invalid sort to be rejected")
+	}
+	if c.Writer.Status() != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, c.Writer.Status())
+	}
+}
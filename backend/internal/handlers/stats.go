@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// DefaultTimelineMonths is how many months GET /api/stats/applications/timeline covers by default
+const DefaultTimelineMonths = 12
+
+// MaxTimelineMonths caps ?months= for the applications timeline
+const MaxTimelineMonths = 60
+
+// DefaultRejectionReasons is how many reasons GET /api/stats/rejection-reasons returns by default
+const DefaultRejectionReasons = 10
+
+// MaxRejectionReasons caps ?limit= for the rejection reasons breakdown
+const MaxRejectionReasons = 50
+
+// StatsHandler handles HTTP requests for aggregate statistics
+type StatsHandler struct {
+	queries *database.Queries
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(queries *database.Queries) *StatsHandler {
+	return &StatsHandler{
+		queries: queries,
+	}
+}
+
+// SourceStat is one row of the applications-by-source breakdown
+type SourceStat struct {
+	Source        string  `json:"source"`
+	Total         int64   `json:"total"`
+	Interviews    int64   `json:"interviews"`     // reached interview, offer or accepted
+	Offers        int64   `json:"offers"`         // reached offer or accepted
+	InterviewRate float64 `json:"interview_rate"` // interviews / total
+}
+
+// GetStatsBySource handles GET /api/stats/by-source
+// Returns application counts per source with how many yielded interviews and offers
+func (h *StatsHandler) GetStatsBySource(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	rows, err := h.queries.GetApplicationStatsBySource(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch source stats", err)
+		return
+	}
+
+	stats := make([]SourceStat, len(rows))
+	for i, row := range rows {
+		stats[i] = SourceStat{
+			Source:        row.Source,
+			Total:         row.Total,
+			Interviews:    row.Interviews,
+			Offers:        row.Offers,
+			InterviewRate: ratio(row.Interviews, row.Total),
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// IndustryStat is one row of the applications-by-industry breakdown
+type IndustryStat struct {
+	Industry string `json:"industry"` // lowercased; "unspecified" when the company has no industry
+	Total    int64  `json:"total"`
+}
+
+// GetStatsByIndustry handles GET /api/stats/by-industry
+// Returns application counts per company industry, sorted by count
+// Only applications with a job (and therefore a company) are counted
+func (h *StatsHandler) GetStatsByIndustry(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	rows, err := h.queries.GetApplicationStatsByIndustry(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch industry stats", err)
+		return
+	}
+
+	stats := make([]IndustryStat, len(rows))
+	for i, row := range rows {
+		stats[i] = IndustryStat{
+			Industry: row.Industry,
+			Total:    row.Total,
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// CompanyStat is one row of the applications-by-company breakdown
+type CompanyStat struct {
+	CompanyID    int32  `json:"company_id"`
+	CompanyName  string `json:"company_name"`
+	Applications int64  `json:"applications"`
+}
+
+// GetStatsByCompany handles GET /api/stats/by-company
+// Returns application counts per company, most applications first, as a PaginatedResponse (?page=1&limit=10)
+// Only companies with applications are listed unless ?include_empty=true
+func (h *StatsHandler) GetStatsByCompany(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	includeEmpty := c.Query("include_empty") == "true"
+	params := ParsePaginationParams(c)
+	offset := CalculateOffset(params.Page, params.Limit)
+
+	ctx := c.Request.Context()
+
+	rows, err := h.queries.GetApplicationCountsByCompany(ctx, database.GetApplicationCountsByCompanyParams{
+		UserID:       userID,
+		IncludeEmpty: includeEmpty,
+		RowLimit:     params.Limit,
+		RowOffset:    offset,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch company stats", err)
+		return
+	}
+
+	totalCount, err := h.queries.CountCompaniesForApplicationCounts(ctx, database.CountCompaniesForApplicationCountsParams{
+		UserID:       userID,
+		IncludeEmpty: includeEmpty,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to count company stats", err)
+		return
+	}
+
+	data := make([]interface{}, len(rows))
+	for i, row := range rows {
+		data[i] = CompanyStat{
+			CompanyID:    row.CompanyID,
+			CompanyName:  row.CompanyName,
+			Applications: row.Applications,
+		}
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: data,
+		Meta: PaginationMeta{
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalCount: totalCount,
+			TotalPages: CalculateTotalPages(totalCount, params.Limit),
+		},
+	})
+}
+
+// CurrencyOfferStat is the offered salary range for one currency
+type CurrencyOfferStat struct {
+	Currency  string  `json:"currency"`
+	Offers    int64   `json:"offers"` // offers with a salary in this currency
+	MinSalary int64   `json:"min_salary"`
+	MaxSalary int64   `json:"max_salary"`
+	AvgSalary float64 `json:"avg_salary"`
+}
+
+// OfferStats summarizes offer outcomes
+type OfferStats struct {
+	Offers         int64               `json:"offers"` // applications in offer or accepted
+	Accepted       int64               `json:"accepted"`
+	Declined       int64               `json:"declined"`
+	Pending        int64               `json:"pending"`         // offers not yet accepted or declined
+	AcceptanceRate float64             `json:"acceptance_rate"` // accepted / offers
+	ByCurrency     []CurrencyOfferStat `json:"by_currency"`     // salaries are never compared across currencies
+	// All salaries converted to BASE_CURRENCY; only present when BASE_CURRENCY is configured
+	InBaseCurrency *BaseCurrencyOfferStat `json:"in_base_currency,omitempty"`
+}
+
+// GetOfferStats handles GET /api/stats/offers
+// Returns offer counts, the acceptance rate and offered salary ranges per currency
+// With BASE_CURRENCY and CURRENCY_RATES configured, also a combined range converted to the base currency
+func (h *StatsHandler) GetOfferStats(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	counts, err := h.queries.GetOfferStats(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch offer stats", err)
+		return
+	}
+
+	rows, err := h.queries.GetOfferSalaryStatsByCurrency(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch offer stats", err)
+		return
+	}
+
+	byCurrency := make([]CurrencyOfferStat, len(rows))
+	for i, row := range rows {
+		byCurrency[i] = CurrencyOfferStat{
+			Currency:  row.Currency,
+			Offers:    row.Offers,
+			MinSalary: row.MinSalary,
+			MaxSalary: row.MaxSalary,
+			AvgSalary: row.AvgSalary,
+		}
+	}
+
+	c.JSON(http.StatusOK, OfferStats{
+		Offers:         counts.Offers,
+		Accepted:       counts.Accepted,
+		Declined:       counts.Declined,
+		Pending:        counts.Offers - counts.Accepted - counts.Declined,
+		AcceptanceRate: ratio(counts.Accepted, counts.Offers),
+		ByCurrency:     byCurrency,
+		InBaseCurrency: convertOfferStats(byCurrency),
+	})
+}
+
+// ReferralStat is the outcome of the applications one contact referred the user for
+type ReferralStat struct {
+	ContactID     int32   `json:"contact_id"`
+	ContactName   string  `json:"contact_name"`
+	Total         int64   `json:"total"`
+	Interviews    int64   `json:"interviews"`     // reached interview or later
+	Offers        int64   `json:"offers"`         // reached offer or later
+	InterviewRate float64 `json:"interview_rate"` // interviews / total
+	OfferRate     float64 `json:"offer_rate"`     // offers / total
+}
+
+// ReferralStats summarizes how referred applications convert, overall and per referring contact
+type ReferralStats struct {
+	Total         int64          `json:"total"`
+	Interviews    int64          `json:"interviews"`
+	Offers        int64          `json:"offers"`
+	InterviewRate float64        `json:"interview_rate"`
+	OfferRate     float64        `json:"offer_rate"`
+	ByContact     []ReferralStat `json:"by_contact"`
+}
+
+// GetReferralStats handles GET /api/stats/referrals
+// Returns interview and offer rates for applications with a referring contact, overall and per contact
+func (h *StatsHandler) GetReferralStats(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetReferralStatsByContact(c.Request.Context(), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch referral stats", err)
+		return
+	}
+
+	stats := ReferralStats{ByContact: make([]ReferralStat, len(rows))}
+	for i, row := range rows {
+		stats.ByContact[i] = ReferralStat{
+			ContactID:     row.ContactID,
+			ContactName:   row.ContactName,
+			Total:         row.Total,
+			Interviews:    row.Interviews,
+			Offers:        row.Offers,
+			InterviewRate: ratio(row.Interviews, row.Total),
+			OfferRate:     ratio(row.Offers, row.Total),
+		}
+		stats.Total += row.Total
+		stats.Interviews += row.Interviews
+		stats.Offers += row.Offers
+	}
+	stats.InterviewRate = ratio(stats.Interviews, stats.Total)
+	stats.OfferRate = ratio(stats.Offers, stats.Total)
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// MonthCount is the number of applications (or, for admin stats, signups) in one month
+type MonthCount struct {
+	Month string `json:"month"` // YYYY-MM
+	Count int64  `json:"count"`
+}
+
+// parseTimelineMonths reads ?months= (default DefaultTimelineMonths) and returns the first day (UTC)
+// of the range that ends with the current month
+// Sends a 400 response and returns false if months is not between 1 and MaxTimelineMonths
+func parseTimelineMonths(c *gin.Context) (months int, start time.Time, ok bool) {
+	months = DefaultTimelineMonths
+	if monthsStr := c.Query("months"); monthsStr != "" {
+		parsed, err := strconv.Atoi(monthsStr)
+		if err != nil || parsed < 1 || parsed > MaxTimelineMonths {
+			sendBadRequest(c, "Invalid months parameter", "months must be a number between 1 and "+strconv.Itoa(MaxTimelineMonths))
+			return 0, time.Time{}, false
+		}
+		months = parsed
+	}
+
+	today := todayUTC()
+	start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1-months, 0)
+	return months, start, true
+}
+
+// fillMonths returns one MonthCount per month from start on, taking counts (keyed YYYY-MM) and 0 for missing months
+func fillMonths(start time.Time, months int, counts map[string]int64) []MonthCount {
+	timeline := make([]MonthCount, months)
+	for i := range timeline {
+		month := start.AddDate(0, i, 0).Format("2006-01")
+		timeline[i] = MonthCount{Month: month, Count: counts[month]}
+	}
+	return timeline
+}
+
+// GetApplicationsTimeline handles GET /api/stats/applications/timeline
+// Returns application counts per applied_date month for the last ?months=N months (default 12), oldest first
+// Every month in the range is present (months without applications have count 0) so charts stay continuous
+func (h *StatsHandler) GetApplicationsTimeline(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	// The range ends with the current month and starts months-1 months before it
+	months, start, ok := parseTimelineMonths(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.GetApplicationCountsByMonth(c.Request.Context(), database.GetApplicationCountsByMonthParams{
+		UserID:      userID,
+		AppliedDate: start,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch applications timeline", err)
+		return
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Month.Format("2006-01")] = row.Count
+	}
+
+	c.JSON(http.StatusOK, fillMonths(start, months, counts))
+}
+
+// ReasonCount is how many closed applications share one (normalized) closed_reason
+type ReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
+
+// RejectionReasonStats summarizes why rejected and withdrawn applications ended
+type RejectionReasonStats struct {
+	Closed     int64         `json:"closed"`      // rejected or withdrawn applications
+	WithReason int64         `json:"with_reason"` // of those, how many have a closed_reason
+	Reasons    []ReasonCount `json:"reasons"`     // most common first
+}
+
+// GetRejectionReasonStats handles GET /api/stats/rejection-reasons
+// Returns the top ?limit=N (default 10) closed reasons of rejected/withdrawn applications
+// Reasons are compared case-insensitively, ignoring extra whitespace and trailing periods
+func (h *StatsHandler) GetRejectionReasonStats(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	limit := DefaultRejectionReasons
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > MaxRejectionReasons {
+			sendBadRequest(c, "Invalid limit parameter", "limit must be a number between 1 and "+strconv.Itoa(MaxRejectionReasons))
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := c.Request.Context()
+
+	counts, err := h.queries.GetClosedApplicationCounts(ctx, userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch rejection reason stats", err)
+		return
+	}
+
+	rows, err := h.queries.GetClosedReasonCounts(ctx, database.GetClosedReasonCountsParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch rejection reason stats", err)
+		return
+	}
+
+	reasons := make([]ReasonCount, len(rows))
+	for i, row := range rows {
+		reasons[i] = ReasonCount{Reason: row.Reason, Count: row.Count}
+	}
+
+	c.JSON(http.StatusOK, RejectionReasonStats{
+		Closed:     counts.Closed,
+		WithReason: counts.WithReason,
+		Reasons:    reasons,
+	})
+}
+
+// ratio returns part/total, or 0 when total is 0
+func ratio(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
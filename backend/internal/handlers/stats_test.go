@@ -0,0 +1,478 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// createTestApplication creates an application for stats tests with the given status and source
+func createTestApplication(t *testing.T, queries *database.Queries, userID int32, status, source string) database.Application {
+	application, err := queries.CreateApplication(context.Background(), database.CreateApplicationParams{
+		Status:      status,
+		AppliedDate: time.Now(),
+		UserID:      userID,
+		Source:      sql.NullString{String: source, Valid: source != ""},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test application: %v", err)
+	}
+	return application
+}
+
+// TestGetStatsBySource tests GET /api/stats/by-source
+func TestGetStatsBySource(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-by-source@example.com")
+	defer cleanup()
+
+	createTestApplication(t, queries, testUser.ID, "applied", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "interview", "linkedin")
+	createTestApplication(t, queries, testUser.ID, "offer", "referral")
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	req := httptest.NewRequest("GET", "/api/stats/by-source", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var stats []SourceStat
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	bySource := make(map[string]SourceStat)
+	for _, s := range stats {
+		bySource[s.Source] = s
+	}
+
+	if got := bySource["linkedin"]; got.Total != 2 || got.Interviews != 1 || got.InterviewRate != 0.5 {
+		t.Errorf("Unexpected linkedin stats: %+v", got)
+	}
+	if got := bySource["referral"]; got.Total != 1 || got.Offers != 1 {
+		t.Errorf("Unexpected referral stats: %+v", got)
+	}
+	if got := bySource["unspecified"]; got.Total != 1 {
+		t.Errorf("Expected 1 application without a source, got %+v", got)
+	}
+	if len(stats) > 0 && stats[0].Source != "linkedin" {
+		t.Errorf("Expected stats sorted by total desc, got first %s", stats[0].Source)
+	}
+}
+
+// TestGetStatsByIndustry tests GET /api/stats/by-industry
+func TestGetStatsByIndustry(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-by-industry@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	// Create companies with and without an industry, and one application+job per entry
+	companyIndustries := []string{"Fintech", "fintech", "Healthcare", ""}
+	for i, industry := range companyIndustries {
+		company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+			Name:     "Industry Company " + strconv.Itoa(i),
+			UserID:   testUser.ID,
+			Industry: sql.NullString{String: industry, Valid: industry != ""},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test company: %v", err)
+		}
+		application := createTestApplication(t, queries, testUser.ID, "applied", "")
+		if _, err := queries.CreateJob(ctx, database.CreateJobParams{
+			ApplicationID: application.ID,
+			CompanyID:     company.ID,
+			Title:         "Engineer",
+		}); err != nil {
+			t.Fatalf("Failed to create test job: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/stats/by-industry", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var stats []IndustryStat
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 industry buckets, got %+v", stats)
+	}
+	if stats[0].Industry != "fintech" || stats[0].Total != 2 {
+		t.Errorf("Expected fintech (2) first, got %+v", stats[0])
+	}
+
+	byIndustry := make(map[string]int64)
+	for _, s := range stats {
+		byIndustry[s.Industry] = s.Total
+	}
+	if byIndustry["unspecified"] != 1 {
+		t.Errorf("Expected 1 application for companies without an industry, got %d", byIndustry["unspecified"])
+	}
+}
+
+// TestGetOfferStats tests GET /api/stats/offers
+func TestGetOfferStats(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-offers@example.com")
+	defer cleanup()
+
+	createOffer := func(status string, salary int64, currency, decision string) {
+		_, err := queries.CreateApplication(context.Background(), database.CreateApplicationParams{
+			Status:        status,
+			AppliedDate:   time.Now(),
+			UserID:        testUser.ID,
+			OfferSalary:   sql.NullInt64{Int64: salary, Valid: salary > 0},
+			OfferCurrency: sql.NullString{String: currency, Valid: currency != ""},
+			Decision:      sql.NullString{String: decision, Valid: decision != ""},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test offer: %v", err)
+		}
+	}
+	createOffer("accepted", 100000, "USD", "")
+	createOffer("offer", 80000, "USD", "declined")
+	createOffer("offer", 90000, "EUR", "pending")
+	createOffer("offer", 0, "", "")
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+
+	req := httptest.NewRequest("GET", "/api/stats/offers", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var stats OfferStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if stats.Offers != 4 || stats.Accepted != 1 || stats.Declined != 1 || stats.Pending != 2 {
+		t.Errorf("Unexpected offer counts: %+v", stats)
+	}
+	if stats.AcceptanceRate != 0.25 {
+		t.Errorf("Expected acceptance rate 0.25, got %v", stats.AcceptanceRate)
+	}
+	if len(stats.ByCurrency) != 2 || stats.ByCurrency[0].Currency != "USD" {
+		t.Fatalf("Expected USD then EUR salary stats, got %+v", stats.ByCurrency)
+	}
+	if usd := stats.ByCurrency[0]; usd.Offers != 2 || usd.MinSalary != 80000 || usd.MaxSalary != 100000 || usd.AvgSalary != 90000 {
+		t.Errorf("Unexpected USD salary stats: %+v", usd)
+	}
+}
+
+// TestGetReferralStats tests referral attribution: creating with referred_by_contact_id,
+// GET /api/contacts/:id/referrals and GET /api/stats/referrals
+func TestGetReferralStats(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-referrals@example.com")
+	defer cleanup()
+
+	contact, err := queries.CreateContact(context.Background(), database.CreateContactParams{
+		Name:   "Referrer",
+		UserID: testUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+
+	createReferred := func(status string, contactID int32) int {
+		body, _ := json.Marshal(map[string]interface{}{
+			"status":                 status,
+			"applied_date":           "2024-01-15",
+			"referred_by_contact_id": contactID,
+		})
+		req := httptest.NewRequest("POST", "/api/applications", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	for _, status := range []string{"applied", "interview", "offer", "rejected"} {
+		if code := createReferred(status, contact.ID); code != http.StatusCreated {
+			t.Fatalf("Expected status %d creating a referred application, got %d", http.StatusCreated, code)
+		}
+	}
+	createTestApplication(t, queries, testUser.ID, "offer", "")
+
+	t.Run("Unknown referring contact", func(t *testing.T) {
+		if code := createReferred("applied", contact.ID+100000); code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, code)
+		}
+	})
+
+	t.Run("Contact referrals", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/contacts/"+strconv.Itoa(int(contact.ID))+"/referrals", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var applications []database.Application
+		if err := json.Unmarshal(w.Body.Bytes(), &applications); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(applications) != 4 {
+			t.Errorf("Expected 4 referred applications, got %d", len(applications))
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/stats/referrals", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var stats ReferralStats
+		if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if stats.Total != 4 || stats.Interviews != 2 || stats.Offers != 1 || stats.OfferRate != 0.25 {
+			t.Errorf("Unexpected referral stats: %+v", stats)
+		}
+		if len(stats.ByContact) != 1 || stats.ByContact[0].ContactID != contact.ID || stats.ByContact[0].InterviewRate != 0.5 {
+			t.Errorf("Unexpected per-contact stats: %+v", stats.ByContact)
+		}
+	})
+}
+
+// TestGetApplicationsTimeline tests GET /api/stats/applications/timeline
+func TestGetApplicationsTimeline(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-timeline@example.com")
+	defer cleanup()
+
+	thisMonth := todayUTC().AddDate(0, 0, 1-todayUTC().Day())
+	for _, appliedDate := range []time.Time{thisMonth, thisMonth, thisMonth.AddDate(0, -2, 0), thisMonth.AddDate(-2, 0, 0)} {
+		_, err := queries.CreateApplication(context.Background(), database.CreateApplicationParams{
+			Status:      "applied",
+			AppliedDate: appliedDate,
+			UserID:      testUser.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test application: %v", err)
+		}
+	}
+
+	getTimeline := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/stats/applications/timeline"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := getTimeline("?months=3")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var timeline []MonthCount
+	if err := json.Unmarshal(w.Body.Bytes(), &timeline); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	expected := []MonthCount{
+		{Month: thisMonth.AddDate(0, -2, 0).Format("2006-01"), Count: 1},
+		{Month: thisMonth.AddDate(0, -1, 0).Format("2006-01"), Count: 0},
+		{Month: thisMonth.Format("2006-01"), Count: 2},
+	}
+	if len(timeline) != len(expected) {
+		t.Fatalf("Expected %d months, got %+v", len(expected), timeline)
+	}
+	for i := range expected {
+		if timeline[i] != expected[i] {
+			t.Errorf("Month %d: expected %+v, got %+v", i, expected[i], timeline[i])
+		}
+	}
+
+	w = getTimeline("")
+	if err := json.Unmarshal(w.Body.Bytes(), &timeline); err != nil || len(timeline) != DefaultTimelineMonths {
+		t.Errorf("Expected %d months by default, got %d (%v)", DefaultTimelineMonths, len(timeline), err)
+	}
+
+	for _, query := range []string{"?months=0", "?months=" + strconv.Itoa(MaxTimelineMonths+1), "?months=abc"} {
+		if w := getTimeline(query); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+// TestGetRejectionReasonStats tests closed_reason on create and GET /api/stats/rejection-reasons
+func TestGetRejectionReasonStats(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-rejection-reasons@example.com")
+	defer cleanup()
+
+	createClosed := func(status, reason string) int {
+		body, _ := json.Marshal(map[string]interface{}{
+			"status":        status,
+			"applied_date":  "2024-01-15",
+			"closed_reason": reason,
+		})
+		req := httptest.NewRequest("POST", "/api/applications", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	for _, tt := range []struct{ status, reason string }{
+		{"rejected", "No visa sponsorship"},
+		{"rejected", "no  visa sponsorship."},
+		{"withdrawn", "Position filled"},
+		{"rejected", ""},
+	} {
+		if code := createClosed(tt.status, tt.reason); code != http.StatusCreated {
+			t.Fatalf("Expected status %d creating a closed application, got %d", http.StatusCreated, code)
+		}
+	}
+
+	t.Run("Reason requires a closed status", func(t *testing.T) {
+		if code := createClosed("applied", "Changed my mind"); code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, code)
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/stats/rejection-reasons", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var stats RejectionReasonStats
+		if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if stats.Closed != 4 || stats.WithReason != 3 {
+			t.Errorf("Unexpected closed counts: %+v", stats)
+		}
+		expected := []ReasonCount{{Reason: "no visa sponsorship", Count: 2}, {Reason: "position filled", Count: 1}}
+		if len(stats.Reasons) != len(expected) || stats.Reasons[0] != expected[0] || stats.Reasons[1] != expected[1] {
+			t.Errorf("Expected reasons %+v, got %+v", expected, stats.Reasons)
+		}
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/stats/rejection-reasons?limit=0", nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+// TestGetStatsByCompany tests GET /api/stats/by-company
+func TestGetStatsByCompany(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-stats-by-company@example.com")
+	defer cleanup()
+	ctx := context.Background()
+
+	// Companies with 2, 1 and 0 applications (one application+job each)
+	for i, applications := range []int{2, 1, 0} {
+		company, err := queries.CreateCompany(ctx, database.CreateCompanyParams{
+			Name:   "Stats Company " + strconv.Itoa(i),
+			UserID: testUser.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test company: %v", err)
+		}
+		for j := 0; j < applications; j++ {
+			application := createTestApplication(t, queries, testUser.ID, "applied", "")
+			if _, err := queries.CreateJob(ctx, database.CreateJobParams{
+				ApplicationID: application.ID,
+				CompanyID:     company.ID,
+				Title:         "Engineer",
+			}); err != nil {
+				t.Fatalf("Failed to create test job: %v", err)
+			}
+		}
+	}
+
+	getStats := func(query string) ([]CompanyStat, PaginationMeta) {
+		req := httptest.NewRequest("GET", "/api/stats/by-company"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response struct {
+			Data []CompanyStat  `json:"data"`
+			Meta PaginationMeta `json:"meta"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.Data, response.Meta
+	}
+
+	stats, meta := getStats("")
+	if len(stats) != 2 || meta.TotalCount != 2 {
+		t.Fatalf("Expected 2 companies with applications, got %+v (%+v)", stats, meta)
+	}
+	if stats[0].CompanyName != "Stats Company 0" || stats[0].Applications != 2 || stats[1].Applications != 1 {
+		t.Errorf("Expected companies ordered by application count, got %+v", stats)
+	}
+
+	stats, meta = getStats("?include_empty=true&page=2&limit=2")
+	if meta.TotalCount != 3 || len(stats) != 1 || stats[0].Applications != 0 {
+		t.Errorf("Expected the empty company on page 2, got %+v (%+v)", stats, meta)
+	}
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TagHandler handles HTTP requests for tags and tagging applications
+type TagHandler struct {
+	db      *sql.DB
+	queries *database.Queries
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(db *sql.DB, queries *database.Queries) *TagHandler {
+	return &TagHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// TagRequest represents the JSON body for creating a tag
+type TagRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+// BulkTagRequest represents the JSON body for applying or removing a tag on many applications
+type BulkTagRequest struct {
+	ApplicationIDs []int32 `json:"application_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence order
+func uniqueIDs(ids []int32) []int32 {
+	unique := make([]int32, 0, len(ids))
+	seen := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// skippedIDs returns the requested IDs that are not in changed, in request order
+func skippedIDs(requested, changed []int32) []int32 {
+	done := make(map[int32]bool, len(changed))
+	for _, id := range changed {
+		done[id] = true
+	}
+	skipped := []int32{}
+	for _, id := range requested {
+		if !done[id] {
+			skipped = append(skipped, id)
+		}
+	}
+	return skipped
+}
+
+// parseTagID parses the :id param as a tag ID
+// Sends a 400 response and returns false if it is not a number
+func parseTagID(c *gin.Context) (int32, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid tag ID", "ID must be a number")
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// GetTags handles GET /api/tags
+// Returns all tags of the authenticated user, ordered by name
+func (h *TagHandler) GetTags(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.queries.GetTagsByUserID(c.Request.Context(), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch tags", err)
+		return
+	}
+	if tags == nil {
+		tags = []database.Tag{}
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// CreateTag handles POST /api/tags
+// Tag names are unique per user (case-insensitive)
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req TagRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		sendBadRequest(c, "Invalid name", "name must not be blank")
+		return
+	}
+
+	tag, err := h.queries.CreateTag(c.Request.Context(), database.CreateTagParams{
+		Name:   name,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Tag") {
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// DeleteTag handles DELETE /api/tags/:id
+// Deletes a tag and detaches it from all applications (verifies ownership)
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	tagID, ok := parseTagID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Fetch then delete in one transaction so the response holds exactly what was deleted
+	var tag database.Tag
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		var err error
+		tag, err = qtx.GetTagByIDAndUserID(ctx, database.GetTagByIDAndUserIDParams{
+			ID:     tagID,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return qtx.DeleteTag(ctx, database.DeleteTagParams{
+			ID:     tagID,
+			UserID: userID,
+		})
+	})
+	if handleDatabaseError(c, err, "Tag") {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+		"id":      tagID,
+		"deleted": tag,
+	})
+}
+
+// GetApplicationTags handles GET /api/applications/:id/tags
+// Returns the tags attached to an application (verifies ownership)
+func (h *TagHandler) GetApplicationTags(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid application ID", "ID must be a number")
+		return
+	}
+	applicationID := int32(id)
+
+	ctx := c.Request.Context()
+
+	// Verify the application exists and belongs to the user (so an unknown application is a 404, not [])
+	_, err = h.queries.GetApplicationByIDAndUserID(ctx, database.GetApplicationByIDAndUserIDParams{
+		ID:     applicationID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+
+	tags, err := h.queries.GetTagsByApplicationIDAndUserID(ctx, database.GetTagsByApplicationIDAndUserIDParams{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch tags", err)
+		return
+	}
+	if tags == nil {
+		tags = []database.Tag{}
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// ApplyTag handles POST /api/tags/:id/apply
+// Attaches the tag to every listed application owned by the user
+func (h *TagHandler) ApplyTag(c *gin.Context) {
+	h.bulkTag(c, "applied to", func(ctx context.Context, qtx *database.Queries, tagID int32, ids []int32, userID int32) ([]int32, error) {
+		return qtx.AttachTagToApplications(ctx, database.AttachTagToApplicationsParams{
+			TagID:          tagID,
+			ApplicationIds: ids,
+			UserID:         userID,
+		})
+	})
+}
+
+// RemoveTag handles POST /api/tags/:id/remove
+// Detaches the tag from every listed application owned by the user
+func (h *TagHandler) RemoveTag(c *gin.Context) {
+	h.bulkTag(c, "removed from", func(ctx context.Context, qtx *database.Queries, tagID int32, ids []int32, userID int32) ([]int32, error) {
+		return qtx.DetachTagFromApplications(ctx, database.DetachTagFromApplicationsParams{
+			TagID:          tagID,
+			ApplicationIds: ids,
+			UserID:         userID,
+		})
+	})
+}
+
+// bulkTag verifies tag ownership and runs change for the requested application IDs in one transaction
+// The response lists the changed IDs under "application_ids" and the rest under "skipped_ids"
+// (applications that don't exist, aren't the user's, or already had the requested tag state)
+func (h *TagHandler) bulkTag(c *gin.Context, action string, change func(ctx context.Context, qtx *database.Queries, tagID int32, ids []int32, userID int32) ([]int32, error)) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	tagID, ok := parseTagID(c)
+	if !ok {
+		return
+	}
+
+	var req BulkTagRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	ids := uniqueIDs(req.ApplicationIDs)
+	if len(ids) > MaxBatchIDs {
+		sendBadRequest(c, "Too many application_ids", fmt.Sprintf("At most %d application_ids can be tagged at once", MaxBatchIDs))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var changed []int32
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		// Verify the tag exists and belongs to the user
+		if _, err := qtx.GetTagByIDAndUserID(ctx, database.GetTagByIDAndUserIDParams{
+			ID:     tagID,
+			UserID: userID,
+		}); err != nil {
+			return err
+		}
+
+		var err error
+		changed, err = change(ctx, qtx, tagID, ids, userID)
+		return err
+	})
+	if handleDatabaseError(c, err, "Tag") {
+		return
+	}
+	if changed == nil {
+		changed = []int32{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         fmt.Sprintf("Tag %s %d application(s)", action, len(changed)),
+		"tag_id":          tagID,
+		"application_ids": changed,
+		"skipped_ids":     skippedIDs(ids, changed),
+	})
+}
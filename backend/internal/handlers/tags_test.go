@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestSkippedIDs tests which requested IDs are reported as skipped
+func TestSkippedIDs(t *testing.T) {
+	got := skippedIDs(uniqueIDs([]int32{3, 1, 3, 2}), []int32{1})
+	if want := []int32{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := skippedIDs([]int32{1}, []int32{1}); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty non-nil slice, got %#v", got)
+	}
+}
+
+// TestBulkTagApplications tests POST /api/tags/:id/apply and /remove
+func TestBulkTagApplications(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create test users
+	testUser, cleanup := createTestUser(t, queries, db, "test-tags@example.com")
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, "test-tags-other@example.com")
+	defer otherCleanup()
+
+	first := createTestApplication(t, queries, testUser.ID, "applied", "")
+	second := createTestApplication(t, queries, testUser.ID, "applied", "")
+	foreign := createTestApplication(t, queries, otherUser.ID, "applied", "")
+
+	request := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			buf.Write(jsonBody)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	type bulkResponse struct {
+		ApplicationIDs []int32 `json:"application_ids"`
+		SkippedIDs     []int32 `json:"skipped_ids"`
+	}
+
+	// Create a tag
+	w := request("POST", "/api/tags", testUser.Token, map[string]interface{}{"name": "Remote"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var tag database.Tag
+	if err := json.Unmarshal(w.Body.Bytes(), &tag); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	tagPath := "/api/tags/" + strconv.Itoa(int(tag.ID))
+
+	// Test the tag name is unique per user regardless of case
+	if w = request("POST", "/api/tags", testUser.Token, map[string]interface{}{"name": "remote"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for duplicate tag, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test apply skips other users' applications and duplicate IDs
+	w = request("POST", tagPath+"/apply", testUser.Token, map[string]interface{}{
+		"application_ids": []int32{first.ID, second.ID, first.ID, foreign.ID},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp bulkResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(resp.ApplicationIDs) != 2 || !reflect.DeepEqual(resp.SkippedIDs, []int32{foreign.ID}) {
+		t.Errorf("Unexpected apply result: %+v", resp)
+	}
+
+	// Test applying again changes nothing
+	w = request("POST", tagPath+"/apply", testUser.Token, map[string]interface{}{"application_ids": []int32{first.ID}})
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(resp.ApplicationIDs) != 0 || len(resp.SkippedIDs) != 1 {
+		t.Errorf("Expected re-applying to skip the application, got %+v", resp)
+	}
+
+	// Test the application lists its tag
+	w = request("GET", "/api/applications/"+strconv.Itoa(int(first.ID))+"/tags", testUser.Token, nil)
+	var tags []database.Tag
+	if err := json.Unmarshal(w.Body.Bytes(), &tags); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(tags) != 1 || tags[0].ID != tag.ID {
+		t.Errorf("Expected application to have tag %d, got %+v", tag.ID, tags)
+	}
+
+	// Test another user cannot use the tag
+	if w = request("POST", tagPath+"/apply", otherUser.Token, map[string]interface{}{"application_ids": []int32{foreign.ID}}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's tag, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Test invalid bodies and the cap on the number of IDs
+	if w = request("POST", tagPath+"/apply", testUser.Token, map[string]interface{}{"application_ids": []int32{}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for empty application_ids, got %d", http.StatusBadRequest, w.Code)
+	}
+	tooMany := make([]int32, MaxBatchIDs+1)
+	for i := range tooMany {
+		tooMany[i] = int32(i + 1)
+	}
+	if w = request("POST", tagPath+"/apply", testUser.Token, map[string]interface{}{"application_ids": tooMany}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for too many application_ids, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test remove
+	w = request("POST", tagPath+"/remove", testUser.Token, map[string]interface{}{"application_ids": []int32{first.ID, foreign.ID}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !reflect.DeepEqual(resp.ApplicationIDs, []int32{first.ID}) || !reflect.DeepEqual(resp.SkippedIDs, []int32{foreign.ID}) {
+		t.Errorf("Unexpected remove result: %+v", resp)
+	}
+
+	// Test delete
+	if w = request("DELETE", tagPath, testUser.Token, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w = request("POST", tagPath+"/apply", testUser.Token, map[string]interface{}{"application_ids": []int32{second.ID}}); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -68,6 +68,7 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *database.Queries, *sql.DB) {
 	r := gin.New()
 	cfg := Config{
 		DB:            queries,
+		DBConn:        db,
 		UseLegacyAuth: true,
 	}
 	cfg.SetupRoutes(r)
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TrackCompanyRequest is the company part of POST /api/track (get-or-create by normalized name)
+type TrackCompanyRequest struct {
+	Name    string `json:"name" binding:"required,min=1,max=255"`
+	Website string `json:"website" binding:"omitempty,url,max=255"` // only used when the company is created
+}
+
+// TrackJobRequest is the job part of POST /api/track
+type TrackJobRequest struct {
+	Title          string `json:"title" binding:"required,min=1,max=255"`
+	Description    string `json:"description" binding:"omitempty,max=10000"`
+	Requirements   string `json:"requirements" binding:"omitempty,max=10000"`
+	Location       string `json:"location" binding:"omitempty,max=255"`
+	EmploymentType string `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary"`
+}
+
+// TrackRequest represents the JSON body for POST /api/track
+// application takes the same fields as POST /api/applications
+type TrackRequest struct {
+	Company     TrackCompanyRequest      `json:"company"`
+	Job         TrackJobRequest          `json:"job"`
+	Application CreateApplicationRequest `json:"application"`
+}
+
+// TrackResponse is the application created by POST /api/track with its job and company
+type TrackResponse struct {
+	Application    database.Application `json:"application"`
+	Job            database.Job         `json:"job"`
+	Company        database.Company     `json:"company"`
+	CompanyCreated bool                 `json:"company_created"` // false when an existing company was reused
+}
+
+// TrackApplication handles POST /api/track
+// Creates an application, its job and (get-or-create) its company in one transaction
+// Nothing is created if any part fails
+func (h *ApplicationHandler) TrackApplication(c *gin.Context) {
+	// Parse JSON body
+	var req TrackRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	params, ok := h.createApplicationParams(c, userID, req.Application)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var response TrackResponse
+	err := withTx(ctx, h.db, h.queries, func(qtx *database.Queries) error {
+		var err error
+		response.Company, response.CompanyCreated, err = getOrCreateCompany(ctx, qtx, userID, req.Company.Name, req.Company.Website)
+		if err != nil {
+			return err
+		}
+
+		// Reset in case withTx retries the transaction
+		applicationParams := params
+		if req.Application.Contact != nil {
+			contact, _, err := getOrCreateContact(ctx, qtx, userID, *req.Application.Contact)
+			if err != nil {
+				return err
+			}
+			applicationParams.ContactID = sql.NullInt32{Int32: contact.ID, Valid: true}
+		}
+
+		response.Application, err = qtx.CreateApplication(ctx, applicationParams)
+		if err != nil {
+			return err
+		}
+
+		response.Job, err = qtx.CreateJob(ctx, database.CreateJobParams{
+			ApplicationID:  response.Application.ID,
+			CompanyID:      response.Company.ID,
+			Title:          req.Job.Title,
+			Description:    sql.NullString{String: req.Job.Description, Valid: req.Job.Description != ""},
+			Requirements:   sql.NullString{String: req.Job.Requirements, Valid: req.Job.Requirements != ""},
+			Location:       sql.NullString{String: req.Job.Location, Valid: req.Job.Location != ""},
+			EmploymentType: sql.NullString{String: req.Job.EmploymentType, Valid: req.Job.EmploymentType != ""},
+		})
+		return err
+	})
+	if handleDatabaseError(c, err, "Application") {
+		return
+	}
+	if response.CompanyCreated {
+		h.counts.Invalidate(countResourceCompanies, userID)
+	}
+	h.counts.Invalidate(countResourceApplications, userID)
+	h.counts.Invalidate(countResourceJobs, userID)
+	h.webhooks.Publish(userID, WebhookEventApplicationCreated, response.Application)
+
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestTrackApplication tests POST /api/track
+func TestTrackApplication(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-track@example.com")
+	defer cleanup()
+
+	track := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/track", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	body := func(jobTitle string) map[string]interface{} {
+		return map[string]interface{}{
+			"company":     map[string]interface{}{"name": "Track Test Co", "website": "https://track.example.com"},
+			"job":         map[string]interface{}{"title": jobTitle, "location": "Remote"},
+			"application": map[string]interface{}{"status": "applied", "applied_date": "2024-01-15", "source": "linkedin"},
+		}
+	}
+
+	w := track(body("Backend Engineer"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var first TrackResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !first.CompanyCreated || first.Company.Name != "Track Test Co" {
+		t.Errorf("Expected a new company, got %+v (created=%v)", first.Company, first.CompanyCreated)
+	}
+	if first.Job.ApplicationID != first.Application.ID || first.Job.CompanyID != first.Company.ID {
+		t.Errorf("Expected the job to link the application and company, got %+v", first.Job)
+	}
+	if first.Application.Source.String != "linkedin" || first.Job.Title != "Backend Engineer" {
+		t.Errorf("Unexpected application or job: %+v, %+v", first.Application, first.Job)
+	}
+
+	t.Run("Reuses existing company", func(t *testing.T) {
+		w := track(body("Platform Engineer"))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var second TrackResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if second.CompanyCreated || second.Company.ID != first.Company.ID {
+			t.Errorf("Expected company %d to be reused, got %+v (created=%v)", first.Company.ID, second.Company, second.CompanyCreated)
+		}
+	})
+
+	t.Run("Missing job title", func(t *testing.T) {
+		if w := track(body("")); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("Invalid application fields", func(t *testing.T) {
+		invalid := body("Data Engineer")
+		invalid["application"] = map[string]interface{}{"status": "applied", "applied_date": "15/01/2024"}
+		if w := track(invalid); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
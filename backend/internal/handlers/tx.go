@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// withTx runs fn inside a database transaction using a transaction-bound Queries
+// Commits if fn returns nil, otherwise rolls back and returns fn's error
+// With RetryTransientDBErrors, a transaction that lost its connection before commit is run once more
+func withTx(ctx context.Context, db *sql.DB, queries *database.Queries, fn func(qtx *database.Queries) error) error {
+	atCommit, err := runTx(ctx, db, queries, fn)
+	// A failed commit may already have been applied, so only failures before it are retried
+	if err != nil && !atCommit && shouldRetryTransient(ctx, err) {
+		_, err = runTx(ctx, db, queries, fn)
+	}
+	return err
+}
+
+// runTx runs fn in a single transaction; atCommit reports whether a returned error came from Commit
+func runTx(ctx context.Context, db *sql.DB, queries *database.Queries, fn func(qtx *database.Queries) error) (atCommit bool, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	// Rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// errDryRun makes a dry-run transaction roll back once fn has run (see withDryRunTx)
+var errDryRun = errors.New("dry run")
+
+// withDryRunTx runs fn like withTx, but rolls the transaction back instead of committing when dryRun is set,
+// so a request can report what it would do without writing anything
+func withDryRunTx(ctx context.Context, db *sql.DB, queries *database.Queries, dryRun bool, fn func(qtx *database.Queries) error) error {
+	err := withTx(ctx, db, queries, func(qtx *database.Queries) error {
+		if err := fn(qtx); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
@@ -2,22 +2,31 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
+	clerkjwt "github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
 	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/auth"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
 )
 
 // UserHandler handles HTTP requests for user authentication
 type UserHandler struct {
-	queries *database.Queries
+	queries   *database.Queries
+	clerkJWKS *jwks.Client // verifies Clerk tokens in Introspect; nil checks legacy access tokens instead
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(queries *database.Queries) *UserHandler {
+// Pass the Clerk JWKS client when requests are authenticated with Clerk, nil with legacy auth
+func NewUserHandler(queries *database.Queries, clerkJWKS *jwks.Client) *UserHandler {
 	return &UserHandler{
-		queries: queries,
+		queries:   queries,
+		clerkJWKS: clerkJWKS,
 	}
 }
 
@@ -39,8 +48,15 @@ type RegisterResponse struct {
 }
 
 // Register handles POST /api/auth/register
-// Deprecated: sign-up is now via Clerk. Returns 410 Gone.
+// Deprecated: sign-up is now via Clerk. Returns 410 Gone, or 403 when REGISTRATION_ENABLED=false.
 func (h *UserHandler) Register(c *gin.Context) {
+	if !middleware.RegistrationEnabled {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Registration disabled",
+			"message": middleware.RegistrationDisabledMessage,
+		})
+		return
+	}
 	c.JSON(http.StatusGone, gin.H{
 		"error":   "Use Clerk for sign-up",
 		"message": "This endpoint is no longer available. Please use Clerk for sign-up.",
@@ -74,7 +90,30 @@ func (h *UserHandler) Refresh(c *gin.Context) {
 
 // Logout handles POST /api/auth/logout
 // No-op for Clerk; session is ended on the frontend via signOut().
+// With legacy tokens and ACCESS_TOKEN_DENYLIST=true, the presented access token is revoked (its jti is denylisted).
 func (h *UserHandler) Logout(c *gin.Context) {
+	if jti := c.GetString("token_jti"); jti != "" {
+		userID, ok := requireAuth(c)
+		if !ok {
+			return
+		}
+		expiresAt, _ := c.Get("token_expires_at")
+		expiry, ok := expiresAt.(time.Time)
+		if !ok {
+			// A token without an exp claim never expires, so its entry must never be pruned
+			expiry = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+		}
+		err := h.queries.RevokeAccessToken(c.Request.Context(), database.RevokeAccessTokenParams{
+			Jti:       jti,
+			UserID:    userID,
+			ExpiresAt: expiry.UTC(),
+		})
+		if err != nil {
+			sendInternalError(c, "Failed to revoke token", err)
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
@@ -105,8 +144,10 @@ func (h *UserHandler) Me(c *gin.Context) {
 		ID    int32  `json:"id"`
 		Email string `json:"email"`
 		Name  string `json:"name"`
+		Role  string `json:"role"` // "user" or "admin"
 	}
 	userResponse.ID = user.ID
+	userResponse.Role = user.Role
 	userResponse.Email = user.Email
 	if user.Name.Valid {
 		userResponse.Name = user.Name.String
@@ -133,18 +174,18 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 
 	// Parse JSON body
 	var req UpdateMeRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		sendValidationError(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	// Convert name to sql.NullString
+	// Convert name to sql.NullString (already trimmed by bindJSON)
 	var name sql.NullString
-	if strings.TrimSpace(req.Name) != "" {
+	if req.Name != "" {
 		name = sql.NullString{
-			String: strings.TrimSpace(req.Name),
+			String: req.Name,
 			Valid:  true,
 		}
 	}
@@ -164,8 +205,10 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 		ID    int32  `json:"id"`
 		Email string `json:"email"`
 		Name  string `json:"name"`
+		Role  string `json:"role"` // "user" or "admin"
 	}
 	userResponse.ID = user.ID
+	userResponse.Role = user.Role
 	userResponse.Email = user.Email
 	if user.Name.Valid {
 		userResponse.Name = user.Name.String
@@ -177,3 +220,276 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 }
 
 
+
+// PatchMeRequest represents the JSON body for PATCH /api/auth/me
+// Omitted fields keep their current value; an empty name clears it
+type PatchMeRequest struct {
+	Name  *string `json:"name" binding:"omitempty,max=255"`
+	Email *string `json:"email" binding:"omitempty,email,max=255"`
+}
+
+// PatchMe handles PATCH /api/auth/me
+// Partially updates the current user's name and/or email
+// Email changes are rejected with 409 if another user has the address (case-insensitive).
+// Users signed in with Clerk get 403 for email changes: Clerk owns and verifies their address
+// (there are no local passwords or verification emails to re-check it with).
+func (h *UserHandler) PatchMe(c *gin.Context) {
+	// Get user_id from context (set by auth middleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	// Parse JSON body
+	var req PatchMeRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+	if req.Name == nil && req.Email == nil {
+		sendBadRequest(c, "Nothing to update", "Provide at least one of: name, email")
+		return
+	}
+	if req.Email != nil && *req.Email == "" {
+		sendBadRequest(c, "Invalid email", "email must not be empty")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Get user from database
+	user, err := h.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			sendError(c, http.StatusNotFound, "User not found")
+			return
+		}
+		sendInternalError(c, "Failed to fetch user", err)
+		return
+	}
+
+	name := user.Name
+	if req.Name != nil {
+		name = sql.NullString{String: *req.Name, Valid: *req.Name != ""}
+	}
+
+	email := user.Email
+	if req.Email != nil && *req.Email != user.Email {
+		if user.ClerkUserID.Valid {
+			sendError(c, http.StatusForbidden, "Email is managed by Clerk", "Change your email in your account settings so the new address can be verified")
+			return
+		}
+
+		// Check if another user already has this email
+		existing, err := h.queries.GetUserByEmail(ctx, *req.Email)
+		if err == nil && existing.ID != user.ID {
+			sendError(c, http.StatusConflict, "Email already in use")
+			return
+		}
+		if err != nil && err != sql.ErrNoRows {
+			sendInternalError(c, "Failed to check for existing email", err)
+			return
+		}
+		email = *req.Email
+	}
+
+	// Update user
+	user, err = h.queries.UpdateUserEmailAndName(ctx, database.UpdateUserEmailAndNameParams{
+		ID:    userID,
+		Email: email,
+		Name:  name,
+	})
+	if err != nil {
+		// Another request may have taken the email between our check and the update
+		errStr := strings.ToLower(err.Error())
+		if strings.Contains(errStr, "duplicate") || strings.Contains(errStr, "unique") {
+			sendError(c, http.StatusConflict, "Email already in use")
+			return
+		}
+		sendInternalError(c, "Failed to update user", err)
+		return
+	}
+
+	// Return updated user info
+	var userResponse struct {
+		ID    int32  `json:"id"`
+		Email string `json:"email"`
+		Name  string `json:"name"`
+		Role  string `json:"role"` // "user" or "admin"
+	}
+	userResponse.ID = user.ID
+	userResponse.Role = user.Role
+	userResponse.Email = user.Email
+	if user.Name.Valid {
+		userResponse.Name = user.Name.String
+	} else {
+		userResponse.Name = ""
+	}
+
+	c.JSON(http.StatusOK, userResponse)
+}
+
+// UsageResponse represents the per-user data usage summary
+// Storage used by attachments will be added here once attachments exist
+type UsageResponse struct {
+	Companies    int64 `json:"companies"`
+	Applications int64 `json:"applications"`
+	Contacts     int64 `json:"contacts"`
+	Jobs         int64 `json:"jobs"`
+}
+
+// Usage handles GET /api/auth/me/usage
+// Returns how many companies, applications, contacts and jobs the current user is tracking
+// Archived records still count, since they are still stored for the user
+func (h *UserHandler) Usage(c *gin.Context) {
+	// Get user_id from context (set by auth middleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	totals, err := h.queries.GetUserTotals(c.Request.Context(), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch usage", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, UsageResponse{
+		Companies:    totals.Companies,
+		Applications: totals.Applications,
+		Contacts:     totals.Contacts,
+		Jobs:         totals.Jobs,
+	})
+}
+
+// IntrospectRequest represents the JSON body for POST /api/auth/introspect
+type IntrospectRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// IntrospectResponse describes a valid access token
+type IntrospectResponse struct {
+	Active    bool       `json:"active"` // always true; invalid tokens get a 401
+	UserID    int32      `json:"user_id"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // omitted for tokens without an exp claim
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+}
+
+// Introspect handles POST /api/auth/introspect
+// Validates the access token in the body and returns its claims, so other services can check a token without
+// calling a protected endpoint. Tokens are checked like the auth middleware in use: Clerk session tokens
+// (signature via the JWKS, expiry, a known user) or, with legacy auth, access tokens (signature, expiry,
+// issuer/audience and, with ACCESS_TOKEN_DENYLIST, revocation)
+// Returns 401 if the token is not valid; the route is rate limited like the other public auth routes
+func (h *UserHandler) Introspect(c *gin.Context) {
+	// Parse JSON body
+	var req IntrospectRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	var response IntrospectResponse
+	var ok bool
+	if h.clerkJWKS != nil {
+		response, ok = h.introspectClerkToken(c, req.Token)
+	} else {
+		response, ok = h.introspectAccessToken(c, req.Token)
+	}
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// introspectClerkToken validates a Clerk session token and resolves its user like ClerkAuthMiddleware
+// (without creating users: a token of someone who never used the API is not active)
+// Sends a 401/500 response and returns false if the token is not valid
+func (h *UserHandler) introspectClerkToken(c *gin.Context, token string) (IntrospectResponse, bool) {
+	ctx := c.Request.Context()
+
+	claims, err := clerkjwt.Verify(ctx, &clerkjwt.VerifyParams{
+		Token:      token,
+		JWKSClient: h.clerkJWKS,
+	})
+	if err != nil || claims.Subject == "" {
+		sendError(c, http.StatusUnauthorized, "Invalid or expired token")
+		return IntrospectResponse{}, false
+	}
+
+	user, err := h.queries.GetUserByClerkID(ctx, sql.NullString{String: claims.Subject, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		sendError(c, http.StatusUnauthorized, "Unknown user")
+		return IntrospectResponse{}, false
+	}
+	if err != nil {
+		sendInternalError(c, "Failed to check user", err)
+		return IntrospectResponse{}, false
+	}
+	// Disabled users are rejected by the auth middleware, so their tokens aren't active either
+	if user.DisabledAt.Valid {
+		sendError(c, http.StatusUnauthorized, "Account disabled")
+		return IntrospectResponse{}, false
+	}
+
+	response := IntrospectResponse{
+		Active: true,
+		UserID: user.ID,
+	}
+	if claims.Expiry != nil {
+		expiresAt := time.Unix(*claims.Expiry, 0).UTC()
+		response.ExpiresAt = &expiresAt
+	}
+	if claims.IssuedAt != nil {
+		issuedAt := time.Unix(*claims.IssuedAt, 0).UTC()
+		response.IssuedAt = &issuedAt
+	}
+	return response, true
+}
+
+// introspectAccessToken validates a legacy access token like LegacyAuthMiddleware
+// Sends a 401/500 response and returns false if the token is not valid
+func (h *UserHandler) introspectAccessToken(c *gin.Context, token string) (IntrospectResponse, bool) {
+	claims, err := auth.ValidateAccessToken(token)
+	if err != nil {
+		sendError(c, http.StatusUnauthorized, "Invalid or expired token")
+		return IntrospectResponse{}, false
+	}
+
+	// Tokens issued without a jti cannot be revoked (same rule as the auth middleware)
+	if auth.DenylistEnabled() && claims.ID != "" {
+		revoked, err := h.queries.IsAccessTokenRevoked(c.Request.Context(), claims.ID)
+		if err != nil {
+			sendInternalError(c, "Failed to check token", err)
+			return IntrospectResponse{}, false
+		}
+		if revoked {
+			sendError(c, http.StatusUnauthorized, "Token has been revoked")
+			return IntrospectResponse{}, false
+		}
+	}
+
+	// Tokens of disabled users are rejected by the auth middleware, so they aren't active either
+	disabled, err := h.queries.IsUserDisabled(c.Request.Context(), claims.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		sendInternalError(c, "Failed to check user", err)
+		return IntrospectResponse{}, false
+	}
+	if disabled {
+		sendError(c, http.StatusUnauthorized, "Account disabled")
+		return IntrospectResponse{}, false
+	}
+
+	response := IntrospectResponse{
+		Active: true,
+		UserID: claims.UserID,
+	}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = &claims.ExpiresAt.Time
+	}
+	if claims.IssuedAt != nil {
+		response.IssuedAt = &claims.IssuedAt.Time
+	}
+	return response, true
+}
@@ -2,12 +2,22 @@ package handlers
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/auth"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
 )
 
 // TestRegister tests POST /api/auth/register (deprecated; returns 410 Gone)
@@ -32,6 +42,24 @@ func TestRegister(t *testing.T) {
 	}
 }
 
+// TestRegister_Disabled tests that POST /api/auth/register returns 403 when REGISTRATION_ENABLED=false
+func TestRegister_Disabled(t *testing.T) {
+	router, _, db := setupTestRouter(t)
+	defer db.Close()
+
+	middleware.RegistrationEnabled = false
+	defer func() { middleware.RegistrationEnabled = true }()
+
+	req := httptest.NewRequest("POST", "/api/auth/register", bytes.NewBufferString(`{"email":"test-register-disabled@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, w.Code, w.Body.String())
+	}
+}
+
 // TestLogin tests POST /api/auth/login (deprecated; returns 410 Gone)
 func TestLogin(t *testing.T) {
 	router, _, db := setupTestRouter(t)
@@ -124,6 +152,9 @@ func TestMe(t *testing.T) {
 	if userResponse["email"].(string) != testUser.Email {
 		t.Errorf("Expected email %s, got %s", testUser.Email, userResponse["email"].(string))
 	}
+	if userResponse["role"] != "user" {
+		t.Errorf("Expected role user, got %v", userResponse["role"])
+	}
 
 	// Test without authentication (should return 401)
 	req = httptest.NewRequest("GET", "/api/auth/me", nil)
@@ -271,3 +302,205 @@ func TestPublicEndpointsWithoutAuth(t *testing.T) {
 	}
 }
 
+
+// TestUsage tests GET /api/auth/me/usage (only the current user's data is counted)
+func TestUsage(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create two test users
+	testUser, cleanup := createTestUser(t, queries, db, fmt.Sprintf("test-usage-%d@example.com", time.Now().UnixNano()))
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, fmt.Sprintf("test-usage-other-%d@example.com", time.Now().UnixNano()))
+	defer otherCleanup()
+
+	createTestApplication(t, queries, testUser.ID, "applied", "")
+	createTestApplication(t, queries, testUser.ID, "interview", "")
+	createTestApplication(t, queries, otherUser.ID, "applied", "")
+
+	req := httptest.NewRequest("GET", "/api/auth/me/usage", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var usage map[string]int64
+	if err := json.Unmarshal(w.Body.Bytes(), &usage); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if usage["applications"] != 2 {
+		t.Errorf("Expected 2 applications, got %d", usage["applications"])
+	}
+	if usage["companies"] != 0 || usage["contacts"] != 0 {
+		t.Errorf("Expected 0 companies and contacts, got %v", usage)
+	}
+}
+
+// TestLogout_RevokesAccessToken tests that logout denylists the access token when ACCESS_TOKEN_DENYLIST=true
+func TestLogout_RevokesAccessToken(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_DENYLIST", "true")
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-logout-revoke@example.com")
+	defer cleanup()
+
+	send := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send("GET", "/api/auth/me"); code != http.StatusOK {
+		t.Fatalf("Expected status %d before logout, got %d", http.StatusOK, code)
+	}
+	if code := send("POST", "/api/auth/logout"); code != http.StatusOK {
+		t.Fatalf("Expected status %d for logout, got %d", http.StatusOK, code)
+	}
+	if code := send("GET", "/api/auth/me"); code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d with a revoked token, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+// TestIntrospect tests POST /api/auth/introspect
+func TestIntrospect(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_DENYLIST", "true")
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-introspect@example.com")
+	defer cleanup()
+
+	introspect := func(token string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(map[string]string{"token": token})
+		req := httptest.NewRequest("POST", "/api/auth/introspect", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := introspect(testUser.Token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response IntrospectResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.Active || response.UserID != testUser.ID || response.ExpiresAt == nil || !response.ExpiresAt.After(time.Now()) {
+		t.Errorf("Unexpected claims: %+v", response)
+	}
+
+	expired, err := auth.GenerateAccessToken(testUser.ID, -time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	for name, token := range map[string]string{"garbage": "not-a-token", "expired": expired} {
+		if code := introspect(token).Code; code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status %d, got %d", name, http.StatusUnauthorized, code)
+		}
+	}
+
+	// A revoked token is no longer valid
+	req := httptest.NewRequest("POST", "/api/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+testUser.Token)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if code := introspect(testUser.Token).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for a revoked token, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+// TestIntrospect_Clerk tests that POST /api/auth/introspect verifies Clerk tokens when Clerk auth is in use
+func TestIntrospect_Clerk(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewUserHandler(nil, jwks.NewClient(&clerk.ClientConfig{}))
+	router.POST("/api/auth/introspect", handler.Introspect)
+
+	// A malformed token is rejected without fetching the JWKS or looking up a user
+	jsonBody, _ := json.Marshal(map[string]string{"token": "not-a-token"})
+	req := httptest.NewRequest("POST", "/api/auth/introspect", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusUnauthorized, w.Code, w.Body.String())
+	}
+}
+
+// TestPatchMe tests PATCH /api/auth/me (partial name/email update)
+func TestPatchMe(t *testing.T) {
+	router, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	suffix := time.Now().UnixNano()
+	testUser, cleanup := createTestUser(t, queries, db, fmt.Sprintf("test-patch-me-%d@example.com", suffix))
+	defer cleanup()
+	otherUser, otherCleanup := createTestUser(t, queries, db, fmt.Sprintf("test-patch-me-other-%d@example.com", suffix))
+	defer otherCleanup()
+
+	patch := func(token string, body map[string]interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("PATCH", "/api/auth/me", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test changing only the email keeps the name
+	newEmail := fmt.Sprintf("test-patch-me-new-%d@example.com", suffix)
+	w := patch(testUser.Token, map[string]interface{}{"email": newEmail})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var userResponse map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &userResponse); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if userResponse["email"] != newEmail || userResponse["name"] != "Test User" {
+		t.Errorf("Expected email %s and unchanged name, got %v", newEmail, userResponse)
+	}
+
+	// Test duplicate email (case-insensitive)
+	if w := patch(testUser.Token, map[string]interface{}{"email": strings.ToUpper(otherUser.Email)}); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for a duplicate email, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Test nothing to update and invalid email
+	if w := patch(testUser.Token, map[string]interface{}{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an empty body, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := patch(testUser.Token, map[string]interface{}{"email": "not-an-email"}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid email, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Test that Clerk users cannot change their email here
+	clerkUser, err := queries.CreateUserWithClerkID(context.Background(), database.CreateUserWithClerkIDParams{
+		ClerkUserID: sql.NullString{String: fmt.Sprintf("user_test_patch_me_%d", suffix), Valid: true},
+		Email:       fmt.Sprintf("test-patch-me-clerk-%d@example.com", suffix),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Clerk test user: %v", err)
+	}
+	defer cleanupTestUser(t, db, clerkUser.ID)
+	clerkToken, err := auth.GenerateAccessToken(clerkUser.ID, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+	if w := patch(clerkToken, map[string]interface{}{"email": fmt.Sprintf("test-patch-me-clerk-new-%d@example.com", suffix)}); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a Clerk user, got %d", http.StatusForbidden, w.Code)
+	}
+	if w := patch(clerkToken, map[string]interface{}{"name": "Clerk User"}); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d renaming a Clerk user, got %d", http.StatusOK, w.Code)
+	}
+}
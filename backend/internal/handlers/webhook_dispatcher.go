@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// Webhook events sent when an application changes
+const (
+	WebhookEventApplicationCreated = "application.created"
+	WebhookEventApplicationUpdated = "application.updated"
+	WebhookEventApplicationDeleted = "application.deleted"
+)
+
+// Webhook delivery statuses (webhook_deliveries.status)
+const (
+	webhookDeliveryPending   = "pending"
+	webhookDeliverySucceeded = "succeeded"
+	webhookDeliveryFailed    = "failed" // dead-lettered: all attempts used up, kept for inspection and re-delivery
+)
+
+// DefaultWebhookMaxAttempts is how many times a delivery is tried before it is marked failed
+const DefaultWebhookMaxAttempts = 5
+
+const (
+	// webhookQueueSize caps how many jobs wait for a worker; events published while it is full are dropped
+	webhookQueueSize = 1000
+	// webhookWorkers is how many deliveries are sent concurrently
+	webhookWorkers = 4
+	// webhookTimeout bounds one delivery attempt, so a slow endpoint can't hold a worker
+	webhookTimeout = 10 * time.Second
+	// webhookSignatureHeader carries "sha256=" + the hex HMAC-SHA256 of the body keyed with the webhook secret
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// webhookAddressAllowed reports whether webhooks may be sent to ip (swapped in tests to allow local servers)
+var webhookAddressAllowed = isPublicIP
+
+// newWebhookClient creates the HTTP client used to deliver webhooks
+// Like jobPageClient it refuses to connect to non-public addresses, checked on every connection so DNS
+// answers that change after the webhook was created are covered too; redirects are not followed
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhookAddressAllowed(ip) {
+				return errNonPublicAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// WebhookDispatcher delivers webhook events in the background
+// Publish only queues the event, so the triggering request never waits on the network or extra queries.
+// Workers record one webhook_deliveries row per webhook and POST it; failed attempts are retried with
+// exponential backoff until maxAttempts, after which the delivery is marked failed.
+// A nil *WebhookDispatcher disables webhooks (Publish is a no-op).
+type WebhookDispatcher struct {
+	queries     *database.Queries
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration // wait before the first retry; doubled for each following retry
+	maxDelay    time.Duration // upper bound for the wait between retries
+	jobs        chan webhookJob
+}
+
+// webhookJob is either an event to fan out to the user's webhooks or a delivery to attempt
+type webhookJob struct {
+	event      *webhookEvent
+	deliveryID int32
+}
+
+// webhookEvent is an application change waiting to be recorded as deliveries
+type webhookEvent struct {
+	userID     int32
+	name       string
+	data       interface{}
+	occurredAt time.Time
+}
+
+// webhookPayload is the JSON body POSTed to webhook URLs
+type webhookPayload struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// NewWebhookDispatcher creates a dispatcher that tries each delivery up to maxAttempts times
+// Call Start to run its workers
+func NewWebhookDispatcher(queries *database.Queries, maxAttempts int) *WebhookDispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &WebhookDispatcher{
+		queries:     queries,
+		client:      newWebhookClient(),
+		maxAttempts: maxAttempts,
+		baseDelay:   30 * time.Second,
+		maxDelay:    time.Hour,
+		jobs:        make(chan webhookJob, webhookQueueSize),
+	}
+}
+
+// Start runs the delivery workers until ctx is done
+// Deliveries still pending from a previous run (e.g. before a restart) are queued again first
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	pending, err := d.queries.GetPendingWebhookDeliveryIDs(ctx)
+	if err != nil {
+		log.Printf("ERROR webhooks: failed to load pending deliveries - %v", err)
+	}
+	for _, id := range pending {
+		d.enqueue(webhookJob{deliveryID: id})
+	}
+
+	for i := 0; i < webhookWorkers; i++ {
+		go d.work(ctx)
+	}
+}
+
+// Publish queues an event for all of the user's webhooks without blocking
+// The event is dropped (and logged) if the queue is full
+func (d *WebhookDispatcher) Publish(userID int32, event string, data interface{}) {
+	if d == nil {
+		return
+	}
+	d.enqueue(webhookJob{event: &webhookEvent{
+		userID:     userID,
+		name:       event,
+		data:       data,
+		occurredAt: time.Now().UTC(),
+	}})
+}
+
+// Redeliver queues an attempt of a delivery that was reset to pending
+// If the queue is full the delivery stays pending and is picked up on the next Start
+func (d *WebhookDispatcher) Redeliver(deliveryID int32) {
+	if d == nil {
+		return
+	}
+	d.enqueue(webhookJob{deliveryID: deliveryID})
+}
+
+// enqueue adds a job to the queue without blocking; returns false if the queue is full
+func (d *WebhookDispatcher) enqueue(job webhookJob) bool {
+	select {
+	case d.jobs <- job:
+		return true
+	default:
+		log.Printf("ERROR webhooks: queue full, dropping job (event=%v delivery=%d)", job.event != nil, job.deliveryID)
+		return false
+	}
+}
+
+// work processes jobs until ctx is done
+func (d *WebhookDispatcher) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-d.jobs:
+			if job.event != nil {
+				d.fanOut(ctx, job.event)
+			} else {
+				d.deliver(ctx, job.deliveryID)
+			}
+		}
+	}
+}
+
+// fanOut records a signed delivery of the event for each of the user's webhooks and attempts them
+func (d *WebhookDispatcher) fanOut(ctx context.Context, event *webhookEvent) {
+	webhooks, err := d.queries.GetWebhooksByUserID(ctx, event.userID)
+	if err != nil {
+		log.Printf("ERROR webhooks: failed to load webhooks for user %d - %v", event.userID, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		Event:      event.name,
+		OccurredAt: event.occurredAt,
+		Data:       event.data,
+	})
+	if err != nil {
+		log.Printf("ERROR webhooks: failed to encode %s payload - %v", event.name, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := d.queries.CreateWebhookDelivery(ctx, database.CreateWebhookDeliveryParams{
+			WebhookID: webhook.ID,
+			Event:     event.name,
+			Payload:   string(payload),
+			Signature: signWebhookPayload(webhook.Secret, payload),
+		})
+		if err != nil {
+			log.Printf("ERROR webhooks: failed to record %s delivery for webhook %d - %v", event.name, webhook.ID, err)
+			continue
+		}
+		d.deliver(ctx, delivery.ID)
+	}
+}
+
+// deliver makes one attempt of a pending delivery and records the outcome
+// A failed attempt is retried after backoff until maxAttempts, then the delivery is marked failed
+func (d *WebhookDispatcher) deliver(ctx context.Context, deliveryID int32) {
+	target, err := d.queries.GetWebhookDeliveryTarget(ctx, deliveryID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ERROR webhooks: failed to load delivery %d - %v", deliveryID, err)
+		}
+		return
+	}
+	// A delivery can be queued twice (e.g. re-delivered while a retry was scheduled); only pending ones are sent
+	if target.Status != webhookDeliveryPending {
+		return
+	}
+
+	responseStatus, sendErr := d.send(ctx, target)
+	attempt := int(target.Attempts) + 1
+
+	status := webhookDeliverySucceeded
+	if sendErr != nil {
+		status = webhookDeliveryPending
+		if attempt >= d.maxAttempts {
+			status = webhookDeliveryFailed
+		}
+	}
+
+	err = d.queries.RecordWebhookDeliveryAttempt(ctx, database.RecordWebhookDeliveryAttemptParams{
+		ID:             deliveryID,
+		Status:         status,
+		ResponseStatus: sql.NullInt32{Int32: int32(responseStatus), Valid: responseStatus != 0},
+		LastError:      errorString(sendErr),
+	})
+	if err != nil {
+		log.Printf("ERROR webhooks: failed to record attempt of delivery %d - %v", deliveryID, err)
+		return
+	}
+
+	if status == webhookDeliveryPending {
+		d.retryAfter(ctx, deliveryID, d.backoff(attempt))
+	}
+}
+
+// send POSTs a delivery to its webhook URL
+// Returns the response status (0 if no response) and an error unless the endpoint answered 2xx
+func (d *WebhookDispatcher) send(ctx context.Context, target database.GetWebhookDeliveryTargetRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Url, bytes.NewBufferString(target.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", target.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(int(target.ID)))
+	req.Header.Set(webhookSignatureHeader, target.Signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain (a bounded amount of) the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryAfter queues the delivery again once delay has passed (or gives up if ctx is done first)
+// Unlike Publish this waits for room in the queue, since it runs on its own goroutine
+func (d *WebhookDispatcher) retryAfter(ctx context.Context, deliveryID int32, delay time.Duration) {
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		select {
+		case <-ctx.Done():
+		case d.jobs <- webhookJob{deliveryID: deliveryID}:
+		}
+	}()
+}
+
+// backoff returns the wait before retrying after the given (1-based) failed attempt:
+// baseDelay, then doubling each time, capped at maxDelay
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.baseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.maxDelay {
+			return d.maxDelay
+		}
+	}
+	return delay
+}
+
+// signWebhookPayload returns the X-Webhook-Signature value for payload: "sha256=" + hex HMAC-SHA256
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// errorString converts err to a nullable string column (NULL when err is nil)
+func errorString(err error) sql.NullString {
+	if err == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: err.Error(), Valid: true}
+}
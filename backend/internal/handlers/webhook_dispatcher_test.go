@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	d := &WebhookDispatcher{baseDelay: time.Second, maxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second}, // capped at maxDelay
+		{30, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempt); got != tt.expected {
+			t.Errorf("backoff(%d) = %v, expected %v", tt.attempt, got, tt.expected)
+		}
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	payload := []byte(`{"event":"application.created"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := signWebhookPayload("secret", payload); got != expected {
+		t.Errorf("Expected signature %s, got %s", expected, got)
+	}
+	if signWebhookPayload("other-secret", payload) == expected {
+		t.Error("Expected a different secret to produce a different signature")
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	var gotSignature, gotEvent string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(webhookSignatureHeader)
+		gotEvent = r.Header.Get("X-Webhook-Event")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	defaultAddressAllowed := webhookAddressAllowed
+	defer func() { webhookAddressAllowed = defaultAddressAllowed }()
+	webhookAddressAllowed = func(net.IP) bool { return true }
+
+	d := NewWebhookDispatcher(nil, 3)
+	target := database.GetWebhookDeliveryTargetRow{
+		ID:        1,
+		Event:     WebhookEventApplicationCreated,
+		Payload:   `{}`,
+		Signature: "sha256=abc",
+		Url:       server.URL,
+	}
+
+	// Test a 2xx response succeeds and carries the signature and event headers
+	code, err := d.send(context.Background(), target)
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Expected success with %d, got %d (%v)", http.StatusNoContent, code, err)
+	}
+	if gotSignature != "sha256=abc" || gotEvent != WebhookEventApplicationCreated {
+		t.Errorf("Unexpected headers: signature=%q event=%q", gotSignature, gotEvent)
+	}
+
+	// Test a non-2xx response is an error that keeps the status
+	status = http.StatusInternalServerError
+	code, err = d.send(context.Background(), target)
+	if err == nil || code != http.StatusInternalServerError {
+		t.Errorf("Expected an error with %d, got %d (%v)", http.StatusInternalServerError, code, err)
+	}
+
+	// Test an unreachable endpoint is an error without a status
+	server.Close()
+	code, err = d.send(context.Background(), target)
+	if err == nil || code != 0 {
+		t.Errorf("Expected a connection error without status, got %d (%v)", code, err)
+	}
+}
+
+func TestWebhookSend_RefusesPrivateAddressesAndRedirects(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(nil, 3)
+	target := database.GetWebhookDeliveryTargetRow{ID: 1, Event: WebhookEventApplicationCreated, Payload: `{}`, Url: server.URL}
+
+	// Test the loopback test server is refused before anything is sent
+	code, err := d.send(context.Background(), target)
+	if err == nil || code != 0 || calls != 0 {
+		t.Errorf("Expected the loopback address to be refused, got %d (%v) after %d calls", code, err, calls)
+	}
+
+	// Test a redirect is not followed
+	defaultAddressAllowed := webhookAddressAllowed
+	defer func() { webhookAddressAllowed = defaultAddressAllowed }()
+	webhookAddressAllowed = func(net.IP) bool { return true }
+
+	code, err = d.send(context.Background(), target)
+	if err == nil || code != http.StatusFound || calls != 1 {
+		t.Errorf("Expected the redirect to fail with %d, got %d (%v) after %d calls", http.StatusFound, code, err, calls)
+	}
+}
+
+func TestCheckWebhookHost(t *testing.T) {
+	for host, expected := range map[string]error{
+		"8.8.8.8":     nil,
+		"127.0.0.1":   errNonPublicAddress,
+		"10.0.0.5":    errNonPublicAddress,
+		"169.254.1.1": errNonPublicAddress,
+		"::1":         errNonPublicAddress,
+	} {
+		if err := checkWebhookHost(context.Background(), host); err != expected {
+			t.Errorf("checkWebhookHost(%s): expected %v, got %v", host, expected, err)
+		}
+	}
+}
+
+func TestWebhookPublish_NilDispatcher(t *testing.T) {
+	var d *WebhookDispatcher
+	// Must not panic: a nil dispatcher disables webhooks
+	d.Publish(1, WebhookEventApplicationCreated, nil)
+	d.Redeliver(1)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// MaxWebhookDeliveries caps how many deliveries GET /api/webhooks/:id/deliveries returns
+const MaxWebhookDeliveries = 100
+
+// WebhookHandler handles HTTP requests for webhooks and their deliveries
+type WebhookHandler struct {
+	queries    *database.Queries
+	dispatcher *WebhookDispatcher
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(queries *database.Queries, dispatcher *WebhookDispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		queries:    queries,
+		dispatcher: dispatcher,
+	}
+}
+
+// WebhookRequest represents the JSON body for creating a webhook
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required,url,max=2000"`
+}
+
+// WebhookResponse is a webhook as listed by GET /api/webhooks
+// The signing secret is left out; it is only returned when the webhook is created
+type WebhookResponse struct {
+	ID        int32        `json:"id"`
+	URL       string       `json:"url"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// newWebhookSecret returns a random 64-character hex secret for signing deliveries
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkWebhookHost resolves a webhook URL host and returns errNonPublicAddress if any of its addresses
+// is loopback, private or link-local (deliveries re-check the address on every connection)
+func checkWebhookHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !webhookAddressAllowed(ip) {
+			return errNonPublicAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !webhookAddressAllowed(addr.IP) {
+			return errNonPublicAddress
+		}
+	}
+	return nil
+}
+
+// requireOwnedWebhook parses the :id param and loads the user's webhook
+// Sends a 400/404 response and returns false if the ID is invalid or the webhook is not the user's
+func (h *WebhookHandler) requireOwnedWebhook(c *gin.Context, userID int32) (database.Webhook, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		sendBadRequest(c, "Invalid webhook ID", "ID must be a number")
+		return database.Webhook{}, false
+	}
+
+	webhook, err := h.queries.GetWebhookByIDAndUserID(c.Request.Context(), database.GetWebhookByIDAndUserIDParams{
+		ID:     int32(id),
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Webhook") {
+		return database.Webhook{}, false
+	}
+	return webhook, true
+}
+
+// GetWebhooks handles GET /api/webhooks
+// Returns all webhooks of the authenticated user, without their signing secrets
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.queries.GetWebhooksByUserID(c.Request.Context(), userID)
+	if err != nil {
+		sendInternalError(c, "Failed to fetch webhooks", err)
+		return
+	}
+
+	response := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = WebhookResponse{ID: webhook.ID, URL: webhook.Url, CreatedAt: webhook.CreatedAt}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateWebhook handles POST /api/webhooks
+// Registers a URL to receive application events; the response includes the generated signing secret
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		sendValidationError(c, err)
+		return
+	}
+
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		sendBadRequest(c, "Invalid url", "url must be an http or https URL")
+		return
+	}
+	if err := checkWebhookHost(c.Request.Context(), parsed.Hostname()); err != nil {
+		if err == errNonPublicAddress {
+			sendBadRequest(c, "Invalid url", "url must point to a public address")
+		} else {
+			sendBadRequest(c, "Invalid url", "url host could not be resolved")
+		}
+		return
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		sendInternalError(c, "Failed to generate webhook secret", err)
+		return
+	}
+
+	webhook, err := h.queries.CreateWebhook(c.Request.Context(), database.CreateWebhookParams{
+		Url:    req.URL,
+		Secret: secret,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Webhook") {
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+// Deletes a webhook and its delivery history (verifies ownership)
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	webhook, ok := h.requireOwnedWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	err := h.queries.DeleteWebhook(c.Request.Context(), database.DeleteWebhookParams{
+		ID:     webhook.ID,
+		UserID: userID,
+	})
+	if handleDatabaseError(c, err, "Webhook") {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+		"id":      webhook.ID,
+	})
+}
+
+// GetWebhookDeliveries handles GET /api/webhooks/:id/deliveries
+// Returns the webhook's most recent deliveries (at most MaxWebhookDeliveries), newest first
+// Supports ?status=failed|pending|succeeded (default failed, i.e. the dead-lettered deliveries)
+// Each delivery includes its payload and signature for debugging
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	status := c.DefaultQuery("status", webhookDeliveryFailed)
+	switch status {
+	case webhookDeliveryFailed, webhookDeliveryPending, webhookDeliverySucceeded:
+	default:
+		sendBadRequest(c, "Invalid status", "status must be one of: failed, pending, succeeded")
+		return
+	}
+
+	webhook, ok := h.requireOwnedWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.queries.GetWebhookDeliveriesByWebhookIDAndStatus(c.Request.Context(), database.GetWebhookDeliveriesByWebhookIDAndStatusParams{
+		WebhookID: webhook.ID,
+		Status:    status,
+		Limit:     MaxWebhookDeliveries,
+	})
+	if err != nil {
+		sendInternalError(c, "Failed to fetch webhook deliveries", err)
+		return
+	}
+	if deliveries == nil {
+		deliveries = []database.WebhookDelivery{}
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhookDelivery handles POST /api/webhooks/:id/deliveries/:deliveryId/redeliver
+// Resets a failed delivery to pending and queues it with a fresh set of attempts
+// Responds 202 since the delivery happens in the background; 409 if the delivery has not failed
+func (h *WebhookHandler) RedeliverWebhookDelivery(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		sendBadRequest(c, "Invalid delivery ID", "ID must be a number")
+		return
+	}
+
+	webhook, ok := h.requireOwnedWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	delivery, err := h.queries.RedeliverWebhookDelivery(ctx, database.RedeliverWebhookDeliveryParams{
+		ID:        int32(deliveryID),
+		WebhookID: webhook.ID,
+	})
+	if err == sql.ErrNoRows {
+		// Tell a missing delivery apart from one that is pending or already succeeded
+		existing, err := h.queries.GetWebhookDeliveryByIDAndWebhookID(ctx, database.GetWebhookDeliveryByIDAndWebhookIDParams{
+			ID:        int32(deliveryID),
+			WebhookID: webhook.ID,
+		})
+		if handleDatabaseError(c, err, "Webhook delivery") {
+			return
+		}
+		sendError(c, http.StatusConflict, "Delivery has not failed", "Only failed deliveries can be re-delivered (status is "+existing.Status+")")
+		return
+	}
+	if handleDatabaseError(c, err, "Webhook delivery") {
+		return
+	}
+
+	h.dispatcher.Redeliver(delivery.ID)
+
+	c.JSON(http.StatusAccepted, delivery)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// TestWebhookDeliveries tests that failing deliveries are retried, dead-lettered and can be re-delivered
+func TestWebhookDeliveries(t *testing.T) {
+	_, queries, db := setupTestRouter(t)
+	defer db.Close()
+
+	// Create a test user
+	testUser, cleanup := createTestUser(t, queries, db, "test-webhooks@example.com")
+	defer cleanup()
+
+	// Endpoint fails until healthy is set
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Allow deliveries to the local test server
+	defaultAddressAllowed := webhookAddressAllowed
+	defer func() { webhookAddressAllowed = defaultAddressAllowed }()
+	webhookAddressAllowed = func(net.IP) bool { return true }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dispatcher := NewWebhookDispatcher(queries, 2)
+	dispatcher.baseDelay = 10 * time.Millisecond
+	dispatcher.Start(ctx)
+
+	router := gin.New()
+	cfg := Config{DB: queries, DBConn: db, UseLegacyAuth: true, Webhooks: dispatcher}
+	cfg.SetupRoutes(router)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testUser.Token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("POST", "/api/webhooks", map[string]string{"url": server.URL})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var webhook database.Webhook
+	if err := json.Unmarshal(w.Body.Bytes(), &webhook); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	// Test the list leaves out the signing secret
+	w = request("GET", "/api/webhooks", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var listed []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(listed) != 1 || listed[0]["url"] != server.URL {
+		t.Fatalf("Expected the created webhook to be listed, got %v", listed)
+	}
+	if _, ok := listed[0]["secret"]; ok {
+		t.Errorf("Expected no secret in the webhook list, got %v", listed[0])
+	}
+	deliveriesPath := "/api/webhooks/" + strconv.Itoa(int(webhook.ID)) + "/deliveries"
+
+	// Test an invalid URL is rejected
+	w = request("POST", "/api/webhooks", map[string]string{"url": "ftp://example.com"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	// Test a URL pointing to a private address is rejected
+	webhookAddressAllowed = defaultAddressAllowed
+	w = request("POST", "/api/webhooks", map[string]string{"url": "http://192.168.1.10/hook"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	webhookAddressAllowed = func(net.IP) bool { return true }
+
+	// Creating an application triggers a delivery that fails twice and is dead-lettered
+	w = request("POST", "/api/applications", map[string]string{"status": "applied", "applied_date": "2024-01-15"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	waitFor := func(status string) []database.WebhookDelivery {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			w := request("GET", deliveriesPath+"?status="+status, nil)
+			var deliveries []database.WebhookDelivery
+			_ = json.Unmarshal(w.Body.Bytes(), &deliveries)
+			if len(deliveries) > 0 {
+				return deliveries
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("Timed out waiting for a %s delivery", status)
+		return nil
+	}
+	failed := waitFor(webhookDeliveryFailed)
+
+	delivery := failed[0]
+	if delivery.Event != WebhookEventApplicationCreated || delivery.Attempts != 2 || delivery.ResponseStatus.Int32 != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected failed delivery: %+v", delivery)
+	}
+	if delivery.Signature != signWebhookPayload(webhook.Secret, []byte(delivery.Payload)) {
+		t.Errorf("Expected the stored signature to match the payload")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+
+	// Test re-delivery once the endpoint recovers
+	healthy.Store(true)
+	redeliverPath := deliveriesPath + "/" + strconv.Itoa(int(delivery.ID)) + "/redeliver"
+	w = request("POST", redeliverPath, nil)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	succeeded := waitFor(webhookDeliverySucceeded)
+	if succeeded[0].ID != delivery.ID || succeeded[0].Attempts != 1 {
+		t.Errorf("Unexpected re-delivered delivery: %+v", succeeded[0])
+	}
+
+	// Test a delivery that has not failed can't be re-delivered
+	w = request("POST", redeliverPath, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// Test an invalid status filter
+	w = request("GET", deliveriesPath+"?status=lost", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// RoleAdmin is the users.role value of administrators
+const RoleAdmin = "admin"
+
+// AdminMiddleware only lets administrators through (403 otherwise)
+// It must run after the auth middleware, which sets user_id; the role is looked up on every request
+// so a demotion takes effect immediately
+func AdminMiddleware(queries *database.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		id, isID := userID.(int32)
+		if !ok || !isID {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Abort()
+			return
+		}
+
+		role, err := queries.GetUserRoleByID(c.Request.Context(), id)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up user"})
+			c.Abort()
+			return
+		}
+		if role != RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
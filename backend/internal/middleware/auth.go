@@ -1,38 +1,137 @@
 package middleware
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peridan9/resumecontrol/backend/internal/auth"
+	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
+// Authorization header errors (messages are returned in 401 responses)
+var (
+	errMissingAuthHeader = errors.New("Authorization header is required")
+	errInvalidAuthFormat = errors.New("Invalid authorization header format. Expected: Bearer <token>")
+	errMissingToken      = errors.New("Missing token")
+)
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+// The scheme is case-insensitive and surrounding or repeated whitespace is ignored.
+func bearerToken(authHeader string) (string, error) {
+	authHeader = strings.TrimSpace(authHeader)
+	if authHeader == "" {
+		return "", errMissingAuthHeader
+	}
+
+	fields := strings.Fields(authHeader)
+	if !strings.EqualFold(fields[0], "Bearer") {
+		return "", errInvalidAuthFormat
+	}
+	if len(fields) == 1 {
+		return "", errMissingToken
+	}
+	if len(fields) > 2 {
+		return "", errInvalidAuthFormat
+	}
+	return fields[1], nil
+}
+
+// abortDisabledUser sends the 403 for requests of a user an admin has disabled
+func abortDisabledUser(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Account disabled",
+		"message": "This account has been disabled by an administrator.",
+	})
+	c.Abort()
+}
+
+// revokedTokenPruneInterval is how often expired entries are deleted from the access token denylist
+const revokedTokenPruneInterval = time.Hour
+
+var pruneRevokedTokensOnce sync.Once
+
+// pruneRevokedTokens deletes denylist entries of expired tokens periodically (they can no longer be used anyway)
+func pruneRevokedTokens(queries *database.Queries) {
+	ticker := time.NewTicker(revokedTokenPruneInterval)
+	go func() {
+		for range ticker.C {
+			pruned, err := queries.DeleteExpiredRevokedAccessTokens(context.Background())
+			if err != nil {
+				log.Printf("WARN failed to prune revoked access tokens: %v", err)
+				continue
+			}
+			if pruned > 0 {
+				log.Printf("Pruned %d expired revoked access tokens", pruned)
+			}
+		}
+	}()
+}
+
 // LegacyAuthMiddleware validates legacy JWT tokens (used only in tests).
 // Production uses ClerkAuthMiddleware.
-func LegacyAuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
-			return
-		}
+// When ACCESS_TOKEN_DENYLIST=true and queries is non-nil, tokens whose jti was revoked are rejected;
+// the token's jti and expiry are then set in the context ("token_jti", "token_expires_at") so logout can revoke it.
+// When queries is non-nil, tokens of users disabled by an admin are rejected with 403.
+func LegacyAuthMiddleware(queries *database.Queries) gin.HandlerFunc {
+	checkDenylist := auth.DenylistEnabled() && queries != nil
+	if checkDenylist {
+		pruneRevokedTokensOnce.Do(func() { pruneRevokedTokens(queries) })
+	}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format. Expected: Bearer <token>"})
+	return func(c *gin.Context) {
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
 
-		claims, err := auth.ValidateAccessToken(parts[1])
+		claims, err := auth.ValidateAccessToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
+		// Tokens issued without a jti cannot be revoked and are accepted until they expire
+		if checkDenylist && claims.ID != "" {
+			revoked, err := queries.IsAccessTokenRevoked(c.Request.Context(), claims.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+			c.Set("token_jti", claims.ID)
+			if claims.ExpiresAt != nil {
+				c.Set("token_expires_at", claims.ExpiresAt.Time)
+			}
+		}
+
+		if queries != nil {
+			disabled, err := queries.IsUserDisabled(c.Request.Context(), claims.UserID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check user"})
+				c.Abort()
+				return
+			}
+			if disabled {
+				abortDisabledUser(c)
+				return
+			}
+		}
+
 		c.Set("user_id", claims.UserID)
 		c.Next()
 	}
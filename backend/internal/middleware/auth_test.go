@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/peridan9/resumecontrol/backend/internal/auth"
+)
+
+// TestBearerToken tests Authorization header parsing shared by the auth middlewares
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		expectedToken string
+		expectedErr   error
+	}{
+		{"Standard", "Bearer abc.def.ghi", "abc.def.ghi", nil},
+		{"Lowercase scheme", "bearer abc.def.ghi", "abc.def.ghi", nil},
+		{"Uppercase scheme", "BEARER abc.def.ghi", "abc.def.ghi", nil},
+		{"Extra spaces", "Bearer    abc.def.ghi  ", "abc.def.ghi", nil},
+		{"Leading whitespace", "  Bearer\tabc.def.ghi", "abc.def.ghi", nil},
+		{"Missing header", "", "", errMissingAuthHeader},
+		{"Whitespace header", "   ", "", errMissingAuthHeader},
+		{"Empty token", "Bearer ", "", errMissingToken},
+		{"Empty token lowercase", "bearer    ", "", errMissingToken},
+		{"Wrong scheme", "Basic abc", "", errInvalidAuthFormat},
+		{"Token with spaces", "Bearer abc def", "", errInvalidAuthFormat},
+		{"Token only", "abc.def.ghi", "", errInvalidAuthFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := bearerToken(tt.header)
+			if err != tt.expectedErr {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
+			if token != tt.expectedToken {
+				t.Errorf("Expected token %q, got %q", tt.expectedToken, token)
+			}
+		})
+	}
+}
+
+// TestLegacyAuthMiddleware tests that the legacy middleware accepts header variants and rejects empty tokens
+func TestLegacyAuthMiddleware(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-that-is-at-least-32-characters-long")
+	if err := auth.InitJWT(); err != nil {
+		t.Fatalf("Failed to initialize JWT: %v", err)
+	}
+	token, err := auth.GenerateAccessToken(42, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LegacyAuthMiddleware(nil))
+	r.GET("/protected", func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+		c.JSON(http.StatusOK, gin.H{"user_id": userID})
+	})
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{"Standard", "Bearer " + token, http.StatusOK},
+		{"Lowercase scheme", "bearer " + token, http.StatusOK},
+		{"Extra spaces", "Bearer   " + token + " ", http.StatusOK},
+		{"Empty token", "Bearer ", http.StatusUnauthorized},
+		{"Missing header", "", http.StatusUnauthorized},
+		{"Invalid token", "Bearer not-a-jwt", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/protected", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestLegacyAuthMiddleware_IssuerAudience tests that iss/aud are checked only when JWT_ISSUER/JWT_AUDIENCE are set
+func TestLegacyAuthMiddleware_IssuerAudience(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-key-that-is-at-least-32-characters-long")
+	newToken := func(issuer, audience string) string {
+		t.Setenv("JWT_ISSUER", issuer)
+		t.Setenv("JWT_AUDIENCE", audience)
+		if err := auth.InitJWT(); err != nil {
+			t.Fatalf("Failed to initialize JWT: %v", err)
+		}
+		token, err := auth.GenerateAccessToken(42, time.Minute)
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		return token
+	}
+	unscoped := newToken("", "")
+	otherIssuer := newToken("other-service", "resumecontrol-api")
+	otherAudience := newToken("resumecontrol", "other-api")
+	scoped := newToken("resumecontrol", "resumecontrol-api")
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LegacyAuthMiddleware(nil))
+	r.GET("/protected", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"Matching issuer and audience", scoped, http.StatusOK},
+		{"Wrong issuer", otherIssuer, http.StatusUnauthorized},
+		{"Wrong audience", otherAudience, http.StatusUnauthorized},
+		{"Missing claims", unscoped, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// With neither variable set, validation is lenient and scoped tokens are still accepted
+	newToken("", "")
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+scoped)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d without JWT_ISSUER/JWT_AUDIENCE, got %d", http.StatusOK, w.Code)
+	}
+}
+
+// TestLegacyAuthMiddleware_SecretRotation tests that JWT_SECRET_PREVIOUS keeps old tokens valid during a rotation
+func TestLegacyAuthMiddleware_SecretRotation(t *testing.T) {
+	oldSecret := "old-secret-key-that-is-at-least-32-characters-long"
+	newSecret := "new-secret-key-that-is-at-least-32-characters-long"
+	initJWT := func(secret, previous string) {
+		t.Setenv("JWT_SECRET", secret)
+		t.Setenv("JWT_SECRET_PREVIOUS", previous)
+		if err := auth.InitJWT(); err != nil {
+			t.Fatalf("Failed to initialize JWT: %v", err)
+		}
+	}
+	newToken := func() string {
+		token, err := auth.GenerateAccessToken(42, time.Minute)
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		return token
+	}
+
+	initJWT(oldSecret, "")
+	oldToken := newToken()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LegacyAuthMiddleware(nil))
+	r.GET("/protected", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	status := func(token string) int {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// During the grace window both secrets are accepted; new tokens use the current secret
+	initJWT(newSecret, oldSecret)
+	rotatedToken := newToken()
+	if code := status(oldToken); code != http.StatusOK {
+		t.Errorf("Expected a token signed with the previous secret to be accepted, got %d", code)
+	}
+	if code := status(rotatedToken); code != http.StatusOK {
+		t.Errorf("Expected a token signed with the current secret to be accepted, got %d", code)
+	}
+
+	// Once the previous secret is removed, only tokens signed with the current secret work
+	initJWT(newSecret, "")
+	if code := status(oldToken); code != http.StatusUnauthorized {
+		t.Errorf("Expected a token signed with the removed secret to be rejected, got %d", code)
+	}
+	if code := status(rotatedToken); code != http.StatusOK {
+		t.Errorf("Expected a token signed with the current secret to be accepted, got %d", code)
+	}
+
+	// A short previous secret is rejected like a short current secret
+	t.Setenv("JWT_SECRET_PREVIOUS", "too-short")
+	if err := auth.InitJWT(); err == nil {
+		t.Error("Expected an error for a short JWT_SECRET_PREVIOUS")
+	}
+
+	// A failed InitJWT leaves the loaded secrets in place
+	if !auth.Ready() {
+		t.Error("Expected JWT to stay ready after a failed InitJWT")
+	}
+	if code := status(rotatedToken); code != http.StatusOK {
+		t.Errorf("Expected a failed InitJWT to keep the current secret, got %d", code)
+	}
+}
@@ -14,28 +14,23 @@ import (
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 )
 
+// RegistrationEnabled allows Clerk users without a user row to be created on their first request
+// (REGISTRATION_ENABLED, default true); when false only existing users can sign in (invite-only instance)
+var RegistrationEnabled = true
+
+// RegistrationDisabledMessage explains the 403 sent to new users while registration is disabled
+const RegistrationDisabledMessage = "Registration is invite-only on this instance. Ask an administrator for an invite code."
+
 // ClerkAuthMiddleware verifies Clerk session JWTs and resolves to internal user_id.
 // Sets user_id in Gin context (same key as AuthMiddleware) so existing handlers work unchanged.
-// If the Clerk user is not yet in the DB, creates a user row using Clerk's user API (email, name).
-func ClerkAuthMiddleware(queries *database.Queries, jwksClient *jwks.Client) gin.HandlerFunc {
+// If the Clerk user is not yet in the DB, creates a user row using Clerk's user API (email, name),
+// unless RegistrationEnabled is false: then a valid invite code in X-Invite-Code is required (403 otherwise).
+// Users disabled by an admin get 403.
+func ClerkAuthMiddleware(db *sql.DB, queries *database.Queries, jwksClient *jwks.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
-			return
-		}
-
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format. Expected: Bearer <token>"})
-			c.Abort()
-			return
-		}
-
-		tokenString := strings.TrimSpace(parts[1])
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing token"})
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
@@ -62,6 +57,10 @@ func ClerkAuthMiddleware(queries *database.Queries, jwksClient *jwks.Client) gin
 		// Resolve to internal user: lookup by clerk_user_id
 		u, err := queries.GetUserByClerkID(ctx, sql.NullString{String: clerkSub, Valid: true})
 		if err == nil {
+			if u.DisabledAt.Valid {
+				abortDisabledUser(c)
+				return
+			}
 			c.Set("user_id", u.ID)
 			c.Next()
 			return
@@ -72,7 +71,16 @@ func ClerkAuthMiddleware(queries *database.Queries, jwksClient *jwks.Client) gin
 			return
 		}
 
-		// User not in DB: fetch from Clerk and create
+		// User not in DB: new users are only created while registration is enabled,
+		// or with an invite code (X-Invite-Code) while it is disabled
+		inviteCode := c.GetHeader(InviteCodeHeader)
+		if !RegistrationEnabled && inviteCode == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Registration disabled", "message": RegistrationDisabledMessage})
+			c.Abort()
+			return
+		}
+
+		// Fetch from Clerk and create
 		clerkUser, err := user.Get(ctx, clerkSub)
 		if err != nil {
 			c.JSON(http.StatusForbidden, gin.H{"error": "User not found in application"})
@@ -84,17 +92,31 @@ func ClerkAuthMiddleware(queries *database.Queries, jwksClient *jwks.Client) gin
 		if email == "" {
 			email = "user-" + clerkSub + "@clerk.invalid"
 		}
-		name := getNameFromClerkUser(clerkUser)
-
-		newUser, err := queries.CreateUserWithClerkID(ctx, database.CreateUserWithClerkIDParams{
+		params := database.CreateUserWithClerkIDParams{
 			ClerkUserID: sql.NullString{String: clerkSub, Valid: true},
 			Email:       email,
-			Name:        name,
-		})
+			Name:        getNameFromClerkUser(clerkUser),
+		}
+
+		var newUser database.User
+		if RegistrationEnabled {
+			newUser, err = queries.CreateUserWithClerkID(ctx, params)
+		} else {
+			newUser, err = CreateUserWithInvite(ctx, db, queries, inviteCode, params)
+			if errors.Is(err, ErrInviteInvalid) || errors.Is(err, ErrInviteUsed) || errors.Is(err, ErrInviteExpired) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Invalid invite code", "message": err.Error()})
+				c.Abort()
+				return
+			}
+		}
 		if err != nil {
 			// Race: another request may have created the user
 			u, retryErr := queries.GetUserByClerkID(ctx, sql.NullString{String: clerkSub, Valid: true})
 			if retryErr == nil {
+				if u.DisabledAt.Valid {
+					abortDisabledUser(c)
+					return
+				}
 				c.Set("user_id", u.ID)
 				c.Next()
 				return
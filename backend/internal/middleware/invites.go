@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/peridan9/resumecontrol/backend/internal/database"
+)
+
+// InviteCodeHeader carries the invite code on a new user's first request while registration is disabled
+const InviteCodeHeader = "X-Invite-Code"
+
+// Errors returned by CreateUserWithInvite for codes that can't be redeemed
+var (
+	ErrInviteInvalid = errors.New("invite code is not valid")
+	ErrInviteUsed    = errors.New("invite code has already been used")
+	ErrInviteExpired = errors.New("invite code has expired")
+)
+
+// CreateUserWithInvite creates a user and redeems the invite code in one transaction
+// The invite row stays locked until commit, so two sign-ups can't redeem the same code
+// Returns ErrInviteInvalid, ErrInviteUsed or ErrInviteExpired when the code can't be redeemed
+func CreateUserWithInvite(ctx context.Context, db *sql.DB, queries *database.Queries, code string, params database.CreateUserWithClerkIDParams) (database.User, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return database.User{}, err
+	}
+	// Rollback is a no-op after a successful commit
+	defer tx.Rollback()
+	qtx := queries.WithTx(tx)
+
+	invite, err := qtx.GetInviteByCodeForUpdate(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.User{}, ErrInviteInvalid
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+	if invite.UsedAt.Valid {
+		return database.User{}, ErrInviteUsed
+	}
+	if invite.ExpiresAt.Valid && !invite.ExpiresAt.Time.After(time.Now()) {
+		return database.User{}, ErrInviteExpired
+	}
+
+	user, err := qtx.CreateUserWithClerkID(ctx, params)
+	if err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.MarkInviteUsed(ctx, database.MarkInviteUsedParams{
+		ID:     invite.ID,
+		UsedBy: sql.NullInt32{Int32: user.ID, Valid: true},
+	}); err != nil {
+		return database.User{}, err
+	}
+
+	return user, tx.Commit()
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceMode controls which requests are rejected during maintenance
+type MaintenanceMode int32
+
+const (
+	// MaintenanceOff serves all requests normally
+	MaintenanceOff MaintenanceMode = iota
+	// MaintenanceWrite rejects mutating requests (POST/PUT/PATCH/DELETE) but keeps reads available
+	MaintenanceWrite
+	// MaintenanceFull rejects all requests except health checks
+	MaintenanceFull
+)
+
+// String returns the env value for the mode
+func (m MaintenanceMode) String() string {
+	switch m {
+	case MaintenanceWrite:
+		return "write"
+	case MaintenanceFull:
+		return "full"
+	default:
+		return "off"
+	}
+}
+
+// ParseMaintenanceMode parses a MAINTENANCE_MODE value ("", "off", "write" or "full")
+func ParseMaintenanceMode(value string) (MaintenanceMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "off", "false":
+		return MaintenanceOff, nil
+	case "write":
+		return MaintenanceWrite, nil
+	case "full":
+		return MaintenanceFull, nil
+	default:
+		return MaintenanceOff, fmt.Errorf("invalid maintenance mode %q (expected off, write or full)", value)
+	}
+}
+
+// maintenanceAlwaysAllowed lists paths served in every mode (monitoring must keep working)
+var maintenanceAlwaysAllowed = map[string]bool{
+	"/api/health": true,
+}
+
+// maintenanceWriteAllowed lists POST paths still served in write mode because they don't change data
+// (refreshing a token, or read-only requests that take a JSON body)
+var maintenanceWriteAllowed = map[string]bool{
+	"/api/auth/refresh":        true,
+	"/api/auth/introspect":     true,
+	"/api/applications/search": true,
+	"/api/jobs/parse":          true,
+}
+
+// Maintenance holds the current maintenance mode (safe to change while serving requests)
+type Maintenance struct {
+	mode atomic.Int32
+}
+
+// NewMaintenance creates a maintenance switch starting in the given mode
+func NewMaintenance(mode MaintenanceMode) *Maintenance {
+	m := &Maintenance{}
+	m.SetMode(mode)
+	return m
+}
+
+// Mode returns the current maintenance mode
+func (m *Maintenance) Mode() MaintenanceMode {
+	return MaintenanceMode(m.mode.Load())
+}
+
+// SetMode switches the maintenance mode (e.g. from a signal handler)
+func (m *Maintenance) SetMode(mode MaintenanceMode) {
+	m.mode.Store(int32(mode))
+}
+
+// isMutatingMethod reports whether the HTTP method changes data
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Middleware returns a middleware that responds 503 for requests blocked by the current mode
+func (m *Maintenance) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mode := m.Mode()
+		path := c.Request.URL.Path
+
+		blocked := false
+		switch mode {
+		case MaintenanceWrite:
+			blocked = isMutatingMethod(c.Request.Method) && !maintenanceAlwaysAllowed[path] && !maintenanceWriteAllowed[path]
+		case MaintenanceFull:
+			blocked = !maintenanceAlwaysAllowed[path]
+		}
+
+		if blocked {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "Service under maintenance",
+				"message": "The API is temporarily unavailable for maintenance. Please try again shortly.",
+				"mode":    mode.String(),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
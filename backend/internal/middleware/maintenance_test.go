@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupMaintenanceRouter creates a router with the maintenance middleware and a few dummy routes
+func setupMaintenanceRouter(m *Maintenance) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(m.Middleware())
+
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) }
+	r.GET("/api/health", ok)
+	r.GET("/api/companies", ok)
+	r.POST("/api/companies", ok)
+	r.DELETE("/api/companies/:id", ok)
+	r.POST("/api/auth/refresh", ok)
+	r.POST("/api/applications/search", ok)
+	return r
+}
+
+// TestMaintenanceMiddleware tests which requests are blocked in each maintenance mode
+func TestMaintenanceMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           MaintenanceMode
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"Off allows writes", MaintenanceOff, "POST", "/api/companies", http.StatusOK},
+		{"Write mode allows reads", MaintenanceWrite, "GET", "/api/companies", http.StatusOK},
+		{"Write mode blocks POST", MaintenanceWrite, "POST", "/api/companies", http.StatusServiceUnavailable},
+		{"Write mode blocks DELETE", MaintenanceWrite, "DELETE", "/api/companies/1", http.StatusServiceUnavailable},
+		{"Write mode allows auth refresh", MaintenanceWrite, "POST", "/api/auth/refresh", http.StatusOK},
+		{"Write mode allows read-only POST", MaintenanceWrite, "POST", "/api/applications/search", http.StatusOK},
+		{"Write mode allows health", MaintenanceWrite, "GET", "/api/health", http.StatusOK},
+		{"Full mode blocks reads", MaintenanceFull, "GET", "/api/companies", http.StatusServiceUnavailable},
+		{"Full mode blocks auth refresh", MaintenanceFull, "POST", "/api/auth/refresh", http.StatusServiceUnavailable},
+		{"Full mode blocks read-only POST", MaintenanceFull, "POST", "/api/applications/search", http.StatusServiceUnavailable},
+		{"Full mode allows health", MaintenanceFull, "GET", "/api/health", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupMaintenanceRouter(NewMaintenance(tt.mode))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestMaintenanceSetMode tests toggling the mode at runtime (as the signal handler does)
+func TestMaintenanceSetMode(t *testing.T) {
+	m := NewMaintenance(MaintenanceOff)
+	router := setupMaintenanceRouter(m)
+
+	m.SetMode(MaintenanceWrite)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/companies", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d after enabling maintenance, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	m.SetMode(MaintenanceOff)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/companies", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d after disabling maintenance, got %d", http.StatusOK, w.Code)
+	}
+}
+
+// TestParseMaintenanceMode tests parsing MAINTENANCE_MODE values
+func TestParseMaintenanceMode(t *testing.T) {
+	valid := map[string]MaintenanceMode{
+		"":      MaintenanceOff,
+		"off":   MaintenanceOff,
+		"write": MaintenanceWrite,
+		"FULL":  MaintenanceFull,
+	}
+	for value, expected := range valid {
+		mode, err := ParseMaintenanceMode(value)
+		if err != nil || mode != expected {
+			t.Errorf("ParseMaintenanceMode(%q) = %v, %v; expected %v", value, mode, err, expected)
+		}
+	}
+
+	if _, err := ParseMaintenanceMode("readonly"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -9,6 +11,15 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// rateLimitExempt lists paths that are never rate limited (monitoring probes must keep working)
+var rateLimitExempt = map[string]bool{
+	"/api/health": true,
+	"/healthz":    true,
+	"/livez":      true,
+	"/readyz":     true,
+	"/metrics":    true,
+}
+
 // RateLimiter stores rate limiters per IP address
 type RateLimiter struct {
 	limiters map[string]*rate.Limiter
@@ -63,39 +74,78 @@ func (rl *RateLimiter) cleanup() {
 }
 
 // getClientIP extracts the client IP address from the request
+// Forwarded headers (X-Forwarded-For, X-Real-IP) only count when they come from a trusted proxy
+// (see TRUSTED_PROXIES), so clients can't pick their own rate limit key
 func getClientIP(c *gin.Context) string {
-	// Check X-Forwarded-For header (for proxies/load balancers)
-	if ip := c.GetHeader("X-Forwarded-For"); ip != "" {
-		return ip
+	return c.ClientIP()
+}
+
+// secondsUntilTokens returns how many whole seconds it takes to refill the given number of tokens
+func secondsUntilTokens(tokens float64, limit rate.Limit) int {
+	if tokens <= 0 || limit <= 0 {
+		return 0
 	}
-	// Check X-Real-IP header
-	if ip := c.GetHeader("X-Real-IP"); ip != "" {
-		return ip
+	return int(math.Ceil(tokens / float64(limit)))
+}
+
+// setRateLimitHeaders reports the client's limiter state as of now:
+// X-RateLimit-Limit is the burst size, X-RateLimit-Remaining the requests left right now
+// and X-RateLimit-Reset the seconds until the full burst is available again
+func setRateLimitHeaders(c *gin.Context, limiter *rate.Limiter, now time.Time) {
+	tokens := limiter.TokensAt(now)
+	remaining := int(math.Floor(tokens))
+	if remaining < 0 {
+		remaining = 0
 	}
-	// Fall back to RemoteAddr
-	return c.ClientIP()
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limiter.Burst()))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(secondsUntilTokens(float64(limiter.Burst())-tokens, limiter.Limit())))
 }
 
 // RateLimitMiddleware creates a middleware that rate limits requests
 // rps: requests per second allowed
 // burst: maximum burst size
+// Every response carries X-RateLimit-* headers; a 429 also carries Retry-After
+// Health, liveness, readiness and metrics paths (rateLimitExempt) skip the limiter and get no headers
 func RateLimitMiddleware(rps float64, burst int) gin.HandlerFunc {
 	limiter := NewRateLimiter(rps, burst)
 	limiter.cleanup()
 
 	return func(c *gin.Context) {
-		ip := getClientIP(c)
-		limiter := limiter.getLimiter(ip)
-
-		if !limiter.Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "Too many requests. Please try again later.",
-			})
-			c.Abort()
+		if rateLimitExempt[c.Request.URL.Path] {
+			c.Next()
 			return
 		}
 
-		c.Next()
+		if limiter.allow(c) {
+			c.Next()
+		}
+	}
+}
+
+// allow takes a token from the client's limiter and sets the X-RateLimit-* headers
+// Sends a 429 with Retry-After, aborts the request and returns false when the client is over its limit
+func (rl *RateLimiter) allow(c *gin.Context) bool {
+	limiter := rl.getLimiter(getClientIP(c))
+
+	now := time.Now()
+	allowed := limiter.AllowN(now, 1)
+	setRateLimitHeaders(c, limiter, now)
+
+	if !allowed {
+		// Seconds until one more token is available (at least 1)
+		retryAfter := secondsUntilTokens(1-limiter.TokensAt(now), limiter.Limit())
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": "Too many requests. Please try again later.",
+		})
+		c.Abort()
+		return false
 	}
+	return true
 }
 
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRateLimitMiddleware_Headers tests the X-RateLimit-* and Retry-After headers
+func TestRateLimitMiddleware_Headers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// 1 request per second, burst of 2
+	r.Use(RateLimitMiddleware(1.0, 2))
+	r.GET("/limited", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/limited", nil)
+		req.Header.Set("X-Real-IP", "203.0.113.7")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// First request: one of two tokens left
+	w := send()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("X-RateLimit-Limit"); got != "2" {
+		t.Errorf("Expected X-RateLimit-Limit 2, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "1" {
+		t.Errorf("Expected X-RateLimit-Remaining 1, got %q", got)
+	}
+	if reset, err := strconv.Atoi(w.Header().Get("X-RateLimit-Reset")); err != nil || reset < 1 {
+		t.Errorf("Expected positive X-RateLimit-Reset, got %q", w.Header().Get("X-RateLimit-Reset"))
+	}
+
+	// Second request uses the burst up
+	if w = send(); w.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Expected X-RateLimit-Remaining 0, got %q", w.Header().Get("X-RateLimit-Remaining"))
+	}
+
+	// Third request is limited and says when to retry
+	w = send()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Expected Retry-After 1, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("Expected X-RateLimit-Remaining 0 on 429, got %q", got)
+	}
+}
+
+// TestRateLimitMiddleware_ExemptPaths tests that rapid health checks are never rate limited
+func TestRateLimitMiddleware_ExemptPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// 1 request per second, burst of 1
+	r.Use(RateLimitMiddleware(1.0, 1))
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) }
+	r.GET("/api/health", ok)
+	r.HEAD("/api/health", ok)
+	r.GET("/metrics", ok)
+	r.GET("/limited", ok)
+
+	send := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Real-IP", "203.0.113.8")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Use up the client's only token
+	if w := send("GET", "/limited"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	for i := 0; i < 50; i++ {
+		for _, probe := range []struct{ method, path string }{{"GET", "/api/health"}, {"HEAD", "/api/health"}, {"GET", "/metrics"}} {
+			w := send(probe.method, probe.path)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected %s %s to return %d, got %d", probe.method, probe.path, http.StatusOK, w.Code)
+			}
+			if got := w.Header().Get("X-RateLimit-Limit"); got != "" {
+				t.Errorf("Expected no X-RateLimit-Limit on %s, got %q", probe.path, got)
+			}
+		}
+	}
+
+	// Other paths are still limited
+	if w := send("GET", "/limited"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRequestIDMiddleware tests that every request gets an ID and well-formed client IDs are kept
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestIDMiddleware())
+	r.GET("/id", func(c *gin.Context) { c.String(http.StatusOK, c.GetString(RequestIDKey)) })
+
+	send := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/id", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Generated IDs are unique and match the context value
+	first, second := send(""), send("")
+	if len(first.Header().Get(RequestIDHeader)) != 32 || first.Header().Get(RequestIDHeader) != first.Body.String() {
+		t.Errorf("Expected a 32-character ID in header and context, got %q / %q", first.Header().Get(RequestIDHeader), first.Body.String())
+	}
+	if first.Header().Get(RequestIDHeader) == second.Header().Get(RequestIDHeader) {
+		t.Error("Expected different IDs for different requests")
+	}
+
+	// A well-formed client ID is kept
+	if got := send("proxy-abc_123.4").Header().Get(RequestIDHeader); got != "proxy-abc_123.4" {
+		t.Errorf("Expected client ID to be kept, got %q", got)
+	}
+
+	// Malformed or oversized IDs are replaced
+	for _, bad := range []string{"bad id\nwith newline", strings.Repeat("a", 65)} {
+		if got := send(bad).Header().Get(RequestIDHeader); got == bad || len(got) != 32 {
+			t.Errorf("Expected %q to be replaced, got %q", bad, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit is a request rate with the burst allowed above it
+type RateLimit struct {
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst"`
+}
+
+// RateLimitConfig holds per-route rate limits (see ParseRateLimitConfig)
+type RateLimitConfig struct {
+	Default  *RateLimit           // for paths without a matching prefix; nil leaves them unlimited
+	Prefixes map[string]RateLimit // path prefix -> limit; the longest matching prefix wins
+}
+
+// rateLimitDefaultKey is the RATE_LIMITS key of the fallback limit
+const rateLimitDefaultKey = "default"
+
+// ParseRateLimitConfig parses a RATE_LIMITS value: a JSON object mapping path prefixes to limits,
+// plus an optional "default" fallback, e.g.
+// {"default": {"rps": 20, "burst": 40}, "/api/contacts/search": {"rps": 2, "burst": 5}}
+func ParseRateLimitConfig(value string) (RateLimitConfig, error) {
+	var raw map[string]RateLimit
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return RateLimitConfig{}, fmt.Errorf("must be a JSON object of {\"rps\": ..., \"burst\": ...} limits: %w", err)
+	}
+
+	config := RateLimitConfig{Prefixes: make(map[string]RateLimit)}
+	for key, limit := range raw {
+		if limit.RPS <= 0 || limit.Burst < 1 {
+			return RateLimitConfig{}, fmt.Errorf("limit for %q needs a positive rps and a burst of at least 1", key)
+		}
+		switch {
+		case key == rateLimitDefaultKey:
+			config.Default = &limit
+		case strings.HasPrefix(key, "/"):
+			config.Prefixes[strings.TrimSuffix(key, "/")] = limit
+		default:
+			return RateLimitConfig{}, fmt.Errorf("key %q must be %q or a path prefix starting with /", key, rateLimitDefaultKey)
+		}
+	}
+	return config, nil
+}
+
+// matchesPathPrefix reports whether path is prefix or below it ("/api/contacts" matches
+// "/api/contacts/1" but not "/api/contactsx")
+func matchesPathPrefix(path, prefix string) bool {
+	return strings.HasPrefix(path, prefix) && (len(path) == len(prefix) || path[len(prefix)] == '/' || prefix == "")
+}
+
+// RouteRateLimitMiddleware rate limits each request with the limiter of the longest path prefix it
+// matches, falling back to config.Default; every prefix keeps its own per-IP buckets
+// Behaves like RateLimitMiddleware otherwise (headers, 429 with Retry-After, exempt health paths)
+func RouteRateLimitMiddleware(config RateLimitConfig) gin.HandlerFunc {
+	limiters := make(map[string]*RateLimiter, len(config.Prefixes))
+	for prefix, limit := range config.Prefixes {
+		limiters[prefix] = NewRateLimiter(limit.RPS, limit.Burst)
+	}
+	var fallback *RateLimiter
+	if config.Default != nil {
+		fallback = NewRateLimiter(config.Default.RPS, config.Default.Burst)
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if rateLimitExempt[path] {
+			c.Next()
+			return
+		}
+
+		limiter := fallback
+		matched := -1
+		for prefix, prefixLimiter := range limiters {
+			if len(prefix) > matched && matchesPathPrefix(path, prefix) {
+				limiter, matched = prefixLimiter, len(prefix)
+			}
+		}
+
+		if limiter == nil || limiter.allow(c) {
+			c.Next()
+		}
+	}
+}
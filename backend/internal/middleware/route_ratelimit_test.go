@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestParseRateLimitConfig tests parsing of RATE_LIMITS values
+func TestParseRateLimitConfig(t *testing.T) {
+	config, err := ParseRateLimitConfig(`{"default": {"rps": 20, "burst": 40}, "/api/contacts/search/": {"rps": 2, "burst": 5}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Default == nil || *config.Default != (RateLimit{RPS: 20, Burst: 40}) {
+		t.Errorf("Expected default 20 rps / 40 burst, got %+v", config.Default)
+	}
+	if limit, ok := config.Prefixes["/api/contacts/search"]; !ok || limit != (RateLimit{RPS: 2, Burst: 5}) {
+		t.Errorf("Expected /api/contacts/search limit without trailing slash, got %+v", config.Prefixes)
+	}
+
+	for _, value := range []string{
+		`not json`,
+		`{"api/contacts": {"rps": 1, "burst": 1}}`,
+		`{"/api/contacts": {"rps": 0, "burst": 1}}`,
+		`{"/api/contacts": {"rps": 1}}`,
+	} {
+		if _, err := ParseRateLimitConfig(value); err == nil {
+			t.Errorf("Expected error for %s", value)
+		}
+	}
+}
+
+// TestRouteRateLimitMiddleware tests that the longest matching prefix picks the limiter and other paths fall back
+func TestRouteRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RouteRateLimitMiddleware(RateLimitConfig{
+		Default: &RateLimit{RPS: 1, Burst: 3},
+		Prefixes: map[string]RateLimit{
+			"/api/contacts":        {RPS: 1, Burst: 2},
+			"/api/contacts/search": {RPS: 1, Burst: 1},
+		},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	for _, path := range []string{"/api/contacts", "/api/contacts/1", "/api/contacts/search", "/api/contactsx", "/api/health"} {
+		r.GET(path, ok)
+	}
+
+	send := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-Real-IP", "203.0.113.9")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	// allowed sends requests until one is rate limited and returns how many got through
+	allowed := func(path string) int {
+		for i := 0; i < 10; i++ {
+			if send(path).Code == http.StatusTooManyRequests {
+				return i
+			}
+		}
+		return 10
+	}
+
+	if n := allowed("/api/contacts/search"); n != 1 {
+		t.Errorf("Expected 1 search request before 429, got %d", n)
+	}
+	// /api/contacts and /api/contacts/1 share the prefix's buckets, unaffected by the search limiter
+	if n := allowed("/api/contacts"); n != 2 {
+		t.Errorf("Expected 2 contacts requests before 429, got %d", n)
+	}
+	if code := send("/api/contacts/1").Code; code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 for /api/contacts/1 sharing the /api/contacts limiter, got %d", code)
+	}
+	// Not a segment match, so the default applies
+	if n := allowed("/api/contactsx"); n != 3 {
+		t.Errorf("Expected 3 requests on the default limiter before 429, got %d", n)
+	}
+	if n := allowed("/api/health"); n != 10 {
+		t.Errorf("Expected health checks to never be rate limited, got 429 after %d", n)
+	}
+}
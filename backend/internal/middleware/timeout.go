@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRequestTimeout is how long a request may run before its context is canceled
+const DefaultRequestTimeout = 30 * time.Second
+
+// RequestTimedOut reports whether the request context hit the deadline set by RequestTimeoutMiddleware
+// Handlers use it to leave the response to the middleware instead of reporting the canceled query as a 500
+func RequestTimedOut(c *gin.Context) bool {
+	return c.Request != nil && errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+}
+
+// RequestTimeoutMiddleware cancels the request context after timeout so in-flight database queries are canceled
+// overrides maps a route pattern (e.g. "/api/contacts/export") to its own timeout; 0 means no timeout (streaming routes)
+// A timeout of 0 disables the middleware for all other routes
+// If the handler returns without writing a response after the deadline, the client gets a 503
+func RequestTimeoutMiddleware(timeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routeTimeout := timeout
+		if override, ok := overrides[c.FullPath()]; ok {
+			routeTimeout = override
+		}
+		if routeTimeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), routeTimeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if RequestTimedOut(c) && !c.Writer.Written() {
+			c.Header("Retry-After", "5")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":      "Request timed out",
+				"message":    "The request took too long to process. Please try again or narrow the request.",
+				"request_id": c.GetString(RequestIDKey),
+			})
+		}
+	}
+}
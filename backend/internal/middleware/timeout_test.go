@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRequestTimeoutMiddleware tests that slow requests are canceled with a 503 unless their route is exempt
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestIDMiddleware())
+	r.Use(RequestTimeoutMiddleware(20*time.Millisecond, map[string]time.Duration{"/export": 0}))
+
+	// slow stands in for a handler stuck on a long query: it returns once the context is canceled
+	slow := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+			c.String(http.StatusOK, "done")
+		}
+	}
+	r.GET("/fast", func(c *gin.Context) { c.String(http.StatusOK, "done") })
+	r.GET("/slow", slow)
+	r.GET("/export", slow)
+
+	send := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	if w := send("/fast"); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d for a fast request, got %d", http.StatusOK, w.Code)
+	}
+
+	start := time.Now()
+	w := send("/slow")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d for a slow request, got %d. Body: %s", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("Expected the slow request to be canceled early, took %s", elapsed)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header on timeout")
+	}
+
+	// Exempt routes run to completion
+	if w := send("/export"); w.Code != http.StatusOK || w.Body.String() != "done" {
+		t.Errorf("Expected the exempt route to finish with %d, got %d (%q)", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// A zero timeout disables the middleware
+	disabled := gin.New()
+	disabled.Use(RequestTimeoutMiddleware(0, nil))
+	disabled.GET("/slow", slow)
+	w = httptest.NewRecorder()
+	disabled.ServeHTTP(w, httptest.NewRequest("GET", "/slow", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d with the timeout disabled, got %d", http.StatusOK, w.Code)
+	}
+}
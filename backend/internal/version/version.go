@@ -0,0 +1,31 @@
+// Package version exposes build information injected at compile time
+//
+// Set via ldflags, e.g.:
+//
+//	go build -ldflags="-X github.com/peridan9/resumecontrol/backend/internal/version.Version=1.2.0 \
+//	  -X github.com/peridan9/resumecontrol/backend/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/peridan9/resumecontrol/backend/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+// Build information (overridden by ldflags; "dev" for local builds)
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildTime = "dev"
+)
+
+// Info is the build information returned by GET /api/version
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	}
+}
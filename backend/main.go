@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
@@ -12,8 +16,11 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/peridan9/resumecontrol/backend/internal/auth"
 	"github.com/peridan9/resumecontrol/backend/internal/database"
 	"github.com/peridan9/resumecontrol/backend/internal/handlers"
+	"github.com/peridan9/resumecontrol/backend/internal/middleware"
+	"github.com/peridan9/resumecontrol/backend/internal/version"
 	_ "github.com/lib/pq" // PostgreSQL driver (imported for side effects)
 )
 
@@ -47,21 +54,64 @@ func main() {
 	// Optimized for RDS and production workloads
 	// Get environment to adjust pool settings accordingly
 	env := os.Getenv("ENV")
+	maxOpenConns, maxIdleConns := 10, 2 // Lower for dev/staging, minimal idle connections
 	if env == "production" {
-		db.SetMaxOpenConns(25)              // Higher for RDS production workloads
-		db.SetMaxIdleConns(5)               // Maintain some idle connections for performance
-	} else {
-		db.SetMaxOpenConns(10)              // Lower for dev/staging
-		db.SetMaxIdleConns(2)               // Minimal idle connections
+		maxOpenConns, maxIdleConns = 25, 5 // Higher for RDS production workloads, keep some idle for performance
 	}
-	db.SetConnMaxIdleTime(5 * time.Minute)  // Close idle connections reasonably
-	db.SetConnMaxLifetime(30 * time.Minute) // Reasonable upper bound to prevent stale connections
+	connMaxLifetime := 30 * time.Minute // Reasonable upper bound to prevent stale connections
 
-	// Test the connection with timeout to handle potential latency gracefully
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := db.PingContext(ctx); err != nil {
-		log.Fatalf("❌ Failed to ping database: %v (check RDS security groups, credentials, and connectivity)", err)
+	// DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME override the defaults above
+	if openStr := os.Getenv("DB_MAX_OPEN_CONNS"); openStr != "" {
+		open, err := strconv.Atoi(openStr)
+		if err != nil || open < 1 {
+			log.Fatalf("❌ Invalid DB_MAX_OPEN_CONNS %q: must be a positive number", openStr)
+		}
+		maxOpenConns = open
+	}
+	if idleStr := os.Getenv("DB_MAX_IDLE_CONNS"); idleStr != "" {
+		idle, err := strconv.Atoi(idleStr)
+		if err != nil || idle < 0 {
+			log.Fatalf("❌ Invalid DB_MAX_IDLE_CONNS %q: must be a non-negative number", idleStr)
+		}
+		maxIdleConns = idle
+	}
+	if maxIdleConns > maxOpenConns {
+		log.Fatalf("❌ Invalid DB_MAX_IDLE_CONNS %d: must not exceed DB_MAX_OPEN_CONNS (%d)", maxIdleConns, maxOpenConns)
+	}
+	if lifetimeStr := os.Getenv("DB_CONN_MAX_LIFETIME"); lifetimeStr != "" {
+		lifetime, err := time.ParseDuration(lifetimeStr)
+		if err != nil || lifetime < 0 {
+			log.Fatalf("❌ Invalid DB_CONN_MAX_LIFETIME %q: must be a non-negative duration like 30m (0 keeps connections forever)", lifetimeStr)
+		}
+		connMaxLifetime = lifetime
+	}
+
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxIdleTime(5 * time.Minute) // Close idle connections reasonably
+	db.SetConnMaxLifetime(connMaxLifetime)
+	log.Printf("🔧 Database pool: max_open=%d max_idle=%d conn_max_lifetime=%s", maxOpenConns, maxIdleConns, connMaxLifetime)
+
+	// Test the connection, retrying while the database may still be starting (e.g. in container orchestration)
+	// DB_CONNECT_ATTEMPTS sets how many pings are tried; DB_CONNECT_INTERVAL is the wait after the first failure, doubled each time
+	connectAttempts := 5
+	if attemptsStr := os.Getenv("DB_CONNECT_ATTEMPTS"); attemptsStr != "" {
+		attempts, err := strconv.Atoi(attemptsStr)
+		if err != nil || attempts < 1 {
+			log.Fatalf("❌ Invalid DB_CONNECT_ATTEMPTS %q: must be a positive number", attemptsStr)
+		}
+		connectAttempts = attempts
+	}
+	connectInterval := 2 * time.Second
+	if intervalStr := os.Getenv("DB_CONNECT_INTERVAL"); intervalStr != "" {
+		interval, err := time.ParseDuration(intervalStr)
+		if err != nil || interval <= 0 {
+			log.Fatalf("❌ Invalid DB_CONNECT_INTERVAL %q: must be a positive duration like 2s", intervalStr)
+		}
+		connectInterval = interval
+	}
+	if err := pingWithRetry(db, connectAttempts, connectInterval); err != nil {
+		log.Fatalf("❌ Failed to ping database after %d attempt(s): %v (check RDS security groups, credentials, and connectivity)", connectAttempts, err)
 	}
 	log.Println("✅ Successfully connected to database!")
 
@@ -75,8 +125,10 @@ func main() {
 	log.Println("✅ Clerk authentication initialized!")
 
 	// Set Gin mode based on environment
+	// Production also stops internal error text (SQL, schema) from reaching clients
 	if env == "production" {
 		gin.SetMode(gin.ReleaseMode)
+		handlers.ExposeErrorDetails = false
 	}
 
 	// Create sqlc queries instance
@@ -85,6 +137,14 @@ func main() {
 	// Initialize Gin router with default middleware (logger and recovery)
 	r := gin.Default()
 
+	// TRUSTED_PROXIES lists the proxy IPs/CIDRs (comma-separated) whose X-Forwarded-For/X-Real-IP headers
+	// c.ClientIP() honors; production trusts none by default, other environments keep Gin's trust-all default
+	if proxies := parseTrustedProxies(os.Getenv("TRUSTED_PROXIES")); proxies != nil || env == "production" {
+		if err := r.SetTrustedProxies(proxies); err != nil {
+			log.Fatalf("❌ Invalid TRUSTED_PROXIES: %v", err)
+		}
+	}
+
 	// Configure CORS middleware
 	// Allow frontend origin (default: http://localhost:3000)
 	// Can be overridden with FRONTEND_URL environment variable
@@ -93,36 +153,87 @@ func main() {
 		frontendURL = "http://localhost:3000"
 	}
 
-	// In development, allow all origins to support different browsers/IDEs (like Cursor's browser)
-	// In production, use specific origins for security
-	corsConfig := cors.Config{
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length"},
-		MaxAge:           12 * time.Hour,
+	// CORS_ALLOW_CREDENTIALS=false stops browsers from sending cookies/auth with cross-origin requests
+	corsAllowCredentials := true
+	if credentialsStr := os.Getenv("CORS_ALLOW_CREDENTIALS"); credentialsStr != "" {
+		allow, err := strconv.ParseBool(credentialsStr)
+		if err != nil {
+			log.Fatalf("❌ Invalid CORS_ALLOW_CREDENTIALS %q: must be true or false", credentialsStr)
+		}
+		corsAllowCredentials = allow
+	}
+	if env == "production" && corsAllowCredentials && frontendURL == "*" {
+		log.Fatalf("❌ Invalid FRONTEND_URL %q: browsers reject a wildcard origin with credentials; set the frontend origin or CORS_ALLOW_CREDENTIALS=false", frontendURL)
 	}
 
-	if env == "production" {
-		// Production: only allow specific frontend URL with credentials
-		corsConfig.AllowOrigins = []string{frontendURL}
-		corsConfig.AllowCredentials = true
-	} else {
-		// Development: allow all origins (including Cursor's browser, Chrome, etc.)
-		// Use AllowOriginFunc to dynamically allow any origin in development
-		corsConfig.AllowOriginFunc = func(origin string) bool {
-			// Log the origin for debugging (can be removed later)
-			if origin != "" {
-				log.Printf("CORS: Allowing origin: %s", origin)
-			} else {
-				log.Printf("CORS: Allowing empty origin (likely Cursor browser or similar)")
-			}
-			// Allow all origins in development
-			return true
+	// CORS_MAX_AGE sets how long browsers may cache preflight responses (0 disables caching)
+	corsMaxAge := 12 * time.Hour
+	if maxAgeStr := os.Getenv("CORS_MAX_AGE"); maxAgeStr != "" {
+		maxAge, err := time.ParseDuration(maxAgeStr)
+		if err != nil || maxAge < 0 {
+			log.Fatalf("❌ Invalid CORS_MAX_AGE %q: must be a duration like 12h (0 disables caching)", maxAgeStr)
 		}
-		corsConfig.AllowCredentials = true
+		corsMaxAge = maxAge
 	}
 
-	r.Use(cors.New(corsConfig))
+	r.Use(cors.New(newCORSConfig(env, frontendURL, corsAllowCredentials, corsMaxAge)))
+
+	// Assign each request an ID (X-Request-ID) so error responses can be matched to server logs
+	r.Use(middleware.RequestIDMiddleware())
+
+	// Configure maintenance mode (registered after CORS so preflight responses keep their headers)
+	// MAINTENANCE_MODE=write rejects mutating requests with 503, MAINTENANCE_MODE=full rejects everything but health
+	maintenanceMode, err := middleware.ParseMaintenanceMode(os.Getenv("MAINTENANCE_MODE"))
+	if err != nil {
+		log.Fatalf("❌ Invalid MAINTENANCE_MODE: %v", err)
+	}
+	maintenance := middleware.NewMaintenance(maintenanceMode)
+	if maintenanceMode != middleware.MaintenanceOff {
+		log.Printf("🚧 Maintenance mode enabled: %s", maintenanceMode)
+	}
+	r.Use(maintenance.Middleware())
+
+	// RATE_LIMITS sets per-route rate limits per client IP as JSON, keyed by path prefix with an optional
+	// "default" fallback, e.g. {"default": {"rps": 20, "burst": 40}, "/api/contacts/export": {"rps": 0.2, "burst": 2}}
+	// These apply on top of the fixed limits of the auth, shared and admin routes
+	if limitsStr := os.Getenv("RATE_LIMITS"); limitsStr != "" {
+		rateLimits, err := middleware.ParseRateLimitConfig(limitsStr)
+		if err != nil {
+			log.Fatalf("❌ Invalid RATE_LIMITS: %v", err)
+		}
+		r.Use(middleware.RouteRateLimitMiddleware(rateLimits))
+	}
+
+	// REQUEST_TIMEOUT cancels slow requests (and their database queries) with a 503; "0" disables it
+	// Streaming routes are exempt, see handlers.RequestTimeoutOverrides
+	requestTimeout := middleware.DefaultRequestTimeout
+	if timeoutStr := os.Getenv("REQUEST_TIMEOUT"); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout < 0 {
+			log.Fatalf("❌ Invalid REQUEST_TIMEOUT %q: must be a non-negative duration like 30s", timeoutStr)
+		}
+		requestTimeout = timeout
+	}
+	r.Use(middleware.RequestTimeoutMiddleware(requestTimeout, handlers.RequestTimeoutOverrides))
+
+	// SIGUSR1 toggles maintenance at runtime without a restart
+	// Toggles between off and the configured mode (write if none was configured)
+	toggleMode := maintenanceMode
+	if toggleMode == middleware.MaintenanceOff {
+		toggleMode = middleware.MaintenanceWrite
+	}
+	maintenanceSignals := make(chan os.Signal, 1)
+	signal.Notify(maintenanceSignals, syscall.SIGUSR1)
+	go func() {
+		for range maintenanceSignals {
+			if maintenance.Mode() == middleware.MaintenanceOff {
+				maintenance.SetMode(toggleMode)
+			} else {
+				maintenance.SetMode(middleware.MaintenanceOff)
+			}
+			log.Printf("🚧 Maintenance mode switched to: %s", maintenance.Mode())
+		}
+	}()
 
 	// Health check endpoint (now includes DB status)
 	// Support both GET and HEAD methods for health checks
@@ -133,27 +244,127 @@ func main() {
 
 		var result int
 		if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&result); err != nil {
-			c.JSON(500, gin.H{
-				"status":  "error",
-				"message": "Database connection failed",
-				"error":   err.Error(),
-			})
+			log.Printf("ERROR [500] health check: database connection failed - %v", err)
+			response := gin.H{
+				"status":    "error",
+				"message":   "Database connection failed",
+				"jwt_ready": auth.Ready(),
+			}
+			if handlers.ExposeErrorDetails {
+				response["error"] = err.Error()
+			}
+			c.JSON(500, response)
 			return
 		}
 
+		// jwt_ready reports whether the legacy JWT secret is loaded (token operations fail with a 500 otherwise)
 		c.JSON(200, gin.H{
-			"status":   "ok",
-			"message":  "ResumeControl API is running",
-			"database": "connected",
+			"status":    "ok",
+			"message":   "ResumeControl API is running",
+			"database":  "connected",
+			"jwt_ready": auth.Ready(),
 		})
 	}
 	r.GET("/api/health", healthHandler)
 	r.HEAD("/api/health", healthHandler)
 
+	// Version endpoint (public) - build info injected via ldflags, see internal/version
+	r.GET("/api/version", func(c *gin.Context) {
+		c.JSON(200, version.Get())
+	})
+
+	// Configure the pagination count cache TTL
+	// COUNT_CACHE_TTL accepts Go durations (e.g. "15s", "1m"); "0" disables caching
+	countCacheTTL := handlers.DefaultCountCacheTTL
+	if ttlStr := os.Getenv("COUNT_CACHE_TTL"); ttlStr != "" {
+		ttl, err := time.ParseDuration(ttlStr)
+		if err != nil || ttl < 0 {
+			log.Fatalf("❌ Invalid COUNT_CACHE_TTL %q: must be a non-negative duration like 15s", ttlStr)
+		}
+		countCacheTTL = ttl
+	}
+
+	// MAX_PAGINATION_OFFSET caps how deep page/limit pagination can go (rows skipped)
+	if offsetStr := os.Getenv("MAX_PAGINATION_OFFSET"); offsetStr != "" {
+		maxOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || maxOffset < 0 {
+			log.Fatalf("❌ Invalid MAX_PAGINATION_OFFSET %q: must be a non-negative number", offsetStr)
+		}
+		handlers.MaxPaginationOffset = int32(maxOffset)
+	}
+
+	// REGISTRATION_ENABLED=false makes the instance invite-only: Clerk users without an account are refused (403)
+	if registrationStr := os.Getenv("REGISTRATION_ENABLED"); registrationStr != "" {
+		enabled, err := strconv.ParseBool(registrationStr)
+		if err != nil {
+			log.Fatalf("❌ Invalid REGISTRATION_ENABLED %q: must be true or false", registrationStr)
+		}
+		middleware.RegistrationEnabled = enabled
+	}
+
+	// MAX_ATTACHMENTS_PER_CONTACT and ATTACHMENT_STORAGE_QUOTA_MB bound attachment storage ("0" disables either limit)
+	if maxStr := os.Getenv("MAX_ATTACHMENTS_PER_CONTACT"); maxStr != "" {
+		maxAttachments, err := strconv.Atoi(maxStr)
+		if err != nil || maxAttachments < 0 {
+			log.Fatalf("❌ Invalid MAX_ATTACHMENTS_PER_CONTACT %q: must be a non-negative number", maxStr)
+		}
+		handlers.MaxAttachmentsPerContact = maxAttachments
+	}
+	if quotaStr := os.Getenv("ATTACHMENT_STORAGE_QUOTA_MB"); quotaStr != "" {
+		quotaMB, err := strconv.ParseInt(quotaStr, 10, 32)
+		if err != nil || quotaMB < 0 {
+			log.Fatalf("❌ Invalid ATTACHMENT_STORAGE_QUOTA_MB %q: must be a non-negative number", quotaStr)
+		}
+		handlers.AttachmentStorageQuota = quotaMB << 20
+	}
+
+	// DB_RETRY_TRANSIENT=true retries a transaction once if it lost its database connection before commit
+	if retryStr := os.Getenv("DB_RETRY_TRANSIENT"); retryStr != "" {
+		retry, err := strconv.ParseBool(retryStr)
+		if err != nil {
+			log.Fatalf("❌ Invalid DB_RETRY_TRANSIENT %q: must be true or false", retryStr)
+		}
+		handlers.RetryTransientDBErrors = retry
+	}
+
+	// WEBHOOK_MAX_ATTEMPTS sets how many times a webhook delivery is tried (with exponential backoff) before it is marked failed
+	webhookMaxAttempts := handlers.DefaultWebhookMaxAttempts
+	if attemptsStr := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); attemptsStr != "" {
+		attempts, err := strconv.Atoi(attemptsStr)
+		if err != nil || attempts < 1 {
+			log.Fatalf("❌ Invalid WEBHOOK_MAX_ATTEMPTS %q: must be a positive number", attemptsStr)
+		}
+		webhookMaxAttempts = attempts
+	}
+	webhooks := handlers.NewWebhookDispatcher(queries, webhookMaxAttempts)
+	webhooks.Start(context.Background())
+
+	// DEFAULT_SORT_<RESOURCE> sets a list's default order (e.g. DEFAULT_SORT_COMPANIES=created_at:desc)
+	// A ?sort= query parameter still overrides it per request
+	for _, resource := range handlers.SortableResources() {
+		envVar := "DEFAULT_SORT_" + strings.ToUpper(resource)
+		if value := os.Getenv(envVar); value != "" {
+			if err := handlers.SetDefaultListSort(resource, value); err != nil {
+				log.Fatalf("❌ Invalid %s %q: %v", envVar, value, err)
+			}
+		}
+	}
+
+	// BASE_CURRENCY and CURRENCY_RATES (e.g. "EUR=1.08,GBP=1.27", base currency units per unit) let
+	// GET /api/stats/offers also report salaries converted to one currency
+	if base := os.Getenv("BASE_CURRENCY"); base != "" {
+		if err := handlers.SetCurrencyRates(base, os.Getenv("CURRENCY_RATES")); err != nil {
+			log.Fatalf("❌ Invalid BASE_CURRENCY/CURRENCY_RATES: %v", err)
+		}
+	}
+
 	// Initialize handlers config and setup routes
 	cfg := handlers.Config{
 		DB:         queries,
+		DBConn:     db,
 		ClerkJWKS:  clerkJWKS,
+		CountCache: handlers.NewCountCache(countCacheTTL),
+		Webhooks:   webhooks,
 	}
 	cfg.SetupRoutes(r)
 
@@ -171,3 +382,70 @@ func main() {
 	}
 }
 
+// newCORSConfig builds the CORS settings for env
+// Production only allows frontendURL; other environments allow any origin so different browsers/IDEs
+// (like Cursor's browser) work. Either way the request origin is echoed back instead of "*",
+// since browsers reject a wildcard Access-Control-Allow-Origin on credentialed requests
+func newCORSConfig(env, frontendURL string, allowCredentials bool, maxAge time.Duration) cors.Config {
+	corsConfig := cors.Config{
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With", "If-Match", "X-Request-ID", "X-Invite-Code"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After", "X-Request-ID"},
+		AllowCredentials: allowCredentials,
+		MaxAge:           maxAge,
+	}
+
+	if env == "production" {
+		// Production: only allow the specific frontend URL
+		corsConfig.AllowOrigins = []string{frontendURL}
+		return corsConfig
+	}
+
+	// Development: allow all origins (including Cursor's browser, Chrome, etc.)
+	// AllowOriginFunc (rather than AllowAllOrigins) makes the middleware reflect the origin with Vary: Origin
+	corsConfig.AllowOriginFunc = func(origin string) bool {
+		log.Printf("CORS: Allowing origin: %s", origin)
+		return true
+	}
+	return corsConfig
+}
+
+// parseTrustedProxies splits a comma-separated TRUSTED_PROXIES value, skipping blank entries
+// Returns nil when no proxy is listed
+func parseTrustedProxies(value string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// pingWithRetry pings the database up to attempts times, each with a 10s timeout to handle latency gracefully
+// It waits interval after the first failure and doubles the wait after each following one (capped at 30s)
+// Returns the last ping error if every attempt fails
+func pingWithRetry(db *sql.DB, attempts int, interval time.Duration) error {
+	const maxInterval = 30 * time.Second
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.Printf("⏳ Database not ready (attempt %d/%d): %v - retrying in %s", attempt, attempts, err, interval)
+		time.Sleep(interval)
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+-- name: CountAllApplications :one
+-- Count applications across all users (admin stats)
+SELECT COUNT(*) FROM applications;
+
+-- name: CountUsers :one
+-- Count all users (admin stats)
+SELECT COUNT(*) FROM users;
+
+-- name: CountUsersMatching :one
+-- Get total count of users matching GetUsersPaginated's email search (all users when email_search is NULL)
+SELECT COUNT(*) FROM users
+WHERE (sqlc.narg(email_search)::text IS NULL OR strpos(LOWER(email), LOWER(sqlc.narg(email_search))) > 0);
+
+-- name: DisableUserByID :one
+-- Disable a user (keeps the original disabled_at if already disabled) and return the admin view of the user
+UPDATE users
+SET disabled_at = COALESCE(disabled_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING id, email, name, created_at, last_login, role, disabled_at;
+
+-- name: EnableUserByID :one
+-- Re-enable a disabled user and return the admin view of the user
+UPDATE users
+SET disabled_at = NULL
+WHERE id = $1
+RETURNING id, email, name, created_at, last_login, role, disabled_at;
+
+-- name: GetSignupCountsByMonth :many
+-- Get new user counts per created_at month across all users, from a given time on (months without signups are omitted)
+SELECT date_trunc('month', created_at)::date AS month,
+       COUNT(*) AS count
+FROM users
+WHERE created_at >= $1
+GROUP BY month
+ORDER BY month ASC;
+
+-- name: GetUsersPaginated :many
+-- Get users newest first with pagination (admin user list); email_search, when set, matches a case-insensitive substring of the email
+SELECT id, email, name, created_at, last_login, role, disabled_at FROM users
+WHERE (sqlc.narg(email_search)::text IS NULL OR strpos(LOWER(email), LOWER(sqlc.narg(email_search))) > 0)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
@@ -0,0 +1,42 @@
+-- name: GetApplicationNotesByApplicationIDAndUserID :many
+-- Get all notes of an application in chronological order (verifies ownership through the application's user_id)
+SELECT n.* FROM application_notes n
+JOIN applications a ON a.id = n.application_id
+WHERE n.application_id = $1 AND a.user_id = $2
+ORDER BY n.created_at ASC, n.id ASC;
+
+-- name: GetApplicationNoteByIDAndUserID :one
+-- Get a single note of an application (verifies ownership through the application's user_id)
+SELECT n.* FROM application_notes n
+JOIN applications a ON a.id = n.application_id
+WHERE n.id = $1 AND n.application_id = $2 AND a.user_id = $3;
+
+-- name: CreateApplicationNote :one
+-- Append a note to an application and return the created record
+-- Ownership of the application must be verified before calling this
+INSERT INTO application_notes (application_id, body)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateApplicationNote :one
+-- Update the body of a note and return the updated record (verifies ownership through the application's user_id)
+UPDATE application_notes
+SET body = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_notes.id = $1
+  AND application_notes.application_id = $2
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = application_notes.application_id AND a.user_id = $4
+  )
+RETURNING *;
+
+-- name: DeleteApplicationNote :exec
+-- Delete a note (verifies ownership through the application's user_id)
+DELETE FROM application_notes
+WHERE application_notes.id = $1
+  AND application_notes.application_id = $2
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = application_notes.application_id AND a.user_id = $3
+  );
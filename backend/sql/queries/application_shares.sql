@@ -0,0 +1,25 @@
+-- name: CreateApplicationShare :one
+-- Create a share link for an application and return the created record
+-- Ownership of the application must be verified before calling this
+INSERT INTO application_shares (application_id, token_hash, include_notes, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetSharedApplicationByTokenHash :one
+-- Get the public view of a shared application: its status, job and company (notes are filtered by the handler)
+-- Returns no rows if the link does not exist or has expired
+SELECT a.status, a.applied_date, a.notes, s.include_notes, s.expires_at,
+       j.title AS job_title, j.description AS job_description, j.location AS job_location, j.employment_type AS job_employment_type,
+       co.name AS company_name, co.website AS company_website, co.industry AS company_industry
+FROM application_shares s
+JOIN applications a ON a.id = s.application_id
+LEFT JOIN jobs j ON j.application_id = a.id
+LEFT JOIN companies co ON co.id = j.company_id
+WHERE s.token_hash = $1
+  AND (s.expires_at IS NULL OR s.expires_at > CURRENT_TIMESTAMP);
+
+-- name: DeleteApplicationSharesByApplicationID :execrows
+-- Revoke all share links of an application and return how many were revoked
+-- Ownership of the application must be verified before calling this
+DELETE FROM application_shares
+WHERE application_id = $1;
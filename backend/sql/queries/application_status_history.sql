@@ -0,0 +1,7 @@
+-- name: GetApplicationStatusHistoryByApplicationIDAndUserID :many
+-- Get the statuses an application has been in, oldest first (verifies ownership through the application's user_id)
+-- Rows are written by a trigger on applications, on create and on every status change
+SELECT h.* FROM application_status_history h
+JOIN applications a ON a.id = h.application_id
+WHERE h.application_id = $1 AND a.user_id = $2
+ORDER BY h.changed_at ASC, h.id ASC;
@@ -1,25 +1,25 @@
 -- name: GetApplicationsByUserID :many
 -- Get all applications for a specific user, ordered by applied_date (newest first)
 SELECT * FROM applications
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC;
 
 -- name: GetApplicationsByUserIDPaginated :many
 -- Get paginated applications for a specific user, ordered by applied_date (newest first)
 SELECT * FROM applications
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: CountApplicationsByUserID :one
 -- Get total count of applications for a specific user
 SELECT COUNT(*) FROM applications
-WHERE user_id = $1;
+WHERE user_id = $1 AND archived_at IS NULL;
 
 -- name: CountApplicationsByStatusAndUserID :one
 -- Get total count of applications with a specific status for a specific user
 SELECT COUNT(*) FROM applications
-WHERE status = $1 AND user_id = $2;
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL;
 
 -- name: GetApplicationByIDAndUserID :one
 -- Get a single application by ID and user_id (ownership verification)
@@ -35,33 +35,47 @@ WHERE j.application_id = $1 AND a.user_id = $2;
 -- name: GetApplicationsByStatusAndUserID :many
 -- Get all applications with a specific status for a specific user
 SELECT * FROM applications
-WHERE status = $1 AND user_id = $2
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC;
 
 -- name: GetApplicationsByStatusAndUserIDPaginated :many
 -- Get paginated applications with a specific status for a specific user
 SELECT * FROM applications
-WHERE status = $1 AND user_id = $2
+WHERE status = $1 AND user_id = $2 AND archived_at IS NULL
 ORDER BY updated_at DESC NULLS LAST, created_at DESC
 LIMIT $3 OFFSET $4;
 
 -- name: CreateApplication :one
 -- Create a new application and return the created record
 -- Note: job_id is no longer needed, jobs will reference applications
--- contact_id is optional
-INSERT INTO applications (status, applied_date, notes, contact_id, user_id)
-VALUES ($1, $2, $3, $4, $5)
+-- contact_id, source, next_action/next_action_due, the offer fields, referred_by_contact_id, closed_reason and cover_letter are optional
+INSERT INTO applications (status, applied_date, notes, contact_id, user_id, source, next_action, next_action_due, offer_salary, offer_currency, offer_received_date, decision, referred_by_contact_id, closed_reason, cover_letter)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING *;
 
 -- name: UpdateApplication :one
 -- Update an application and return the updated record (verifies ownership via user_id)
+-- expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+-- keep_contact leaves contact_id unchanged (the client omitted it); otherwise contact_id is set, NULL detaching the contact
+-- The other keep_* flags do the same for their columns (keep_next_action covers next_action_due, keep_offer every offer column)
 UPDATE applications
-SET status = $2,
-    applied_date = $3,
-    notes = $4,
-    contact_id = $5,
+SET status = sqlc.arg(status),
+    applied_date = sqlc.arg(applied_date),
+    notes = sqlc.arg(notes),
+    contact_id = CASE WHEN sqlc.arg(keep_contact)::boolean THEN contact_id ELSE sqlc.arg(contact_id) END,
+    source = CASE WHEN sqlc.arg(keep_source)::boolean THEN source ELSE sqlc.arg(source) END,
+    next_action = CASE WHEN sqlc.arg(keep_next_action)::boolean THEN next_action ELSE sqlc.arg(next_action) END,
+    next_action_due = CASE WHEN sqlc.arg(keep_next_action)::boolean THEN next_action_due ELSE sqlc.arg(next_action_due) END,
+    offer_salary = CASE WHEN sqlc.arg(keep_offer)::boolean THEN offer_salary ELSE sqlc.arg(offer_salary) END,
+    offer_currency = CASE WHEN sqlc.arg(keep_offer)::boolean THEN offer_currency ELSE sqlc.arg(offer_currency) END,
+    offer_received_date = CASE WHEN sqlc.arg(keep_offer)::boolean THEN offer_received_date ELSE sqlc.arg(offer_received_date) END,
+    decision = CASE WHEN sqlc.arg(keep_offer)::boolean THEN decision ELSE sqlc.arg(decision) END,
+    referred_by_contact_id = CASE WHEN sqlc.arg(keep_referred_by)::boolean THEN referred_by_contact_id ELSE sqlc.arg(referred_by_contact_id) END,
+    closed_reason = CASE WHEN sqlc.arg(keep_closed_reason)::boolean THEN closed_reason ELSE sqlc.arg(closed_reason) END,
+    cover_letter = CASE WHEN sqlc.arg(keep_cover_letter)::boolean THEN cover_letter ELSE sqlc.arg(cover_letter) END,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $6
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(expected_updated_at)::timestamp IS NULL OR updated_at = sqlc.narg(expected_updated_at))
 RETURNING *;
 
 -- name: DeleteApplication :exec
@@ -69,3 +83,182 @@ RETURNING *;
 DELETE FROM applications
 WHERE id = $1 AND user_id = $2;
 
+
+-- name: GetApplicationsFilteredByUserID :many
+-- Get applications for a specific user with optional filters (a NULL filter is not applied)
+-- missing_job/missing_resume/missing_cover_letter keep only applications without a job/resume document/cover letter; archived applications are skipped unless include_archived is true
+-- row_limit NULL returns all rows (LIMIT NULL), otherwise paginates with row_offset
+-- sort_key picks the order (e.g. created_at_desc); other values keep the default order
+SELECT * FROM applications
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source))
+  AND (NOT sqlc.arg(missing_job)::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id))
+  AND (NOT sqlc.arg(missing_resume)::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = applications.id AND d.type = 'resume'))
+  AND (NOT sqlc.arg(missing_cover_letter)::boolean OR COALESCE(cover_letter, '') = '')
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_asc' THEN applied_date END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_desc' THEN applied_date END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_desc' THEN updated_at END DESC NULLS LAST,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_asc' THEN last_activity_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_desc' THEN last_activity_at END DESC NULLS LAST,
+  updated_at DESC NULLS LAST, created_at DESC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountApplicationsFilteredByUserID :one
+-- Get total count of applications for a specific user with the same optional filters
+SELECT COUNT(*) FROM applications
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source))
+  AND (NOT sqlc.arg(missing_job)::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id))
+  AND (NOT sqlc.arg(missing_resume)::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = applications.id AND d.type = 'resume'))
+  AND (NOT sqlc.arg(missing_cover_letter)::boolean OR COALESCE(cover_letter, '') = '')
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL);
+
+-- name: GetApplicationsWithDueNextActionByUserID :many
+-- Get applications whose next action is due on or before the given date (overdue first)
+SELECT * FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
+  AND next_action IS NOT NULL
+  AND next_action_due <= $2
+ORDER BY next_action_due ASC, id ASC;
+
+-- name: SnoozeNextActionByIDAndUserID :one
+-- Move an application's next action due date and return the updated record (verifies ownership via user_id)
+-- Only applications that have a next action are updated
+UPDATE applications
+SET next_action_due = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND user_id = $3 AND next_action IS NOT NULL
+RETURNING *;
+
+-- name: GetStaleApplicationsByUserID :many
+-- Get applications still in "applied" whose applied_date is before the given date (oldest first)
+SELECT * FROM applications
+WHERE user_id = $1 AND archived_at IS NULL
+  AND status = 'applied'
+  AND applied_date < $2
+ORDER BY applied_date ASC, id ASC;
+
+-- name: GetApplicationsByIDsAndUserID :many
+-- Get the user's applications among the given IDs (IDs of other users are silently skipped)
+SELECT * FROM applications
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND user_id = sqlc.arg(user_id);
+
+-- name: GetApplicationsWithFlagsFilteredByUserID :many
+-- Same as GetApplicationsFilteredByUserID plus flags telling whether a job, resume document or contact is attached
+-- sort_key picks the order (e.g. created_at_desc); other values keep the default order
+SELECT a.*,
+  EXISTS(SELECT 1 FROM jobs j WHERE j.application_id = a.id) AS has_job,
+  EXISTS(SELECT 1 FROM documents d WHERE d.application_id = a.id AND d.type = 'resume') AS has_resume,
+  EXISTS(SELECT 1 FROM contacts ct WHERE ct.id = a.contact_id) AS has_contact
+FROM applications a
+WHERE a.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(status)::text IS NULL OR a.status = sqlc.narg(status))
+  AND (sqlc.narg(source)::text IS NULL OR a.source = sqlc.narg(source))
+  AND (NOT sqlc.arg(missing_job)::boolean OR NOT EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = a.id))
+  AND (NOT sqlc.arg(missing_resume)::boolean OR NOT EXISTS (SELECT 1 FROM documents d WHERE d.application_id = a.id AND d.type = 'resume'))
+  AND (NOT sqlc.arg(missing_cover_letter)::boolean OR COALESCE(a.cover_letter, '') = '')
+  AND (sqlc.arg(include_archived)::boolean OR a.archived_at IS NULL)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_asc' THEN a.applied_date END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_desc' THEN a.applied_date END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN a.created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN a.created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_asc' THEN a.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_desc' THEN a.updated_at END DESC NULLS LAST,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_asc' THEN a.last_activity_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_desc' THEN a.last_activity_at END DESC NULLS LAST,
+  a.updated_at DESC NULLS LAST, a.created_at DESC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ArchiveApplicationByIDAndUserID :one
+-- Archive an application and return the updated record (verifies ownership via user_id)
+-- Archiving an archived application keeps its original archived_at
+UPDATE applications
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: UnarchiveApplicationByIDAndUserID :one
+-- Unarchive an application and return the updated record (verifies ownership via user_id)
+UPDATE applications
+SET archived_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ArchiveApplicationsByIDsAndUserID :many
+-- Archive the user's applications among the given IDs and return the archived records
+-- IDs of other users' applications and already archived ones are silently skipped
+UPDATE applications
+SET archived_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND user_id = sqlc.arg(user_id) AND archived_at IS NULL
+RETURNING *;
+
+-- name: GetApplicationsReferredByContactIDAndUserID :many
+-- Get the applications a contact referred the user for, most recently applied first
+SELECT * FROM applications
+WHERE referred_by_contact_id = $1 AND user_id = $2
+ORDER BY applied_date DESC, id DESC;
+
+-- name: ReassignApplicationContactByUserID :execrows
+-- Point the user's applications linked to one contact (as contact or referrer) at another and return how many changed
+-- Ownership of both contacts must be verified before calling this
+UPDATE applications
+SET contact_id = CASE WHEN contact_id = sqlc.arg(from_contact_id)::int THEN sqlc.arg(to_contact_id)::int ELSE contact_id END,
+    referred_by_contact_id = CASE WHEN referred_by_contact_id = sqlc.arg(from_contact_id)::int THEN sqlc.arg(to_contact_id)::int ELSE referred_by_contact_id END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id)
+  AND (contact_id = sqlc.arg(from_contact_id)::int OR referred_by_contact_id = sqlc.arg(from_contact_id)::int);
+
+-- name: GetUsedApplicationStatusesByUserID :many
+-- Get the distinct statuses present in a user's applications, in alphabetical order
+SELECT DISTINCT status FROM applications
+WHERE user_id = $1
+ORDER BY status ASC;
+
+-- name: SearchApplicationsByUserID :many
+-- Search a user's applications with optional filters combined with AND (a NULL filter is not applied)
+-- statuses matches any of the given statuses; company_id and tag_id match through the application's job and tags
+-- applied_from/applied_to bound applied_date (inclusive); sort_key orders like GetApplicationsFilteredByUserID
+-- archived applications are skipped unless include_archived is true
+SELECT * FROM applications
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(statuses)::text[] IS NULL OR status = ANY(sqlc.narg(statuses)::text[]))
+  AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source))
+  AND (sqlc.narg(company_id)::int IS NULL OR EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id AND j.company_id = sqlc.narg(company_id)))
+  AND (sqlc.narg(tag_id)::int IS NULL OR EXISTS (SELECT 1 FROM application_tags apt WHERE apt.application_id = applications.id AND apt.tag_id = sqlc.narg(tag_id)))
+  AND (sqlc.narg(applied_from)::date IS NULL OR applied_date >= sqlc.narg(applied_from))
+  AND (sqlc.narg(applied_to)::date IS NULL OR applied_date <= sqlc.narg(applied_to))
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_asc' THEN applied_date END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'applied_date_desc' THEN applied_date END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'updated_at_desc' THEN updated_at END DESC NULLS LAST,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_asc' THEN last_activity_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'last_activity_desc' THEN last_activity_at END DESC NULLS LAST,
+  updated_at DESC NULLS LAST, created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountSearchApplicationsByUserID :one
+-- Get total count of applications matching SearchApplicationsByUserID's filters
+SELECT COUNT(*) FROM applications
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(statuses)::text[] IS NULL OR status = ANY(sqlc.narg(statuses)::text[]))
+  AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source))
+  AND (sqlc.narg(company_id)::int IS NULL OR EXISTS (SELECT 1 FROM jobs j WHERE j.application_id = applications.id AND j.company_id = sqlc.narg(company_id)))
+  AND (sqlc.narg(tag_id)::int IS NULL OR EXISTS (SELECT 1 FROM application_tags apt WHERE apt.application_id = applications.id AND apt.tag_id = sqlc.narg(tag_id)))
+  AND (sqlc.narg(applied_from)::date IS NULL OR applied_date >= sqlc.narg(applied_from))
+  AND (sqlc.narg(applied_to)::date IS NULL OR applied_date <= sqlc.narg(applied_to))
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL);
@@ -1,26 +1,63 @@
 -- name: GetCompaniesByUserID :many
--- Get all companies for a specific user, ordered by name
+-- Get all non-archived companies for a specific user, ordered by name
 SELECT * FROM companies
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY name ASC;
 
 -- name: GetCompaniesByUserIDPaginated :many
--- Get paginated companies for a specific user, ordered by name
+-- Get paginated non-archived companies for a specific user, ordered by name
 SELECT * FROM companies
-WHERE user_id = $1
+WHERE user_id = $1 AND archived_at IS NULL
 ORDER BY name ASC
 LIMIT $2 OFFSET $3;
 
 -- name: CountCompaniesByUserID :one
--- Get total count of companies for a specific user
+-- Get total count of non-archived companies for a specific user
 SELECT COUNT(*) FROM companies
-WHERE user_id = $1;
+WHERE user_id = $1 AND archived_at IS NULL;
+
+-- name: GetCompaniesFilteredByUserID :many
+-- Get companies for a specific user with optional filters (a NULL filter is not applied)
+-- industry matches case-insensitively; archived companies are skipped unless include_archived is true
+-- has_website true keeps companies with a non-empty website, false those without one
+-- favorite true keeps starred companies, false the others
+-- row_limit NULL returns all rows, otherwise paginates with row_offset
+-- sort_key picks the order (e.g. created_at_desc); other values keep the default order
+-- favorites_first lists starred companies before the others, each group in sort_key order
+SELECT * FROM companies
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(industry)::text IS NULL OR LOWER(industry) = LOWER(sqlc.narg(industry)))
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL)
+  AND (sqlc.narg(has_website)::boolean IS NULL OR (COALESCE(website, '') <> '') = sqlc.narg(has_website))
+  AND (sqlc.narg(favorite)::boolean IS NULL OR is_favorite = sqlc.narg(favorite))
+ORDER BY
+  CASE WHEN sqlc.arg(favorites_first)::boolean THEN is_favorite END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'name_asc' THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'name_desc' THEN name END DESC,
+  name ASC, id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountCompaniesFilteredByUserID :one
+-- Get total count of companies for a specific user with the same optional filters
+SELECT COUNT(*) FROM companies
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(industry)::text IS NULL OR LOWER(industry) = LOWER(sqlc.narg(industry)))
+  AND (sqlc.arg(include_archived)::boolean OR archived_at IS NULL)
+  AND (sqlc.narg(has_website)::boolean IS NULL OR (COALESCE(website, '') <> '') = sqlc.narg(has_website))
+  AND (sqlc.narg(favorite)::boolean IS NULL OR is_favorite = sqlc.narg(favorite));
 
 -- name: GetCompanyByIDAndUserID :one
 -- Get a single company by ID and user_id (ownership verification)
 SELECT * FROM companies
 WHERE id = $1 AND user_id = $2;
 
+-- name: GetCompaniesByIDsAndUserID :many
+-- Get the user's companies among the given IDs (IDs of other users are silently skipped)
+SELECT * FROM companies
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND user_id = sqlc.arg(user_id);
+
 -- name: GetCompanyByNameAndUserID :one
 -- Get a company by name and user_id (case-insensitive, for normalization check)
 -- This query uses normalized comparison to check if a company exists for a specific user
@@ -30,17 +67,38 @@ LIMIT 1;
 
 -- name: CreateCompany :one
 -- Create a new company and return the created record
-INSERT INTO companies (name, website, user_id)
-VALUES ($1, $2, $3)
+INSERT INTO companies (name, website, user_id, industry, size)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
+-- name: UpsertCompany :one
+-- Get-or-create a company by normalized name in one atomic statement (no check-then-insert race)
+-- An existing company is returned unchanged; inserted is true only when a new row was created
+INSERT INTO companies (name, website, user_id, industry, size)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, LOWER(TRIM(name))) DO UPDATE SET name = companies.name
+RETURNING *, (xmax = 0) AS inserted;
+
 -- name: UpdateCompany :one
 -- Update a company and return the updated record (verifies ownership via user_id)
+-- expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+-- keep_industry/keep_size leave the column unchanged (the client omitted it); otherwise it is set, NULL clearing it
+UPDATE companies
+SET name = sqlc.arg(name),
+    website = sqlc.arg(website),
+    industry = CASE WHEN sqlc.arg(keep_industry)::boolean THEN industry ELSE sqlc.arg(industry) END,
+    size = CASE WHEN sqlc.arg(keep_size)::boolean THEN size ELSE sqlc.arg(size) END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(expected_updated_at)::timestamp IS NULL OR updated_at = sqlc.narg(expected_updated_at))
+RETURNING *;
+
+-- name: SetCompanyFavorite :one
+-- Star or unstar a company and return the updated record (verifies ownership via user_id)
 UPDATE companies
-SET name = $2,
-    website = $3,
+SET is_favorite = $1,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $4
+WHERE id = $2 AND user_id = $3
 RETURNING *;
 
 -- name: DeleteCompany :exec
@@ -48,3 +106,115 @@ RETURNING *;
 DELETE FROM companies
 WHERE id = $1 AND user_id = $2;
 
+
+-- name: CountActiveApplicationsByCompanyID :one
+-- Get how many of the user's applications for a company (through their jobs) are still active
+-- Active means the application has not been archived
+SELECT COUNT(*) FROM applications a
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+  AND a.archived_at IS NULL;
+
+-- name: GetCompanyDeleteImpact :one
+-- Count what deleting a company affects (verify ownership of the company first)
+-- Its jobs are cascade-deleted; their applications are kept but lose their job
+SELECT COUNT(*) AS jobs,
+       COUNT(*) FILTER (WHERE a.status IN ('applied', 'interview', 'offer') AND a.archived_at IS NULL) AS active_applications
+FROM jobs j
+JOIN applications a ON a.id = j.application_id
+WHERE j.company_id = $1 AND a.user_id = $2;
+
+-- name: ArchiveCompany :one
+-- Archive a company and return the updated record (verifies ownership via user_id)
+-- Archiving an archived company keeps its original archived_at
+UPDATE companies
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: UnarchiveCompany :one
+-- Unarchive a company and return the updated record (verifies ownership via user_id)
+UPDATE companies
+SET archived_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetCompanyActivityByUserID :many
+-- Get a merged timeline of a company's activity, newest first, through the company's jobs (verifies ownership via user_id)
+-- event_type is application_created, status_changed (every status after the first, see application_status_history)
+-- or interview (at its scheduled time, or when it was added if unscheduled)
+SELECT 'application_created'::text AS event_type, COALESCE(a.created_at, a.applied_date)::timestamp AS occurred_at,
+       a.id AS application_id, j.id AS job_id, j.title AS job_title, a.status AS status,
+       NULL::int AS interview_id, NULL::text AS interview_title, NULL::text AS interview_outcome
+FROM applications a
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+UNION ALL
+SELECT 'status_changed'::text, h.changed_at,
+       a.id, j.id, j.title, h.status,
+       NULL::int, NULL::text, NULL::text
+FROM application_status_history h
+JOIN applications a ON a.id = h.application_id
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+  AND EXISTS (
+    SELECT 1 FROM application_status_history earlier
+    WHERE earlier.application_id = h.application_id AND earlier.id < h.id
+  )
+UNION ALL
+SELECT 'interview'::text, COALESCE(i.scheduled_at, i.created_at, CURRENT_TIMESTAMP)::timestamp,
+       a.id, j.id, j.title, NULL::text,
+       i.id, i.title, i.outcome
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+JOIN jobs j ON j.application_id = a.id
+WHERE j.company_id = $1 AND a.user_id = $2
+ORDER BY occurred_at DESC, application_id DESC, event_type ASC
+LIMIT $3 OFFSET $4;
+
+-- name: CountCompanyActivityByUserID :one
+-- Get total count of events in GetCompanyActivityByUserID's timeline
+SELECT
+  (SELECT COUNT(*) FROM applications a
+   JOIN jobs j ON j.application_id = a.id
+   WHERE j.company_id = $1 AND a.user_id = $2)
+  + (SELECT COUNT(*) FROM application_status_history h
+     JOIN applications a ON a.id = h.application_id
+     JOIN jobs j ON j.application_id = a.id
+     WHERE j.company_id = $1 AND a.user_id = $2
+       AND EXISTS (
+         SELECT 1 FROM application_status_history earlier
+         WHERE earlier.application_id = h.application_id AND earlier.id < h.id
+       ))
+  + (SELECT COUNT(*) FROM interviews i
+     JOIN applications a ON a.id = i.application_id
+     JOIN jobs j ON j.application_id = a.id
+     WHERE j.company_id = $1 AND a.user_id = $2)
+  AS count;
+
+-- name: GetCompanyResponseTime :one
+-- Get the median number of days from applied_date to the first status change after 'applied',
+-- across the company's applications that were in 'applied' and have moved on since (verify ownership of the company first)
+-- samples is how many applications the median is based on (median_days is 0 when there are none)
+WITH responses AS (
+  SELECT a.applied_date,
+         (SELECT MIN(later.changed_at) FROM application_status_history later
+          WHERE later.application_id = a.id AND later.status <> 'applied' AND later.id > applied.id) AS responded_at
+  FROM applications a
+  JOIN jobs j ON j.application_id = a.id
+  JOIN LATERAL (
+    SELECT h.id FROM application_status_history h
+    WHERE h.application_id = a.id AND h.status = 'applied'
+    ORDER BY h.id ASC
+    LIMIT 1
+  ) applied ON true
+  WHERE j.company_id = $1 AND a.user_id = $2
+)
+SELECT COALESCE(percentile_cont(0.5) WITHIN GROUP (
+         ORDER BY GREATEST(EXTRACT(EPOCH FROM (responded_at - applied_date::timestamp)) / 86400, 0)
+       ), 0)::float8 AS median_days,
+       COUNT(*) AS samples
+FROM responses
+WHERE responded_at IS NOT NULL;
@@ -0,0 +1,53 @@
+-- name: GetContactAttachmentsByContactIDAndUserID :many
+-- Get the attachments of a contact without their data, oldest first (verifies ownership through the contact's user_id)
+SELECT ca.id, ca.contact_id, ca.filename, ca.content_type, ca.size_bytes, ca.created_at
+FROM contact_attachments ca
+JOIN contacts ct ON ct.id = ca.contact_id
+WHERE ca.contact_id = $1 AND ct.user_id = $2
+ORDER BY ca.created_at ASC, ca.id ASC;
+
+-- name: GetContactAttachmentByIDAndUserID :one
+-- Get a single attachment of a contact with its data (verifies ownership through the contact's user_id)
+SELECT ca.* FROM contact_attachments ca
+JOIN contacts ct ON ct.id = ca.contact_id
+WHERE ca.id = $1 AND ca.contact_id = $2 AND ct.user_id = $3;
+
+-- name: CreateContactAttachment :one
+-- Store a file for a contact and return the created record without its data
+-- Ownership of the contact must be verified before calling this
+INSERT INTO contact_attachments (contact_id, filename, content_type, size_bytes, data)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, contact_id, filename, content_type, size_bytes, created_at;
+
+-- name: DeleteContactAttachment :execrows
+-- Delete an attachment of a contact and return how many were deleted (verifies ownership through the contact's user_id)
+DELETE FROM contact_attachments
+WHERE contact_attachments.id = $1
+  AND contact_attachments.contact_id = $2
+  AND EXISTS (
+    SELECT 1 FROM contacts ct
+    WHERE ct.id = contact_attachments.contact_id AND ct.user_id = $3
+  );
+
+-- name: CountContactAttachmentsByContactID :one
+-- Count the attachments of a contact (ownership of the contact must be verified before calling this)
+SELECT COUNT(*) FROM contact_attachments
+WHERE contact_id = $1;
+
+-- name: GetAttachmentStorageByUserID :one
+-- Total size in bytes of the files attached to the user's contacts
+SELECT COALESCE(SUM(ca.size_bytes), 0)::bigint AS total_bytes
+FROM contact_attachments ca
+JOIN contacts ct ON ct.id = ca.contact_id
+WHERE ct.user_id = $1;
+
+-- name: ReassignContactAttachmentsByUserID :execrows
+-- Move the attachments of one of the user's contacts to another and return how many moved
+-- Ownership of both contacts must be verified before calling this
+UPDATE contact_attachments
+SET contact_id = sqlc.arg(to_contact_id)::int
+WHERE contact_id = sqlc.arg(from_contact_id)::int
+  AND EXISTS (
+    SELECT 1 FROM contacts ct
+    WHERE ct.id = contact_attachments.contact_id AND ct.user_id = sqlc.arg(user_id)
+  );
@@ -1,29 +1,68 @@
 -- name: GetContactsByUserID :many
--- Get all contacts for a specific user, ordered by name
+-- Get all contacts for a specific user, ordered by sort_key (e.g. created_at_desc), then by name
+-- email_domain, when set, keeps contacts whose email is at exactly that domain (case-insensitive)
+-- role, when set, keeps contacts with that role
+-- row_limit NULL returns all rows, otherwise paginates with row_offset
 SELECT * FROM contacts
-WHERE user_id = $1
-ORDER BY name ASC;
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(email_domain)::text IS NULL OR LOWER(split_part(email, '@', 2)) = LOWER(sqlc.narg(email_domain)))
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'name_asc' THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'name_desc' THEN name END DESC,
+  name ASC, id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountContactsByUserID :one
+-- Get total count of contacts for a specific user with the same optional email_domain and role filters
+SELECT COUNT(*) FROM contacts
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(email_domain)::text IS NULL OR LOWER(split_part(email, '@', 2)) = LOWER(sqlc.narg(email_domain)))
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role));
+
+-- name: GetContactsByUserIDAfterID :many
+-- Get the next batch of a user's contacts in ID order, starting after after_id (keyset pagination for exports)
+SELECT * FROM contacts
+WHERE user_id = sqlc.arg(user_id) AND id > sqlc.arg(after_id)
+ORDER BY id ASC
+LIMIT sqlc.arg(batch_size);
 
 -- name: GetContactByIDAndUserID :one
 -- Get a contact by ID and user_id (ownership verification)
 SELECT * FROM contacts
 WHERE id = $1 AND user_id = $2;
 
+-- name: GetContactsByIDsAndUserID :many
+-- Get the user's contacts among the given IDs (IDs of other users are silently skipped)
+SELECT * FROM contacts
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND user_id = sqlc.arg(user_id);
+
+-- name: GetOwnedContactIDsByUserID :many
+-- Get which of the given contact IDs belong to the user (ownership check for bulk operations)
+SELECT id FROM contacts
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND user_id = sqlc.arg(user_id);
+
 -- name: CreateContact :one
 -- Create a new contact and return the created record
-INSERT INTO contacts (name, email, phone, linkedin, user_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO contacts (name, email, phone, linkedin, user_id, role)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: UpdateContact :one
 -- Update a contact and return the updated record (verifies ownership via user_id)
+-- expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+-- keep_role leaves role unchanged (the client omitted it); otherwise it is set, NULL clearing it
 UPDATE contacts
-SET name = $2,
-    email = $3,
-    phone = $4,
-    linkedin = $5,
+SET name = sqlc.arg(name),
+    email = sqlc.arg(email),
+    phone = sqlc.arg(phone),
+    linkedin = sqlc.arg(linkedin),
+    role = CASE WHEN sqlc.arg(keep_role)::boolean THEN role ELSE sqlc.arg(role) END,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $6
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(expected_updated_at)::timestamp IS NULL OR updated_at = sqlc.narg(expected_updated_at))
 RETURNING *;
 
 -- name: DeleteContact :exec
@@ -31,3 +70,25 @@ RETURNING *;
 DELETE FROM contacts
 WHERE id = $1 AND user_id = $2;
 
+
+-- name: GetContactByEmailAndUserID :one
+-- Get a contact by email and user_id (case-insensitive, for get-or-create dedupe)
+SELECT * FROM contacts
+WHERE LOWER(email) = LOWER($1) AND user_id = $2
+LIMIT 1;
+
+-- name: SearchContactsByUserID :many
+-- Get the user's contacts with any of name, email, phone, linkedin or role matching the ILIKE pattern, ordered by name
+SELECT * FROM contacts
+WHERE user_id = sqlc.arg(user_id)
+  AND (name ILIKE sqlc.arg(pattern) OR email ILIKE sqlc.arg(pattern) OR phone ILIKE sqlc.arg(pattern)
+    OR linkedin ILIKE sqlc.arg(pattern) OR role ILIKE sqlc.arg(pattern))
+ORDER BY name ASC, id ASC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountContactsMatchingByUserID :one
+-- Count the user's contacts matched by SearchContactsByUserID
+SELECT COUNT(*) FROM contacts
+WHERE user_id = sqlc.arg(user_id)
+  AND (name ILIKE sqlc.arg(pattern) OR email ILIKE sqlc.arg(pattern) OR phone ILIKE sqlc.arg(pattern)
+    OR linkedin ILIKE sqlc.arg(pattern) OR role ILIKE sqlc.arg(pattern));
@@ -0,0 +1,44 @@
+-- name: GetDocumentsByApplicationIDAndUserID :many
+-- Get all documents for an application (verifies ownership through the application's user_id)
+SELECT d.* FROM documents d
+JOIN applications a ON a.id = d.application_id
+WHERE d.application_id = $1 AND a.user_id = $2
+ORDER BY d.created_at ASC, d.id ASC;
+
+-- name: GetDocumentByIDAndUserID :one
+-- Get a single document of an application (verifies ownership through the application's user_id)
+SELECT d.* FROM documents d
+JOIN applications a ON a.id = d.application_id
+WHERE d.id = $1 AND d.application_id = $2 AND a.user_id = $3;
+
+-- name: CreateDocument :one
+-- Create a new document link and return the created record
+-- Ownership of the application must be verified before calling this
+INSERT INTO documents (application_id, label, url, type)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateDocument :one
+-- Update a document link and return the updated record (verifies ownership through the application's user_id)
+UPDATE documents
+SET label = $3,
+    url = $4,
+    type = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE documents.id = $1
+  AND documents.application_id = $2
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = documents.application_id AND a.user_id = $6
+  )
+RETURNING *;
+
+-- name: DeleteDocument :exec
+-- Delete a document link (verifies ownership through the application's user_id)
+DELETE FROM documents
+WHERE documents.id = $1
+  AND documents.application_id = $2
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = documents.application_id AND a.user_id = $3
+  );
@@ -0,0 +1,37 @@
+-- name: GetUpcomingInterviewsByApplicationIDAndUserID :many
+-- Get the interviews of an application that are not completed yet, soonest first (verifies ownership through the application's user_id)
+-- Interviews without a scheduled time come last
+SELECT i.* FROM interviews i
+JOIN applications a ON a.id = i.application_id
+WHERE i.application_id = $1 AND a.user_id = $2 AND i.completed = FALSE
+ORDER BY i.scheduled_at ASC NULLS LAST, i.id ASC;
+
+-- name: GetCompletedInterviewsByApplicationIDAndUserID :many
+-- Get the completed interviews of an application, most recent first (verifies ownership through the application's user_id)
+SELECT i.* FROM interviews i
+JOIN applications a ON a.id = i.application_id
+WHERE i.application_id = $1 AND a.user_id = $2 AND i.completed = TRUE
+ORDER BY i.scheduled_at DESC NULLS LAST, i.id DESC;
+
+-- name: CreateInterview :one
+-- Add an interview to an application and return the created record
+-- Ownership of the application must be verified before calling this
+INSERT INTO interviews (application_id, title, scheduled_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateInterviewResult :one
+-- Partially update whether an interview is completed, its outcome and feedback (NULL keeps the current value)
+-- Verifies ownership through the application's user_id
+UPDATE interviews
+SET completed = COALESCE(sqlc.narg(completed)::boolean, completed),
+    outcome = COALESCE(sqlc.narg(outcome)::text, outcome),
+    feedback = COALESCE(sqlc.narg(feedback)::text, feedback),
+    updated_at = CURRENT_TIMESTAMP
+WHERE interviews.id = sqlc.arg(id)
+  AND interviews.application_id = sqlc.arg(application_id)
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = interviews.application_id AND a.user_id = sqlc.arg(user_id)
+  )
+RETURNING *;
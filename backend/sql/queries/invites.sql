@@ -0,0 +1,18 @@
+-- name: CreateInvite :one
+-- Create an invite code and return the created record
+INSERT INTO invites (code, created_by, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetInviteByCodeForUpdate :one
+-- Get an invite by code and lock it until the transaction ends, so a code can only be redeemed once
+SELECT * FROM invites
+WHERE code = $1
+FOR UPDATE;
+
+-- name: MarkInviteUsed :exec
+-- Mark an invite as redeemed by a user
+UPDATE invites
+SET used_by = $2,
+    used_at = CURRENT_TIMESTAMP
+WHERE id = $1;
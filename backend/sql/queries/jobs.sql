@@ -19,6 +19,29 @@ SELECT COUNT(*) FROM jobs j
 INNER JOIN applications a ON j.application_id = a.id
 WHERE a.user_id = $1;
 
+-- name: GetJobsFilteredByUserID :many
+-- Get jobs for a specific user (through applications) with optional filters (a NULL filter is not applied)
+-- row_limit NULL returns all rows (LIMIT NULL), otherwise paginates with row_offset
+-- sort_key picks the order (e.g. created_at_desc); other values keep the default order
+SELECT j.* FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE a.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(employment_type)::text IS NULL OR j.employment_type = sqlc.narg(employment_type))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_asc' THEN j.created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'created_at_desc' THEN j.created_at END DESC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'title_asc' THEN j.title END ASC,
+  CASE WHEN sqlc.arg(sort_key)::text = 'title_desc' THEN j.title END DESC,
+  j.created_at DESC, j.id DESC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountJobsFilteredByUserID :one
+-- Get total count of jobs for a specific user with the same optional filters
+SELECT COUNT(*) FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE a.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(employment_type)::text IS NULL OR j.employment_type = sqlc.narg(employment_type));
+
 -- name: GetJobByIDAndUserID :one
 -- Get a single job by ID and verify ownership through application's user_id
 SELECT j.* FROM jobs j
@@ -39,27 +62,53 @@ INNER JOIN applications a ON j.application_id = a.id
 WHERE j.application_id = $1 AND a.user_id = $2
 ORDER BY j.created_at DESC;
 
+-- name: GetJobsByApplicationIDsAndUserID :many
+-- Get the jobs of the given applications and verify ownership through application's user_id
+-- Used to expand ?expand=job on application lists in one query
+SELECT j.* FROM jobs j
+INNER JOIN applications a ON j.application_id = a.id
+WHERE j.application_id = ANY(sqlc.arg(application_ids)::int[]) AND a.user_id = sqlc.arg(user_id);
+
 -- name: CreateJob :one
 -- Create a new job and return the created record
 -- Jobs now belong to applications (application_id is required)
 -- Note: user_id verification happens in handler by checking application ownership
-INSERT INTO jobs (application_id, company_id, title, description, requirements, location)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO jobs (application_id, company_id, title, description, requirements, location, employment_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateJob :one
 -- Update a job and return the updated record (verifies ownership through application's user_id)
+-- expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+-- keep_employment_type leaves employment_type unchanged (the client omitted it); otherwise it is set, NULL clearing it
 UPDATE jobs
-SET title = $2,
-    description = $3,
-    requirements = $4,
-    location = $5,
+SET title = sqlc.arg(title),
+    description = sqlc.arg(description),
+    requirements = sqlc.arg(requirements),
+    location = sqlc.arg(location),
+    employment_type = CASE WHEN sqlc.arg(keep_employment_type)::boolean THEN employment_type ELSE sqlc.arg(employment_type) END,
     updated_at = CURRENT_TIMESTAMP
-WHERE jobs.id = $1
+WHERE jobs.id = sqlc.arg(id)
+  AND EXISTS (
+    SELECT 1 FROM applications a
+    WHERE a.id = jobs.application_id AND a.user_id = sqlc.arg(user_id)
+  )
+  AND (sqlc.narg(expected_updated_at)::timestamp IS NULL OR jobs.updated_at = sqlc.narg(expected_updated_at))
+RETURNING *;
+
+-- name: MoveJobToApplication :one
+-- Link a job to another application and return the updated record (verifies ownership through application's user_id)
+-- The caller checks the target application is the user's; jobs_application_id_unique keeps one job per application
+-- expected_updated_at enables optimistic concurrency: when set, no row is updated if the record changed since
+UPDATE jobs
+SET application_id = sqlc.arg(application_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE jobs.id = sqlc.arg(id)
   AND EXISTS (
     SELECT 1 FROM applications a
-    WHERE a.id = jobs.application_id AND a.user_id = $6
+    WHERE a.id = jobs.application_id AND a.user_id = sqlc.arg(user_id)
   )
+  AND (sqlc.narg(expected_updated_at)::timestamp IS NULL OR jobs.updated_at = sqlc.narg(expected_updated_at))
 RETURNING *;
 
 -- name: DeleteJob :exec
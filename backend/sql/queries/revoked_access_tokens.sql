@@ -0,0 +1,17 @@
+-- name: RevokeAccessToken :exec
+-- Add an access token's jti to the denylist (revoking an already revoked token is a no-op)
+INSERT INTO revoked_access_tokens (jti, user_id, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsAccessTokenRevoked :one
+-- Check whether an access token's jti is on the denylist
+SELECT EXISTS (
+    SELECT 1 FROM revoked_access_tokens
+    WHERE jti = $1
+) AS revoked;
+
+-- name: DeleteExpiredRevokedAccessTokens :execrows
+-- Delete denylist entries of tokens that have expired anyway (for maintenance/cleanup)
+DELETE FROM revoked_access_tokens
+WHERE expires_at < CURRENT_TIMESTAMP;
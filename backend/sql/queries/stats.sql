@@ -0,0 +1,137 @@
+-- name: GetApplicationStatsBySource :many
+-- Get application counts per source for a specific user, with how many reached interview/offer
+-- Applications without a source are grouped under 'unspecified'
+SELECT COALESCE(source, 'unspecified')::text AS source,
+       COUNT(*) AS total,
+       COUNT(*) FILTER (WHERE status IN ('interview', 'offer', 'accepted')) AS interviews,
+       COUNT(*) FILTER (WHERE status IN ('offer', 'accepted')) AS offers
+FROM applications
+WHERE user_id = $1
+GROUP BY COALESCE(source, 'unspecified')
+ORDER BY total DESC, source ASC;
+
+-- name: GetApplicationStatusCounts :many
+-- Get application counts per status for a specific user
+SELECT status, COUNT(*) AS count
+FROM applications
+WHERE user_id = $1
+GROUP BY status
+ORDER BY status ASC;
+
+-- name: GetUserTotals :one
+-- Get total numbers of applications, companies, contacts and jobs for a specific user
+SELECT (SELECT COUNT(*) FROM applications a WHERE a.user_id = $1)::bigint AS applications,
+       (SELECT COUNT(*) FROM companies co WHERE co.user_id = $1)::bigint AS companies,
+       (SELECT COUNT(*) FROM contacts ct WHERE ct.user_id = $1)::bigint AS contacts,
+       (SELECT COUNT(*) FROM jobs j JOIN applications ja ON ja.id = j.application_id WHERE ja.user_id = $1)::bigint AS jobs;
+
+-- name: GetApplicationStatsByIndustry :many
+-- Get application counts per company industry for a specific user (through each application's job)
+-- Industries are compared case-insensitively; companies without an industry are grouped under 'unspecified'
+SELECT COALESCE(LOWER(co.industry), 'unspecified')::text AS industry,
+       COUNT(DISTINCT a.id) AS total
+FROM applications a
+JOIN jobs j ON j.application_id = a.id
+JOIN companies co ON co.id = j.company_id
+WHERE a.user_id = $1
+GROUP BY COALESCE(LOWER(co.industry), 'unspecified')
+ORDER BY total DESC, industry ASC;
+
+-- name: GetOfferStats :one
+-- Get offer outcome counts for a specific user; an offer is an application in "offer" or "accepted"
+-- An offer counts as accepted if its status is "accepted" or its decision is "accepted"
+SELECT COUNT(*) AS offers,
+       COUNT(*) FILTER (WHERE status = 'accepted' OR decision = 'accepted') AS accepted,
+       COUNT(*) FILTER (WHERE status <> 'accepted' AND decision = 'declined') AS declined
+FROM applications
+WHERE user_id = $1
+  AND status IN ('offer', 'accepted');
+
+-- name: GetOfferSalaryStatsByCurrency :many
+-- Get offered salary ranges per currency for a specific user (offers with a salary and currency only)
+SELECT offer_currency::text AS currency,
+       COUNT(*) AS offers,
+       MIN(offer_salary)::bigint AS min_salary,
+       MAX(offer_salary)::bigint AS max_salary,
+       AVG(offer_salary)::float8 AS avg_salary
+FROM applications
+WHERE user_id = $1
+  AND status IN ('offer', 'accepted')
+  AND offer_salary IS NOT NULL
+  AND offer_currency IS NOT NULL
+GROUP BY offer_currency
+ORDER BY offers DESC, currency ASC;
+
+-- name: GetReferralStatsByContact :many
+-- Get referral outcomes per referring contact for a specific user, with how many reached interview/offer
+SELECT ct.id AS contact_id,
+       ct.name AS contact_name,
+       COUNT(*) AS total,
+       COUNT(*) FILTER (WHERE a.status IN ('interview', 'offer', 'accepted')) AS interviews,
+       COUNT(*) FILTER (WHERE a.status IN ('offer', 'accepted')) AS offers
+FROM applications a
+JOIN contacts ct ON ct.id = a.referred_by_contact_id
+WHERE a.user_id = $1
+GROUP BY ct.id, ct.name
+ORDER BY total DESC, ct.name ASC;
+
+-- name: GetApplicationCountsByMonth :many
+-- Get application counts per applied_date month for a specific user, from a given date on (months without applications are omitted)
+SELECT date_trunc('month', applied_date)::date AS month,
+       COUNT(*) AS count
+FROM applications
+WHERE user_id = $1 AND applied_date >= $2
+GROUP BY month
+ORDER BY month ASC;
+
+-- name: GetClosedApplicationCounts :one
+-- Get how many of a user's applications are rejected or withdrawn, and how many of those have a closed_reason
+SELECT COUNT(*) AS closed,
+       COUNT(*) FILTER (WHERE closed_reason IS NOT NULL AND closed_reason <> '') AS with_reason
+FROM applications
+WHERE user_id = $1 AND status IN ('rejected', 'withdrawn');
+
+-- name: GetClosedReasonCounts :many
+-- Get the most common closed_reason values of a user's rejected/withdrawn applications
+-- Reasons are normalized (lowercased, whitespace collapsed, trailing periods dropped) so near-duplicates group together
+SELECT RTRIM(LOWER(regexp_replace(TRIM(closed_reason), '\s+', ' ', 'g')), '.')::text AS reason,
+       COUNT(*) AS count
+FROM applications
+WHERE user_id = $1 AND status IN ('rejected', 'withdrawn')
+  AND closed_reason IS NOT NULL AND closed_reason <> ''
+GROUP BY reason
+ORDER BY count DESC, reason ASC
+LIMIT $2;
+
+-- name: GetApplicationCountsByCompany :many
+-- Get application counts per company for a specific user (through each application's job), most applications first
+-- include_empty also returns companies without applications
+SELECT co.id AS company_id,
+       co.name AS company_name,
+       COUNT(j.id) AS applications
+FROM companies co
+LEFT JOIN jobs j ON j.company_id = co.id
+WHERE co.user_id = sqlc.arg(user_id)
+GROUP BY co.id, co.name
+HAVING sqlc.arg(include_empty)::boolean OR COUNT(j.id) > 0
+ORDER BY applications DESC, co.name ASC, co.id ASC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountCompaniesForApplicationCounts :one
+-- Get how many companies GetApplicationCountsByCompany returns in total (for pagination)
+SELECT COUNT(*) FROM companies co
+WHERE co.user_id = sqlc.arg(user_id)
+  AND (sqlc.arg(include_empty)::boolean OR EXISTS (SELECT 1 FROM jobs j WHERE j.company_id = co.id));
+
+-- name: CountApplicationsAppliedBetween :one
+-- Count a user's applications with an applied_date in [from_date, to_date)
+SELECT COUNT(*) FROM applications
+WHERE user_id = sqlc.arg(user_id)
+  AND applied_date >= sqlc.arg(from_date)::date AND applied_date < sqlc.arg(to_date)::date;
+
+-- name: CountInterviewsScheduledBetween :one
+-- Count a user's interviews scheduled in [from_time, to_time) (through each interview's application)
+SELECT COUNT(*) FROM interviews i
+JOIN applications a ON a.id = i.application_id
+WHERE a.user_id = sqlc.arg(user_id)
+  AND i.scheduled_at >= sqlc.arg(from_time)::timestamp AND i.scheduled_at < sqlc.arg(to_time)::timestamp;
@@ -0,0 +1,50 @@
+-- name: GetTagsByUserID :many
+-- Get all tags for a specific user, ordered by name
+SELECT * FROM tags
+WHERE user_id = $1
+ORDER BY LOWER(name) ASC;
+
+-- name: GetTagByIDAndUserID :one
+-- Get a single tag by ID and user_id (ownership verification)
+SELECT * FROM tags
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetTagsByApplicationIDAndUserID :many
+-- Get the tags attached to an application (verifies ownership through the application's user_id)
+SELECT t.* FROM tags t
+JOIN application_tags apt ON apt.tag_id = t.id
+JOIN applications a ON a.id = apt.application_id
+WHERE apt.application_id = $1 AND a.user_id = $2
+ORDER BY LOWER(t.name) ASC;
+
+-- name: CreateTag :one
+-- Create a new tag and return the created record
+INSERT INTO tags (name, user_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteTag :exec
+-- Delete a tag by ID (verifies ownership via user_id); detaches it from all applications
+DELETE FROM tags
+WHERE id = $1 AND user_id = $2;
+
+-- name: AttachTagToApplications :many
+-- Attach a tag to the user's applications among the given IDs and return the newly tagged IDs
+-- IDs of other users' applications and already tagged applications are skipped
+-- Ownership of the tag must be verified before calling this
+INSERT INTO application_tags (application_id, tag_id)
+SELECT a.id, sqlc.arg(tag_id)::int FROM applications a
+WHERE a.id = ANY(sqlc.arg(application_ids)::int[]) AND a.user_id = sqlc.arg(user_id)
+ON CONFLICT DO NOTHING
+RETURNING application_id;
+
+-- name: DetachTagFromApplications :many
+-- Detach a tag from the user's applications among the given IDs and return the untagged IDs
+-- IDs of other users' applications and applications without the tag are skipped
+DELETE FROM application_tags
+USING applications a
+WHERE application_tags.application_id = a.id
+  AND application_tags.tag_id = sqlc.arg(tag_id)
+  AND application_tags.application_id = ANY(sqlc.arg(application_ids)::int[])
+  AND a.user_id = sqlc.arg(user_id)
+RETURNING application_tags.application_id;
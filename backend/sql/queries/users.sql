@@ -35,6 +35,15 @@ SET name = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateUserEmailAndName :one
+-- Update a user's email and name (callers check email uniqueness first; the unique index also enforces it)
+UPDATE users
+SET email = $2,
+    name = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateUserLastLogin :exec
 -- Update the last_login timestamp
 UPDATE users
@@ -46,3 +55,20 @@ WHERE id = $1;
 DELETE FROM users
 WHERE id = $1;
 
+
+-- name: GetUserRoleByID :one
+-- Get a user's role ('user' or 'admin')
+SELECT role FROM users
+WHERE id = $1;
+
+-- name: IsUserDisabled :one
+-- Check whether a user was disabled by an admin (checked on every authenticated request)
+SELECT disabled_at IS NOT NULL AS disabled FROM users
+WHERE id = $1;
+
+-- name: LockUserByID :one
+-- Lock a user's row until the transaction ends, so checks on the user's totals (e.g. attachment limits)
+-- and the insert they guard can't interleave with another request of the same user
+SELECT id FROM users
+WHERE id = $1
+FOR UPDATE;
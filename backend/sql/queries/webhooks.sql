@@ -0,0 +1,76 @@
+-- name: GetWebhooksByUserID :many
+-- Get all webhooks for a specific user, oldest first
+SELECT * FROM webhooks
+WHERE user_id = $1
+ORDER BY id ASC;
+
+-- name: GetWebhookByIDAndUserID :one
+-- Get a single webhook by ID and user_id (ownership verification)
+SELECT * FROM webhooks
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateWebhook :one
+-- Create a new webhook and return the created record
+INSERT INTO webhooks (url, secret, user_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+-- Delete a webhook by ID (verifies ownership via user_id); its deliveries are deleted with it
+DELETE FROM webhooks
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateWebhookDelivery :one
+-- Record a pending delivery of an event to a webhook
+INSERT INTO webhook_deliveries (webhook_id, event, payload, signature)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhookDeliveryTarget :one
+-- Get what the delivery worker needs to send a delivery: its payload, signature, progress and the webhook URL
+SELECT d.id, d.event, d.payload, d.signature, d.status, d.attempts, w.url
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+WHERE d.id = $1;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+-- Record the outcome of one delivery attempt and count it
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    response_status = $3,
+    last_error = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetPendingWebhookDeliveryIDs :many
+-- Get the IDs of deliveries still waiting for an attempt (e.g. queued before a restart)
+SELECT id FROM webhook_deliveries
+WHERE status = 'pending'
+ORDER BY id ASC;
+
+-- name: GetWebhookDeliveriesByWebhookIDAndStatus :many
+-- Get a webhook's deliveries with the given status, newest first
+-- Ownership of the webhook must be verified before calling this
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1 AND status = $2
+ORDER BY id DESC
+LIMIT $3;
+
+-- name: GetWebhookDeliveryByIDAndWebhookID :one
+-- Get a single delivery of a webhook
+-- Ownership of the webhook must be verified before calling this
+SELECT * FROM webhook_deliveries
+WHERE id = $1 AND webhook_id = $2;
+
+-- name: RedeliverWebhookDelivery :one
+-- Reset a failed delivery to pending with a fresh set of attempts
+-- Returns no rows if the delivery does not exist or has not failed
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    response_status = NULL,
+    last_error = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND webhook_id = $2 AND status = 'failed'
+RETURNING *;
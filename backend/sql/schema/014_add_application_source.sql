@@ -0,0 +1,8 @@
+-- +goose Up
+-- Track where each application was found (LinkedIn, referral, company site, ...)
+ALTER TABLE applications ADD COLUMN source VARCHAR(50);
+CREATE INDEX applications_source_idx ON applications(user_id, source);
+
+-- +goose Down
+DROP INDEX IF EXISTS applications_source_idx;
+ALTER TABLE applications DROP COLUMN IF EXISTS source;
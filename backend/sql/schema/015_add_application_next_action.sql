@@ -0,0 +1,10 @@
+-- +goose Up
+-- Next concrete to-do for an application (e.g. "send thank-you note") and when it is due
+ALTER TABLE applications ADD COLUMN next_action TEXT;
+ALTER TABLE applications ADD COLUMN next_action_due DATE;
+CREATE INDEX applications_next_action_due_idx ON applications(user_id, next_action_due) WHERE next_action_due IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS applications_next_action_due_idx;
+ALTER TABLE applications DROP COLUMN IF EXISTS next_action_due;
+ALTER TABLE applications DROP COLUMN IF EXISTS next_action;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Optional company metadata for filtering and stats
+ALTER TABLE companies ADD COLUMN industry VARCHAR(100);
+ALTER TABLE companies ADD COLUMN size VARCHAR(20);
+CREATE INDEX companies_industry_idx ON companies(user_id, LOWER(industry));
+
+-- +goose Down
+DROP INDEX IF EXISTS companies_industry_idx;
+ALTER TABLE companies DROP COLUMN IF EXISTS size;
+ALTER TABLE companies DROP COLUMN IF EXISTS industry;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Create documents table (links to documents stored elsewhere, e.g. a Google Doc resume or portfolio)
+CREATE TABLE documents (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    label VARCHAR(255) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    type VARCHAR(50) NOT NULL DEFAULT 'other',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for better query performance
+CREATE INDEX documents_application_id_idx ON documents(application_id);
+
+-- +goose Down
+-- Drop documents table
+DROP TABLE IF EXISTS documents;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Employment type of a job, for filtering (full-time vs contract vs internship ...)
+ALTER TABLE jobs ADD COLUMN employment_type VARCHAR(20)
+    CHECK (employment_type IN ('full_time', 'part_time', 'contract', 'internship', 'temporary'));
+CREATE INDEX jobs_employment_type_idx ON jobs(employment_type);
+
+-- +goose Down
+DROP INDEX IF EXISTS jobs_employment_type_idx;
+ALTER TABLE jobs DROP COLUMN IF EXISTS employment_type;
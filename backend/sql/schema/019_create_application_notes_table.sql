@@ -0,0 +1,16 @@
+-- +goose Up
+-- Create application_notes table (timestamped journal entries per application)
+CREATE TABLE application_notes (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for better query performance
+CREATE INDEX application_notes_application_id_idx ON application_notes(application_id, created_at);
+
+-- +goose Down
+-- Drop application_notes table
+DROP TABLE IF EXISTS application_notes;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Create tags table (user-defined labels for categorizing applications)
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tag names are unique per user (case-insensitive)
+CREATE UNIQUE INDEX tags_user_id_name_idx ON tags(user_id, LOWER(name));
+
+-- Create application_tags join table
+CREATE TABLE application_tags (
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (application_id, tag_id)
+);
+
+-- Create index for looking up applications by tag
+CREATE INDEX application_tags_tag_id_idx ON application_tags(tag_id);
+
+-- +goose Down
+-- Drop tag tables
+DROP TABLE IF EXISTS application_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Offer details of an application, recorded once it reaches "offer" (or "accepted")
+-- offer_salary is in whole units of offer_currency (ISO 4217 code, e.g. USD)
+ALTER TABLE applications ADD COLUMN offer_salary BIGINT CHECK (offer_salary >= 0);
+ALTER TABLE applications ADD COLUMN offer_currency VARCHAR(3);
+ALTER TABLE applications ADD COLUMN offer_received_date DATE;
+ALTER TABLE applications ADD COLUMN decision VARCHAR(20)
+    CHECK (decision IN ('accepted', 'declined', 'pending'));
+
+-- +goose Down
+ALTER TABLE applications DROP COLUMN IF EXISTS decision;
+ALTER TABLE applications DROP COLUMN IF EXISTS offer_received_date;
+ALTER TABLE applications DROP COLUMN IF EXISTS offer_currency;
+ALTER TABLE applications DROP COLUMN IF EXISTS offer_salary;
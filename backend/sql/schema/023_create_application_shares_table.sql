@@ -0,0 +1,18 @@
+-- +goose Up
+-- Create application_shares table (read-only public links to a single application)
+-- Only a SHA-256 hash of the token is stored; the token itself is shown once when the link is created
+CREATE TABLE application_shares (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    include_notes BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for revoking an application's links
+CREATE INDEX application_shares_application_id_idx ON application_shares(application_id);
+
+-- +goose Down
+-- Drop application_shares table
+DROP TABLE IF EXISTS application_shares;
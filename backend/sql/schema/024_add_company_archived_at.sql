@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add archived_at to companies (set when the user archives a company they are no longer pursuing)
+-- Archived companies are hidden from the default company lists
+ALTER TABLE companies ADD COLUMN archived_at TIMESTAMP;
+
+-- +goose Down
+-- Remove archived_at from companies
+ALTER TABLE companies DROP COLUMN IF EXISTS archived_at;
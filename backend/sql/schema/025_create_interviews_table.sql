@@ -0,0 +1,22 @@
+-- +goose Up
+-- Create interviews table (the interview rounds of an application)
+-- outcome stays 'pending' until the round is completed and its result is known
+CREATE TABLE interviews (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    scheduled_at TIMESTAMP,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (outcome IN ('passed', 'failed', 'pending')),
+    feedback TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for better query performance
+CREATE INDEX interviews_application_id_idx ON interviews(application_id, completed, scheduled_at);
+
+-- +goose Down
+-- Drop interviews table
+DROP TABLE IF EXISTS interviews;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- Create application_status_history table (one row per status an application has been in)
+CREATE TABLE application_status_history (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for better query performance
+CREATE INDEX application_status_history_application_id_idx ON application_status_history(application_id, changed_at);
+
+-- Seed the current status of existing applications; when it was reached is unknown, so use the last update
+INSERT INTO application_status_history (application_id, status, changed_at)
+SELECT id, status, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+FROM applications;
+
+-- Record the status on create and whenever it changes, whichever code path writes it
+-- +goose StatementBegin
+CREATE FUNCTION record_application_status_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO application_status_history (application_id, status)
+        VALUES (NEW.id, NEW.status);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER applications_status_history
+AFTER INSERT OR UPDATE OF status ON applications
+FOR EACH ROW EXECUTE FUNCTION record_application_status_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS applications_status_history ON applications;
+DROP FUNCTION IF EXISTS record_application_status_change();
+DROP TABLE IF EXISTS application_status_history;
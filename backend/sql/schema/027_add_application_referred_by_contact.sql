@@ -0,0 +1,10 @@
+-- +goose Up
+-- The contact who referred the user for an application (distinct from the general contact_id link)
+ALTER TABLE applications ADD COLUMN referred_by_contact_id INTEGER REFERENCES contacts(id) ON DELETE SET NULL;
+
+-- Create index for listing a contact's referrals
+CREATE INDEX applications_referred_by_contact_id_idx ON applications(referred_by_contact_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS applications_referred_by_contact_id_idx;
+ALTER TABLE applications DROP COLUMN IF EXISTS referred_by_contact_id;
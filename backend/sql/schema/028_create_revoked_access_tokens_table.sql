@@ -0,0 +1,19 @@
+-- +goose Up
+-- Create revoked_access_tokens table (denylist of access token jti claims revoked before expiry)
+-- Only consulted when ACCESS_TOKEN_DENYLIST=true; rows are pruned once the token has expired
+CREATE TABLE revoked_access_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for pruning expired entries
+CREATE INDEX revoked_access_tokens_expires_at_idx ON revoked_access_tokens(expires_at);
+
+-- +goose Down
+-- Drop index
+DROP INDEX IF EXISTS revoked_access_tokens_expires_at_idx;
+
+-- Drop revoked_access_tokens table
+DROP TABLE IF EXISTS revoked_access_tokens;
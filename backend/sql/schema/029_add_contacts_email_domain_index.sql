@@ -0,0 +1,6 @@
+-- +goose Up
+-- Index contacts by the domain of their email so ?email_domain= lookups stay fast
+CREATE INDEX contacts_email_domain_idx ON contacts(user_id, LOWER(split_part(email, '@', 2)));
+
+-- +goose Down
+DROP INDEX IF EXISTS contacts_email_domain_idx;
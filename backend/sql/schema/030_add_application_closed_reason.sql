@@ -0,0 +1,6 @@
+-- +goose Up
+-- Why an application ended, recorded when its status is rejected or withdrawn (free text, optional)
+ALTER TABLE applications ADD COLUMN closed_reason VARCHAR(500);
+
+-- +goose Down
+ALTER TABLE applications DROP COLUMN IF EXISTS closed_reason;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- The contact's role in the user's search (e.g. recruiter, hiring_manager); NULL when unknown
+ALTER TABLE contacts ADD COLUMN role VARCHAR(50);
+
+-- Create index for ?role= filtering
+CREATE INDEX contacts_user_id_role_idx ON contacts(user_id, role);
+
+-- +goose Down
+DROP INDEX IF EXISTS contacts_user_id_role_idx;
+ALTER TABLE contacts DROP COLUMN IF EXISTS role;
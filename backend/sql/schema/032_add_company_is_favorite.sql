@@ -0,0 +1,10 @@
+-- +goose Up
+-- Starred "dream" companies, listed first when ?favorites_first=true
+ALTER TABLE companies ADD COLUMN is_favorite BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create index for ?favorite= filtering
+CREATE INDEX companies_user_id_is_favorite_idx ON companies(user_id, is_favorite);
+
+-- +goose Down
+DROP INDEX IF EXISTS companies_user_id_is_favorite_idx;
+ALTER TABLE companies DROP COLUMN IF EXISTS is_favorite;
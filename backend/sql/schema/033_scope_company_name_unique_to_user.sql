@@ -0,0 +1,12 @@
+-- +goose Up
+-- Company names are unique per user (normalized), not across all users;
+-- this index is also the conflict target of UpsertCompany
+DROP INDEX IF EXISTS companies_name_normalized_idx;
+CREATE UNIQUE INDEX companies_user_id_name_normalized_idx
+ON companies (user_id, LOWER(TRIM(name)));
+
+-- +goose Down
+-- Fails if two users have a company with the same normalized name
+DROP INDEX IF EXISTS companies_user_id_name_normalized_idx;
+CREATE UNIQUE INDEX companies_name_normalized_idx
+ON companies (LOWER(TRIM(name)));
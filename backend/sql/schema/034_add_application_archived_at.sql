@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add archived_at to applications (set when the user archives an application, e.g. after a job search wraps up)
+-- Archived applications are hidden from the default application lists and reminders but kept for history and stats
+ALTER TABLE applications ADD COLUMN archived_at TIMESTAMP;
+
+-- +goose Down
+-- Remove archived_at from applications
+ALTER TABLE applications DROP COLUMN IF EXISTS archived_at;
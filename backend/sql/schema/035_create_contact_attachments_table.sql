@@ -0,0 +1,19 @@
+-- +goose Up
+-- Create contact_attachments table (files uploaded to a contact, e.g. a scanned business card)
+-- The file itself is stored in data; size_bytes is kept alongside so listings don't read it
+CREATE TABLE contact_attachments (
+    id SERIAL PRIMARY KEY,
+    contact_id INTEGER NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for better query performance
+CREATE INDEX contact_attachments_contact_id_idx ON contact_attachments(contact_id);
+
+-- +goose Down
+-- Drop contact_attachments table
+DROP TABLE IF EXISTS contact_attachments;
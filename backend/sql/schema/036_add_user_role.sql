@@ -0,0 +1,8 @@
+-- +goose Up
+-- The user's role: 'admin' users can reach the /api/admin routes; everyone else is a 'user'
+-- Promote the first admin by hand: UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'admin'));
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS role;
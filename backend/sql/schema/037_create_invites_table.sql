@@ -0,0 +1,16 @@
+-- +goose Up
+-- Create invites table (single-use codes that let a new user sign up while registration is disabled)
+-- expires_at NULL means the code never expires; used_by/used_at are set when the code is redeemed
+CREATE TABLE invites (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    used_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    used_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- Drop invites table
+DROP TABLE IF EXISTS invites;
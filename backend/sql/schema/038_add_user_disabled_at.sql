@@ -0,0 +1,6 @@
+-- +goose Up
+-- When an admin disabled the user (NULL = active); disabled users' requests are rejected but their data is kept
+ALTER TABLE users ADD COLUMN disabled_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- The cover letter sent with the application (plain text, optional); the app caps it at 50000 characters
+ALTER TABLE applications ADD COLUMN cover_letter TEXT;
+
+-- +goose Down
+ALTER TABLE applications DROP COLUMN IF EXISTS cover_letter;
@@ -0,0 +1,60 @@
+-- +goose Up
+-- When the user last worked on an application: the application itself (including its status), its job or its notes changed
+ALTER TABLE applications ADD COLUMN last_activity_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- Backfill from the latest change already recorded on the application, its job and its notes
+UPDATE applications a SET last_activity_at = GREATEST(
+    COALESCE(a.updated_at, a.created_at),
+    (SELECT MAX(COALESCE(j.updated_at, j.created_at)) FROM jobs j WHERE j.application_id = a.id),
+    (SELECT MAX(COALESCE(n.updated_at, n.created_at)) FROM application_notes n WHERE n.application_id = a.id)
+);
+
+-- Create index for ?sort=last_activity
+CREATE INDEX applications_user_id_last_activity_at_idx ON applications(user_id, last_activity_at DESC);
+
+-- Bump last_activity_at on every update of the application, whichever code path writes it
+-- +goose StatementBegin
+CREATE FUNCTION touch_application_last_activity() RETURNS trigger AS $$
+BEGIN
+    NEW.last_activity_at := CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER applications_last_activity
+BEFORE UPDATE ON applications
+FOR EACH ROW EXECUTE FUNCTION touch_application_last_activity();
+
+-- Bump the parent application when its job or notes are created, changed or deleted
+-- A job moved to another application touches both
+-- +goose StatementBegin
+CREATE FUNCTION touch_parent_application_last_activity() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'DELETE' THEN
+        UPDATE applications SET last_activity_at = CURRENT_TIMESTAMP WHERE id = NEW.application_id;
+    END IF;
+    IF TG_OP = 'DELETE' OR NEW.application_id IS DISTINCT FROM OLD.application_id THEN
+        UPDATE applications SET last_activity_at = CURRENT_TIMESTAMP WHERE id = OLD.application_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_application_last_activity
+AFTER INSERT OR UPDATE OR DELETE ON jobs
+FOR EACH ROW EXECUTE FUNCTION touch_parent_application_last_activity();
+
+CREATE TRIGGER application_notes_application_last_activity
+AFTER INSERT OR UPDATE OR DELETE ON application_notes
+FOR EACH ROW EXECUTE FUNCTION touch_parent_application_last_activity();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS application_notes_application_last_activity ON application_notes;
+DROP TRIGGER IF EXISTS jobs_application_last_activity ON jobs;
+DROP FUNCTION IF EXISTS touch_parent_application_last_activity();
+DROP TRIGGER IF EXISTS applications_last_activity ON applications;
+DROP FUNCTION IF EXISTS touch_application_last_activity();
+DROP INDEX IF EXISTS applications_user_id_last_activity_at_idx;
+ALTER TABLE applications DROP COLUMN IF EXISTS last_activity_at;
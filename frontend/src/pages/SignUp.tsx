@@ -1,9 +1,26 @@
 // Sign-up page using Clerk
 
+import { useState } from 'react'
 import { SignUp } from '@clerk/clerk-react'
-import { Link } from 'react-router-dom'
+import { Link, useSearchParams } from 'react-router-dom'
+import { getInviteCode, setInviteCode } from '../services/invite'
 
 export default function SignUpPage() {
+  // Invite links may carry the code as ?invite=...; it is sent with the first API call after sign-up
+  const [searchParams] = useSearchParams()
+  const [inviteCode, setInviteCodeValue] = useState(() => {
+    const fromLink = searchParams.get('invite')
+    if (fromLink) {
+      setInviteCode(fromLink)
+    }
+    return fromLink ?? getInviteCode() ?? ''
+  })
+
+  const handleInviteCodeChange = (code: string) => {
+    setInviteCodeValue(code)
+    setInviteCode(code)
+  }
+
   return (
     <div className="min-h-screen flex flex-col items-center justify-center bg-gray-50 dark:bg-gray-900 py-12 px-4 sm:px-6 lg:px-8">
       <div className="mb-4 w-full max-w-md">
@@ -14,6 +31,21 @@ export default function SignUpPage() {
           ← Back to home
         </Link>
       </div>
+      <div className="mb-4 w-full max-w-md">
+        <label htmlFor="invite-code" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
+          Invite code (optional)
+        </label>
+        <input
+          type="text"
+          id="invite-code"
+          name="invite-code"
+          value={inviteCode}
+          onChange={(e) => handleInviteCodeChange(e.target.value)}
+          className="w-full px-3 py-2 border border-gray-300 dark:border-gray-600 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500 bg-white dark:bg-gray-800 text-gray-900 dark:text-white"
+          placeholder="Only needed on invite-only instances"
+          autoComplete="off"
+        />
+      </div>
       <SignUp
         signInUrl="/sign-in"
         fallbackRedirectUrl="/dashboard"
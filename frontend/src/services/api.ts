@@ -55,8 +55,9 @@ export const companiesAPI = {
     })
     return transformCompany(company)
   },
+  // The UI confirms before deleting, so also delete companies that still have jobs
   delete: (id: number) =>
-    fetchAPI<void>(`/companies/${id}`, {
+    fetchAPI<void>(`/companies/${id}?confirm=true`, {
       method: 'DELETE',
     }),
   getJobs: async (companyId: number): Promise<Job[]> => {
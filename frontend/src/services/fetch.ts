@@ -1,6 +1,7 @@
 // Shared fetch utility – uses Clerk session token for API auth
 
 import { getClerkToken } from './auth'
+import { INVITE_CODE_HEADER, clearInviteCode, getInviteCode } from './invite'
 
 const API_BASE_URL = '/api'
 
@@ -34,6 +35,11 @@ export async function fetchAPI<T>(
   if (token && shouldAddAuthHeader) {
     headers['Authorization'] = `Bearer ${token}`
   }
+  // A pending invite code lets a new user register on an invite-only instance
+  const inviteCode = token && shouldAddAuthHeader ? getInviteCode() : null
+  if (inviteCode) {
+    headers[INVITE_CODE_HEADER] = inviteCode
+  }
 
   const response = await fetch(`${API_BASE_URL}${endpoint}`, {
     ...options,
@@ -50,6 +56,11 @@ export async function fetchAPI<T>(
     throw err
   }
 
+  // The user row exists now, so the invite code is used (or was not needed)
+  if (inviteCode) {
+    clearInviteCode()
+  }
+
   return response.json()
 }
 
@@ -0,0 +1,37 @@
+// Invite code for signing up on an invite-only instance (REGISTRATION_ENABLED=false)
+// Kept until the first authenticated API call, which creates the user row and uses the code
+
+const INVITE_CODE_KEY = 'inviteCode'
+
+/** Header the backend reads the invite code from */
+export const INVITE_CODE_HEADER = 'X-Invite-Code'
+
+// localStorage so the code survives Clerk's verification and OAuth redirects
+export function getInviteCode(): string | null {
+  try {
+    return localStorage.getItem(INVITE_CODE_KEY)
+  } catch {
+    return null
+  }
+}
+
+export function setInviteCode(code: string) {
+  try {
+    const trimmed = code.trim()
+    if (trimmed) {
+      localStorage.setItem(INVITE_CODE_KEY, trimmed)
+    } else {
+      localStorage.removeItem(INVITE_CODE_KEY)
+    }
+  } catch (error) {
+    console.error('Failed to save invite code:', error)
+  }
+}
+
+export function clearInviteCode() {
+  try {
+    localStorage.removeItem(INVITE_CODE_KEY)
+  } catch (error) {
+    console.error('Failed to clear invite code:', error)
+  }
+}